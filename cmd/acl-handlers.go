@@ -18,11 +18,13 @@
 package cmd
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"io"
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/minio/madmin-go"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/pkg/bucket/policy"
@@ -40,6 +42,23 @@ type grantee struct {
 	URI         string `xml:"URI,omitempty"`
 }
 
+// UnmarshalXML decodes the grantee type from the xsi:type attribute,
+// the decoder resolves its namespace prefix so it cannot be matched by tag.
+func (g *grantee) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type granteeWrapper grantee
+	var gw granteeWrapper
+	if err := d.DecodeElement(&gw, &start); err != nil {
+		return err
+	}
+	*g = grantee(gw)
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "type" {
+			g.XMLXSI = attr.Value
+		}
+	}
+	return nil
+}
+
 type grant struct {
 	Grantee    grantee `xml:"Grantee"`
 	Permission string  `xml:"Permission"`
@@ -166,9 +185,8 @@ func (api objectAPIHandlers) GetBucketACLHandler(w http.ResponseWriter, r *http.
 
 // PutObjectACLHandler - PUT Object ACL
 // -----------------
-// This operation uses the ACL subresource
-// to set ACL for a bucket, this is a dummy call
-// only responds success if the ACL is private.
+// This operation uses the ACL subresource to set the ACL of an
+// object, either as a canned ACL or as an access control policy.
 func (api objectAPIHandlers) PutObjectACLHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "PutObjectACL")
 
@@ -188,43 +206,100 @@ func (api objectAPIHandlers) PutObjectACLHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	// Allow putObjectACL if policy action is set, since this is a dummy call
-	// we are simply re-purposing the bucketPolicyAction.
+	// Allow putObjectACL if policy action is set, object ACLs are
+	// managed with the same permission as the bucket policy.
 	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
 		return
 	}
 
+	opts, err := getOpts(ctx, r, bucket, object)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
 	// Before proceeding validate if object exists.
-	_, err = objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, opts)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
 
-	aclHeader := r.Header.Get(xhttp.AmzACL)
-	if aclHeader == "" {
+	var cannedACL, grantsData string
+	if aclHeader := r.Header.Get(xhttp.AmzACL); aclHeader != "" {
+		if !isValidCannedACL(aclHeader) {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidCannedACL), r.URL)
+			return
+		}
+		if isPublicCannedACL(aclHeader) {
+			cannedACL = aclHeader
+		}
+	} else {
 		acl := &accessControlPolicy{}
 		if err = xmlDecoder(r.Body, acl, r.ContentLength); err != nil {
-			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			if err == io.EOF {
+				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingSecurityHeader), r.URL)
+				return
+			}
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMalformedACL), r.URL)
 			return
 		}
 
-		if len(acl.AccessControlList.Grants) == 0 {
-			writeErrorResponse(ctx, w, toAPIError(ctx, NotImplemented{}), r.URL)
+		grants, s3Error := parseACLGrants(acl)
+		if s3Error != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
 			return
 		}
-
-		if acl.AccessControlList.Grants[0].Permission != "FULL_CONTROL" {
-			writeErrorResponse(ctx, w, toAPIError(ctx, NotImplemented{}), r.URL)
-			return
+		if len(grants) > 0 {
+			data, err := json.Marshal(grants)
+			if err != nil {
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+				return
+			}
+			grantsData = string(data)
 		}
 	}
 
-	if aclHeader != "" && aclHeader != "private" {
-		writeErrorResponse(ctx, w, toAPIError(ctx, NotImplemented{}), r.URL)
+	// A private ACL on an object without a stored ACL is a no-op,
+	// which succeeds on every backend.
+	if cannedACL == "" && grantsData == "" &&
+		objInfo.UserDefined[cannedACLKey] == "" && objInfo.UserDefined[aclGrantsKey] == "" {
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+
+	// Object ACLs are stored in the object metadata, which
+	// only the erasure backends can update in place.
+	if objAPI.BackendInfo().Type != madmin.Erasure {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
 		return
 	}
+
+	popts := ObjectOptions{
+		MTime:     objInfo.ModTime,
+		VersionID: objInfo.VersionID,
+		EvalMetadataFn: func(oi ObjectInfo) error {
+			// Empty values clear the previous ACL of the object.
+			if cannedACL != "" {
+				oi.UserDefined[cannedACLKey] = cannedACL
+			} else {
+				delete(oi.UserDefined, cannedACLKey)
+			}
+			if grantsData != "" {
+				oi.UserDefined[aclGrantsKey] = grantsData
+			} else {
+				delete(oi.UserDefined, aclGrantsKey)
+			}
+			return nil
+		},
+	}
+	if _, err = objAPI.PutObjectMetadata(ctx, bucket, object, popts); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
 }
 
 // GetObjectACLHandler - GET Object ACL
@@ -250,29 +325,32 @@ func (api objectAPIHandlers) GetObjectACLHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	// Allow getObjectACL if policy action is set, since this is a dummy call
-	// we are simply re-purposing the bucketPolicyAction.
+	// Allow getObjectACL if policy action is set, object ACLs are
+	// managed with the same permission as the bucket policy.
 	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketPolicyAction, bucket, ""); s3Error != ErrNone {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
 		return
 	}
 
+	opts, err := getOpts(ctx, r, bucket, object)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
 	// Before proceeding validate if object exists.
-	_, err = objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, opts)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
 
 	acl := &accessControlPolicy{}
-	acl.AccessControlList.Grants = append(acl.AccessControlList.Grants, grant{
-		Grantee: grantee{
-			XMLNS:  "http://www.w3.org/2001/XMLSchema-instance",
-			XMLXSI: "CanonicalUser",
-			Type:   "CanonicalUser",
-		},
-		Permission: "FULL_CONTROL",
-	})
+	acl.Owner = Owner{
+		ID:          globalMinioDefaultOwnerID,
+		DisplayName: "minio",
+	}
+	acl.AccessControlList.Grants = objectACLGrants(objInfo)
 	if err := xml.NewEncoder(w).Encode(acl); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
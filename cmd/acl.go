@@ -0,0 +1,278 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/pkg/bucket/policy"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// Canned ACLs as per
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/acl-overview.html#canned-acl
+const (
+	cannedACLPrivate                = "private"
+	cannedACLPublicRead             = "public-read"
+	cannedACLPublicReadWrite        = "public-read-write"
+	cannedACLAWSExecRead            = "aws-exec-read"
+	cannedACLAuthenticatedRead      = "authenticated-read"
+	cannedACLBucketOwnerRead        = "bucket-owner-read"
+	cannedACLBucketOwnerFullControl = "bucket-owner-full-control"
+	cannedACLLogDeliveryWrite       = "log-delivery-write"
+)
+
+// Internal object metadata keys holding the object ACL, cannedACLKey
+// keeps public canned ACLs only and aclGrantsKey the JSON encoded grants.
+const (
+	cannedACLKey = ReservedMetadataPrefixLower + "acl"
+	aclGrantsKey = ReservedMetadataPrefixLower + "acl-grants"
+)
+
+// ACL grant permissions.
+const (
+	aclPermissionFullControl = "FULL_CONTROL"
+	aclPermissionRead        = "READ"
+	aclPermissionWrite       = "WRITE"
+	aclPermissionReadACP     = "READ_ACP"
+	aclPermissionWriteACP    = "WRITE_ACP"
+)
+
+// ACL grantee types and the supported predefined groups.
+const (
+	granteeCanonicalUser         = "CanonicalUser"
+	granteeGroup                 = "Group"
+	granteeAmazonCustomerByEmail = "AmazonCustomerByEmail"
+
+	granteeAllUsers           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	granteeAuthenticatedUsers = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+)
+
+// isValidCannedACL returns true if acl is a known canned ACL. Canned ACLs
+// other than public-read and public-read-write grant no access beyond the
+// IAM and bucket policies and are treated as private.
+func isValidCannedACL(acl string) bool {
+	switch acl {
+	case cannedACLPrivate, cannedACLPublicRead, cannedACLPublicReadWrite,
+		cannedACLAWSExecRead, cannedACLAuthenticatedRead, cannedACLBucketOwnerRead,
+		cannedACLBucketOwnerFullControl, cannedACLLogDeliveryWrite:
+		return true
+	}
+	return false
+}
+
+// isPublicCannedACL returns true if acl grants anonymous access.
+func isPublicCannedACL(acl string) bool {
+	return acl == cannedACLPublicRead || acl == cannedACLPublicReadWrite
+}
+
+// cannedACLBucketPolicy returns the bucket policy granting anonymous
+// users the access given by a public canned ACL.
+func cannedACLBucketPolicy(bucket, acl string) *policy.Policy {
+	bucketActions := policy.NewActionSet(policy.GetBucketLocationAction, policy.ListBucketAction)
+	objectActions := policy.NewActionSet(policy.GetObjectAction)
+	if acl == cannedACLPublicReadWrite {
+		bucketActions.Add(policy.ListBucketMultipartUploadsAction)
+		objectActions.Add(policy.PutObjectAction)
+		objectActions.Add(policy.DeleteObjectAction)
+		objectActions.Add(policy.AbortMultipartUploadAction)
+		objectActions.Add(policy.ListMultipartUploadPartsAction)
+	}
+
+	return &policy.Policy{
+		Version: policy.DefaultVersion,
+		Statements: []policy.Statement{
+			policy.NewStatement("", policy.Allow, policy.NewPrincipal("*"),
+				bucketActions, policy.NewResourceSet(policy.NewResource(bucket, "")), nil),
+			policy.NewStatement("", policy.Allow, policy.NewPrincipal("*"),
+				objectActions, policy.NewResourceSet(policy.NewResource(bucket, "*")), nil),
+		},
+	}
+}
+
+// setBucketCannedACL stores the bucket policy equivalent of a public
+// canned ACL, anonymous requests are evaluated against it from then on.
+func setBucketCannedACL(ctx context.Context, bucket, acl string) error {
+	if !isPublicCannedACL(acl) {
+		return nil
+	}
+
+	configData, err := json.Marshal(cannedACLBucketPolicy(bucket, acl))
+	if err != nil {
+		return err
+	}
+
+	updatedAt, err := globalBucketMetadataSys.Update(ctx, bucket, bucketPolicyConfig, configData)
+	if err != nil {
+		return err
+	}
+
+	// Call site replication hook.
+	return globalSiteReplicationSys.BucketMetaHook(ctx, madmin.SRBucketMeta{
+		Type:      madmin.SRBucketMetaTypePolicy,
+		Bucket:    bucket,
+		Policy:    configData,
+		UpdatedAt: updatedAt,
+	})
+}
+
+// aclPermissionForAction returns the ACL permission granting action
+// on an object, or an empty string if ACLs do not apply to it. Only
+// READ is enforced, the other permissions are stored and returned by
+// GetObjectACL but grant nothing beyond the IAM and bucket policies.
+func aclPermissionForAction(action policy.Action) string {
+	if action == policy.GetObjectAction {
+		return aclPermissionRead
+	}
+	return ""
+}
+
+// isObjectACLApplicable returns true if object ACL grants may be
+// consulted for the IAM user in args. Temporary and service account
+// credentials stay restricted to their policies, and an explicit deny
+// in the user's policies is never overridden by an ACL grant.
+func isObjectACLApplicable(args iampolicy.Args) bool {
+	if newGlobalAuthZPluginFn() != nil {
+		return false
+	}
+
+	if ok, _, err := globalIAMSys.IsTempUser(args.AccountName); err != nil || ok {
+		return false
+	}
+	if ok, _, err := globalIAMSys.IsServiceAccount(args.AccountName); err != nil || ok {
+		return false
+	}
+
+	policies, err := globalIAMSys.PolicyDBGet(args.AccountName, false, args.Groups...)
+	if err != nil {
+		return false
+	}
+
+	args.DenyOnly = true
+	return globalIAMSys.GetCombinedPolicy(policies...).IsAllowed(args)
+}
+
+// isObjectACLAllowed returns true if the ACL of the object grants action
+// to accessKey, an empty accessKey stands for an anonymous request.
+func isObjectACLAllowed(ctx context.Context, r *http.Request, action policy.Action, bucket, object, accessKey string) bool {
+	permission := aclPermissionForAction(action)
+	if permission == "" || object == "" {
+		return false
+	}
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return false
+	}
+
+	opts, err := getOpts(ctx, r, bucket, object)
+	if err != nil {
+		return false
+	}
+
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		return false
+	}
+
+	for _, g := range objectACLGrants(objInfo) {
+		if g.Permission != permission && g.Permission != aclPermissionFullControl {
+			continue
+		}
+		switch g.Grantee.XMLXSI {
+		case granteeGroup:
+			if g.Grantee.URI == granteeAllUsers || (g.Grantee.URI == granteeAuthenticatedUsers && accessKey != "") {
+				return true
+			}
+		case granteeCanonicalUser:
+			if accessKey != "" && g.Grantee.ID == accessKey {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newGrant returns a grant of permission to the grantee identified
+// by id for canonical users or by uri for groups.
+func newGrant(granteeType, id, uri, permission string) grant {
+	return grant{
+		Grantee: grantee{
+			XMLNS:  "http://www.w3.org/2001/XMLSchema-instance",
+			XMLXSI: granteeType,
+			Type:   granteeType,
+			ID:     id,
+			URI:    uri,
+		},
+		Permission: permission,
+	}
+}
+
+// objectACLGrants returns the grants in effect for an object, the
+// owner always has full control in addition to the ACL grants.
+func objectACLGrants(objInfo ObjectInfo) []grant {
+	grants := []grant{newGrant(granteeCanonicalUser, globalMinioDefaultOwnerID, "", aclPermissionFullControl)}
+	if isPublicCannedACL(objInfo.UserDefined[cannedACLKey]) {
+		grants = append(grants, newGrant(granteeGroup, "", granteeAllUsers, aclPermissionRead))
+	}
+	if v := objInfo.UserDefined[aclGrantsKey]; v != "" {
+		var stored []grant
+		if err := json.Unmarshal([]byte(v), &stored); err == nil {
+			grants = append(grants, stored...)
+		}
+	}
+	return grants
+}
+
+// parseACLGrants validates the grantees and permissions of an access
+// control policy and returns the grants to be stored for an object.
+func parseACLGrants(acl *accessControlPolicy) ([]grant, APIErrorCode) {
+	var grants []grant
+	for _, g := range acl.AccessControlList.Grants {
+		switch g.Permission {
+		case aclPermissionFullControl, aclPermissionRead, aclPermissionWrite,
+			aclPermissionReadACP, aclPermissionWriteACP:
+		default:
+			return nil, ErrMalformedACL
+		}
+
+		switch g.Grantee.XMLXSI {
+		case granteeCanonicalUser:
+			if g.Grantee.ID == "" {
+				return nil, ErrMalformedACL
+			}
+			if g.Grantee.ID == globalMinioDefaultOwnerID {
+				// The owner always has full control.
+				continue
+			}
+		case granteeGroup:
+			if g.Grantee.URI != granteeAllUsers && g.Grantee.URI != granteeAuthenticatedUsers {
+				return nil, ErrMalformedACL
+			}
+		case granteeAmazonCustomerByEmail:
+			return nil, ErrNotImplemented
+		default:
+			return nil, ErrMalformedACL
+		}
+		grants = append(grants, newGrant(g.Grantee.XMLXSI, g.Grantee.ID, g.Grantee.URI, g.Permission))
+	}
+	return grants, ErrNone
+}
@@ -41,6 +41,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio-go/v7/pkg/signer"
 	"github.com/minio/minio/internal/auth"
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 const (
@@ -209,6 +210,8 @@ func TestIAMInternalIDPServerSuite(t *testing.T) {
 				suite.TestServiceAccountOpsByAdmin(c)
 				suite.TestServiceAccountOpsByUser(c)
 				suite.TestAddServiceAccountPerms(c)
+				suite.TestObjectACLGrants(c)
+				suite.TestCannedACLPermissions(c)
 				suite.TearDownSuite(c)
 			},
 		)
@@ -305,6 +308,177 @@ func (s *TestSuiteIAM) TestUserCreate(c *check) {
 	}
 }
 
+func (s *TestSuiteIAM) TestObjectACLGrants(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	bucket := getRandomBucketName()
+	err := s.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{})
+	if err != nil {
+		c.Fatalf("bucket create error: %v", err)
+	}
+	object := "object"
+	_, err = s.client.PutObject(ctx, bucket, object, bytes.NewReader([]byte("hello world")), 11, minio.PutObjectOptions{})
+	if err != nil {
+		c.Fatalf("object upload error: %v", err)
+	}
+
+	// 1. Create a user without any policy.
+	accessKey, secretKey := mustGenerateCredentials(c)
+	err = s.adm.SetUser(ctx, accessKey, secretKey, madmin.AccountEnabled)
+	if err != nil {
+		c.Fatalf("Unable to set user: %v", err)
+	}
+	client := s.getUserClient(c, accessKey, secretKey, "")
+	if _, err = client.StatObject(ctx, bucket, object, minio.StatObjectOptions{}); err == nil {
+		c.Fatalf("user was unexpectedly able to read the object")
+	}
+
+	// 2. Grant the user READ on the object.
+	body := []byte(`<AccessControlPolicy xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><AccessControlList><Grant>` +
+		`<Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"><ID>` + accessKey + `</ID></Grantee>` +
+		`<Permission>READ</Permission></Grant></AccessControlList></AccessControlPolicy>`)
+	request, err := newTestSignedRequest(http.MethodPut, getObjectACLURL(s.endPoint, bucket, object),
+		int64(len(body)), bytes.NewReader(body), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err := s.TestSuiteCommon.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// 3. The grant allows the user to read the object, but not to change its ACL.
+	if _, err = client.StatObject(ctx, bucket, object, minio.StatObjectOptions{}); err != nil {
+		c.Fatalf("user could not read the object: %v", err)
+	}
+	if _, err = client.PutObject(ctx, bucket, "other", bytes.NewReader([]byte("hello world")), 11, minio.PutObjectOptions{}); err == nil {
+		c.Fatalf("user was unexpectedly able to upload an object")
+	}
+	request, err = newTestSignedRequest(http.MethodPut, getObjectACLURL(s.endPoint, bucket, object),
+		int64(len(body)), bytes.NewReader(body), accessKey, secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = s.TestSuiteCommon.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusForbidden)
+
+	// 4. Grant READ to all authenticated users, which does not widen
+	// the access of the user's service accounts.
+	body = []byte(`<AccessControlPolicy xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><AccessControlList><Grant>` +
+		`<Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="Group"><URI>http://acs.amazonaws.com/groups/global/AuthenticatedUsers</URI></Grantee>` +
+		`<Permission>READ</Permission></Grant></AccessControlList></AccessControlPolicy>`)
+	request, err = newTestSignedRequest(http.MethodPut, getObjectACLURL(s.endPoint, bucket, object),
+		int64(len(body)), bytes.NewReader(body), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = s.TestSuiteCommon.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	if _, err = client.StatObject(ctx, bucket, object, minio.StatObjectOptions{}); err != nil {
+		c.Fatalf("user could not read the object: %v", err)
+	}
+	cr := c.mustCreateSvcAccount(ctx, accessKey, s.adm)
+	svcClient := s.getUserClient(c, cr.AccessKey, cr.SecretKey, "")
+	if _, err = svcClient.StatObject(ctx, bucket, object, minio.StatObjectOptions{}); err == nil {
+		c.Fatalf("service account was unexpectedly able to read the object")
+	}
+
+	// 5. An explicit deny in the user's policy overrides the grant.
+	policy := "deny-getobject-acl-test"
+	policyBytes := []byte(fmt.Sprintf(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Deny",
+   "Action": [
+    "s3:GetObject"
+   ],
+   "Resource": [
+    "arn:aws:s3:::%s/*"
+   ]
+  }
+ ]
+}`, bucket))
+	err = s.adm.AddCannedPolicy(ctx, policy, policyBytes)
+	if err != nil {
+		c.Fatalf("policy add error: %v", err)
+	}
+	err = s.adm.SetPolicy(ctx, policy, accessKey, false)
+	if err != nil {
+		c.Fatalf("Unable to set policy: %v", err)
+	}
+	if _, err = client.StatObject(ctx, bucket, object, minio.StatObjectOptions{}); err == nil {
+		c.Fatalf("user was unexpectedly able to read the object")
+	}
+}
+
+func (s *TestSuiteIAM) TestCannedACLPermissions(c *check) {
+	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
+	defer cancel()
+
+	bucket := getRandomBucketName()
+	err := s.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{})
+	if err != nil {
+		c.Fatalf("bucket create error: %v", err)
+	}
+
+	// 1. Create a user allowed to create buckets and upload objects.
+	accessKey, secretKey := mustGenerateCredentials(c)
+	err = s.adm.SetUser(ctx, accessKey, secretKey, madmin.AccountEnabled)
+	if err != nil {
+		c.Fatalf("Unable to set user: %v", err)
+	}
+	policy := "create-put-canned-acl-test"
+	policyBytes := []byte(`{
+ "Version": "2012-10-17",
+ "Statement": [
+  {
+   "Effect": "Allow",
+   "Action": [
+    "s3:CreateBucket",
+    "s3:PutObject"
+   ],
+   "Resource": [
+    "arn:aws:s3:::*"
+   ]
+  }
+ ]
+}`)
+	err = s.adm.AddCannedPolicy(ctx, policy, policyBytes)
+	if err != nil {
+		c.Fatalf("policy add error: %v", err)
+	}
+	err = s.adm.SetPolicy(ctx, policy, accessKey, false)
+	if err != nil {
+		c.Fatalf("Unable to set policy: %v", err)
+	}
+
+	// 2. Private uploads are allowed, public ones need the policy permission.
+	client := s.getUserClient(c, accessKey, secretKey, "")
+	_, err = client.PutObject(ctx, bucket, "private", bytes.NewReader([]byte("hello world")), 11, minio.PutObjectOptions{
+		UserMetadata: map[string]string{xhttp.AmzACL: "private"},
+	})
+	if err != nil {
+		c.Fatalf("user could not upload a private object: %v", err)
+	}
+	_, err = client.PutObject(ctx, bucket, "public", bytes.NewReader([]byte("hello world")), 11, minio.PutObjectOptions{
+		UserMetadata: map[string]string{xhttp.AmzACL: "public-read"},
+	})
+	if err == nil {
+		c.Fatalf("user was unexpectedly able to upload a public object")
+	}
+
+	// 3. Creating a public bucket needs the policy permission as well.
+	request, err := newTestRequest(http.MethodPut, getMakeBucketURL(s.endPoint, getRandomBucketName()), 0, nil)
+	c.Assert(err, nil)
+	request.Header.Set(xhttp.AmzACL, "public-read")
+	if s.signer == signerV2 {
+		c.Assert(signRequestV2(request, accessKey, secretKey), nil)
+	} else {
+		c.Assert(signRequestV4(request, accessKey, secretKey), nil)
+	}
+	response, err := s.TestSuiteCommon.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusForbidden)
+}
+
 func (s *TestSuiteIAM) TestUserPolicyEscalationBug(c *check) {
 	ctx, cancel := context.WithTimeout(context.Background(), testDefaultTimeout)
 	defer cancel()
@@ -18,6 +18,7 @@
 package cmd
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	crand "crypto/rand"
@@ -301,6 +302,11 @@ type ServerHTTPStats struct {
 	TotalS3RejectedTime    uint64             `json:"totalS3RejectedTime"`
 	TotalS3RejectedHeader  uint64             `json:"totalS3RejectedHeader"`
 	TotalS3RejectedInvalid uint64             `json:"totalS3RejectedInvalid"`
+	TotalS3RejectedRate    uint64             `json:"totalS3RejectedRate"`
+	TotalS3RejectedMax     uint64             `json:"totalS3RejectedMax"`
+
+	// Number of requests per auth type and outcome.
+	TotalS3AuthOutcomes map[string]map[string]uint64 `json:"totalS3AuthOutcomes"`
 }
 
 // StorageInfoHandler - GET /minio/admin/v3/storageinfo
@@ -2765,6 +2771,62 @@ func (a adminAPIHandlers) InspectDataHandler(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// SignatureDebugHandler - POST /minio/admin/v3/debug/signature
+// ----------
+// Accepts a raw signature V4 signed HTTP request in the body and returns the
+// canonical request and string to sign computed by the server for it, so that
+// they can be compared with the ones computed by the client. The signature is
+// only returned for requests signed with the credentials of the caller.
+func (a adminAPIHandlers) SignatureDebugHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SignatureDebug")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	cred, adminAPIErr := checkAdminRequestAuth(ctx, r, iampolicy.TraceAdminAction, "")
+	if adminAPIErr != ErrNone {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(adminAPIErr), r.URL)
+		return
+	}
+
+	if r.ContentLength > maxEConfigJSONSize || r.ContentLength == -1 {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrEntityTooLarge), r.URL)
+		return
+	}
+
+	req, err := http.ReadRequest(bufio.NewReader(io.LimitReader(r.Body, r.ContentLength)))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+	if err = req.ParseForm(); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidQueryParams), r.URL)
+		return
+	}
+	if !isRequestSignatureV4(req) {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrSignatureVersionNotSupported), r.URL)
+		return
+	}
+
+	signV4Values, computed, s3Err := computeSignatureV4(getContentSha256Cksum(req, serviceS3), req, globalSite.Region, serviceS3)
+	if s3Err != ErrNone {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
+		return
+	}
+
+	// The signature is a credential for the debugged request, never
+	// hand it out for requests signed by someone else.
+	if signV4Values.Credential.accessKey != cred.AccessKey {
+		computed.Signature = ""
+	}
+
+	resp, err := json.Marshal(computed)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, resp)
+}
+
 func createHostAnonymizerForFSMode() map[string]string {
 	hostAnonymizer := map[string]string{
 		globalLocalNodeName: "server1",
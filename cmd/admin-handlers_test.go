@@ -25,7 +25,9 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/http/httputil"
 	"net/url"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -33,6 +35,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/auth"
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 // adminErasureTestBed - encapsulates subsystems that need to be setup for
@@ -294,6 +297,70 @@ func TestAdminServerInfo(t *testing.T) {
 	}
 }
 
+func TestAdminSignatureDebug(t *testing.T) {
+	defer func(debug bool) { serverDebugLog = debug }(serverDebugLog)
+	serverDebugLog = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adminTestBed, err := prepareAdminErasureTestBed(ctx)
+	if err != nil {
+		t.Fatal("Failed to initialize a single node Erasure backend for admin handler tests.", err)
+	}
+	defer adminTestBed.TearDown()
+
+	debugged, err := newTestSignedRequestV4(http.MethodPut, "http://127.0.0.1:9000/bucket/object?tagging",
+		0, nil, globalActiveCred.AccessKey, globalActiveCred.SecretKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dump, err := httputil.DumpRequest(debugged, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := buildAdminRequest(url.Values{}, http.MethodPost, "/debug/signature", int64(len(dump)), bytes.NewReader(dump))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected to succeed but failed with %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var computed signatureV4Computation
+	if err = json.NewDecoder(rec.Body).Decode(&computed); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(computed.CanonicalRequest, "PUT\n/bucket/object\ntagging=\n") {
+		t.Errorf("Unexpected canonical request %q", computed.CanonicalRequest)
+	}
+	if !strings.HasPrefix(computed.StringToSign, signV4Algorithm+"\n") {
+		t.Errorf("Unexpected string to sign %q", computed.StringToSign)
+	}
+	if !strings.HasSuffix(debugged.Header.Get(xhttp.Authorization), "Signature="+computed.Signature) {
+		t.Errorf("Expected signature of %q, got %q", debugged.Header.Get(xhttp.Authorization), computed.Signature)
+	}
+
+	// Unsigned requests cannot be debugged.
+	debugged.Header.Del(xhttp.Authorization)
+	dump, err = httputil.DumpRequest(debugged, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err = buildAdminRequest(url.Values{}, http.MethodPost, "/debug/signature", int64(len(dump)), bytes.NewReader(dump))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec = httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected %d for an unsigned request, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
 // TestToAdminAPIErrCode - test for toAdminAPIErrCode helper function.
 func TestToAdminAPIErrCode(t *testing.T) {
 	testCases := []struct {
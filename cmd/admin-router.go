@@ -62,6 +62,11 @@ func registerAdminRouter(router *mux.Router, enableConfigOps bool) {
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/info").HandlerFunc(gz(httpTraceAll(adminAPI.ServerInfoHandler)))
 		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/inspect-data").HandlerFunc(httpTraceHdrs(adminAPI.InspectDataHandler)).Queries("volume", "{volume:.*}", "file", "{file:.*}")
 
+		// Signature debugging, only available with _MINIO_SERVER_DEBUG=on
+		if serverDebugLog {
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/debug/signature").HandlerFunc(gz(httpTraceHdrs(adminAPI.SignatureDebugHandler)))
+		}
+
 		// StorageInfo operations
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/storageinfo").HandlerFunc(gz(httpTraceAll(adminAPI.StorageInfoHandler)))
 		// DataUsageInfo operations
@@ -43,6 +43,7 @@ import (
 	"github.com/minio/minio/internal/bucket/versioning"
 	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/pkg/bucket/policy"
 )
 
@@ -176,6 +177,8 @@ const (
 	ErrBucketAlreadyOwnedByYou
 	ErrInvalidDuration
 	ErrBucketAlreadyExists
+	ErrTooManyBuckets
+	ErrInvalidLocationConstraint
 	ErrMetadataTooLarge
 	ErrUnsupportedMetadata
 	ErrMaximumExpires
@@ -240,17 +243,22 @@ const (
 	ErrStorageFull
 	ErrRequestBodyParse
 	ErrObjectExistsAsDirectory
+	ErrObjectNameCaseCollision
 	ErrInvalidObjectName
 	ErrInvalidObjectNamePrefixSlash
+	ErrInvalidObjectNameControlCharacter
 	ErrInvalidResourceName
 	ErrServerNotInitialized
 	ErrOperationTimedOut
+	ErrRequestTimeout
 	ErrClientDisconnected
 	ErrOperationMaxedOut
 	ErrInvalidRequest
 	ErrTransitionStorageClassNotFoundError
 	// MinIO storage class error codes
 	ErrInvalidStorageClass
+	ErrInvalidCannedACL
+	ErrMalformedACL
 	ErrBackendDown
 	// Add new extended error codes here.
 	// Please open a https://github.com/minio/minio/issues before adding
@@ -440,6 +448,16 @@ var errorCodes = errorCodeMap{
 		Description:    "Invalid storage class.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrInvalidCannedACL: {
+		Code:           "InvalidArgument",
+		Description:    "The canned ACL specified is not valid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrMalformedACL: {
+		Code:           "MalformedACLError",
+		Description:    "The XML you provided was not well-formed or did not validate against our published schema.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrInvalidRequestBody: {
 		Code:           "InvalidArgument",
 		Description:    "Body shouldn't be set for this request.",
@@ -690,6 +708,16 @@ var errorCodes = errorCodeMap{
 		Description:    "The requested bucket name is not available. The bucket namespace is shared by all users of the system. Please select a different name and try again.",
 		HTTPStatusCode: http.StatusConflict,
 	},
+	ErrTooManyBuckets: {
+		Code:           "TooManyBuckets",
+		Description:    "You have attempted to create more buckets than allowed.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidLocationConstraint: {
+		Code:           "InvalidLocationConstraint",
+		Description:    "The specified location-constraint is not valid",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrAllAccessDisabled: {
 		Code:           "AllAccessDisabled",
 		Description:    "All access to this resource has been disabled.",
@@ -1171,6 +1199,11 @@ var errorCodes = errorCodeMap{
 		Description:    "Object name already exists as a directory.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrObjectNameCaseCollision: {
+		Code:           "InvalidRequest",
+		Description:    "Object name differs only in case from an existing object.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrInvalidObjectName: {
 		Code:           "XMinioInvalidObjectName",
 		Description:    "Object name contains unsupported characters.",
@@ -1181,6 +1214,11 @@ var errorCodes = errorCodeMap{
 		Description:    "Object name contains a leading slash.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrInvalidObjectNameControlCharacter: {
+		Code:           "InvalidArgument",
+		Description:    "Object name contains control characters.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrInvalidResourceName: {
 		Code:           "XMinioInvalidResourceName",
 		Description:    "Resource name contains bad components such as \"..\" or \".\".",
@@ -1297,6 +1335,11 @@ var errorCodes = errorCodeMap{
 		Description:    "A timeout occurred while trying to lock a resource, please reduce your request rate",
 		HTTPStatusCode: http.StatusServiceUnavailable,
 	},
+	ErrRequestTimeout: {
+		Code:           "RequestTimeout",
+		Description:    "Your socket connection to the server was not read from or written to within the timeout period.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrClientDisconnected: {
 		Code:           "ClientDisconnected",
 		Description:    "Client disconnected before response was ready",
@@ -1887,6 +1930,11 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		}
 	}
 
+	// Readers consuming the request body may wrap the timeout error.
+	if errors.Is(err, xhttp.ErrReadBodyTimeout) {
+		return ErrRequestTimeout
+	}
+
 	switch err {
 	case errInvalidArgument:
 		apiErr = ErrAdminInvalidArgument
@@ -1929,6 +1977,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrMissingSSECustomerKeyMD5
 	case crypto.ErrCustomerKeyMD5Mismatch:
 		apiErr = ErrSSECustomerKeyMD5Mismatch
+	case crypto.ErrMalformedCustomerKey:
+		apiErr = ErrInvalidSSECustomerKey
 	case errObjectTampered:
 		apiErr = ErrObjectTampered
 	case errEncryptedObject:
@@ -1988,6 +2038,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrIncompleteBody
 	case ObjectExistsAsDirectory:
 		apiErr = ErrObjectExistsAsDirectory
+	case ObjectNameCaseCollision:
+		apiErr = ErrObjectNameCaseCollision
 	case PrefixAccessDenied:
 		apiErr = ErrAccessDenied
 	case BucketNameInvalid:
@@ -2006,6 +2058,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchKey
 	case MethodNotAllowed:
 		apiErr = ErrMethodNotAllowed
+	case PreConditionFailed:
+		apiErr = ErrPreconditionFailed
 	case ObjectLocked:
 		apiErr = ErrObjectLocked
 	case InvalidVersionID:
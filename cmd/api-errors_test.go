@@ -20,12 +20,14 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/minio/minio/internal/crypto"
 	"github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 var toAPIErrorTests = []struct {
@@ -53,11 +55,14 @@ var toAPIErrorTests = []struct {
 	{err: StorageFull{}, errCode: ErrStorageFull},
 	{err: NotImplemented{}, errCode: ErrNotImplemented},
 	{err: errSignatureMismatch, errCode: ErrSignatureDoesNotMatch},
+	{err: xhttp.ErrReadBodyTimeout, errCode: ErrRequestTimeout},
+	{err: fmt.Errorf("read part: %w", xhttp.ErrReadBodyTimeout), errCode: ErrRequestTimeout},
 
 	// SSE-C errors
 	{err: crypto.ErrInvalidCustomerAlgorithm, errCode: ErrInvalidSSECustomerAlgorithm},
 	{err: crypto.ErrMissingCustomerKey, errCode: ErrMissingSSECustomerKey},
 	{err: crypto.ErrInvalidCustomerKey, errCode: ErrAccessDenied},
+	{err: crypto.ErrMalformedCustomerKey, errCode: ErrInvalidSSECustomerKey},
 	{err: crypto.ErrMissingCustomerKeyMD5, errCode: ErrMissingSSECustomerKeyMD5},
 	{err: crypto.ErrCustomerKeyMD5Mismatch, errCode: ErrSSECustomerKeyMD5Mismatch},
 	{err: errObjectTampered, errCode: ErrObjectTampered},
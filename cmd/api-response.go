@@ -711,6 +711,16 @@ func generateListMultipartUploadsResponse(bucket string, multipartsInfo ListMult
 		newUpload := Upload{}
 		newUpload.UploadID = upload.UploadID
 		newUpload.Key = s3EncodeName(upload.Object, encodingType)
+		newUpload.StorageClass = globalMinioDefaultStorageClass
+		// Dumb values not meaningful
+		newUpload.Initiator = Initiator{
+			ID:          globalMinioDefaultOwnerID,
+			DisplayName: globalMinioDefaultOwnerID,
+		}
+		newUpload.Owner = Owner{
+			ID:          globalMinioDefaultOwnerID,
+			DisplayName: globalMinioDefaultOwnerID,
+		}
 		newUpload.Initiated = upload.Initiated.UTC().Format(iso8601TimeFormat)
 		listMultipartUploadsResponse.Uploads[index] = newUpload
 	}
@@ -790,9 +800,12 @@ func writeSuccessResponseHeadersOnly(w http.ResponseWriter) {
 func writeErrorResponse(ctx context.Context, w http.ResponseWriter, err APIError, reqURL *url.URL) {
 	switch err.Code {
 	case "SlowDown", "XMinioServerNotInitialized", "XMinioReadQuorum", "XMinioWriteQuorum":
-		// Set retry-after header to indicate user-agents to retry request after 120secs.
+		// Set retry-after header to indicate user-agents when to retry the request
+		// based on the current load, unless the caller already computed a value.
 		// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Retry-After
-		w.Header().Set(xhttp.RetryAfter, "120")
+		if w.Header().Get(xhttp.RetryAfter) == "" {
+			setRetryAfter(w, computeRetryAfter())
+		}
 	case "InvalidRegion":
 		err.Description = fmt.Sprintf("Region does not match; expecting '%s'.", globalSite.Region)
 	case "AuthorizationHeaderMalformed":
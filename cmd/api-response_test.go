@@ -18,8 +18,14 @@
 package cmd
 
 import (
+	"context"
+	"encoding/xml"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
+
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 // Tests object location.
@@ -123,3 +129,66 @@ func TestGetURLScheme(t *testing.T) {
 		t.Errorf("Expected %s, got %s", httpsScheme, gotScheme)
 	}
 }
+
+// Tests that every API error code maps to a valid S3 error.
+func TestAPIErrorCodesWellFormed(t *testing.T) {
+	for code, apiErr := range errorCodes {
+		if apiErr.Code == "" {
+			t.Errorf("%s: missing S3 error code", code)
+		}
+		if apiErr.HTTPStatusCode < 200 || apiErr.HTTPStatusCode > 599 {
+			t.Errorf("%s: invalid HTTP status code %d", code, apiErr.HTTPStatusCode)
+		}
+	}
+}
+
+// Tests the XML error response written for API errors.
+func TestWriteErrorResponse(t *testing.T) {
+	testCases := []struct {
+		errCode        APIErrorCode
+		expectedCode   string
+		expectedStatus int
+	}{
+		{ErrSignatureVersionNotSupported, "InvalidRequest", http.StatusBadRequest},
+		{ErrSignatureDoesNotMatch, "SignatureDoesNotMatch", http.StatusForbidden},
+		{ErrAccessDenied, "AccessDenied", http.StatusForbidden},
+		{ErrNoSuchKey, "NoSuchKey", http.StatusNotFound},
+		{ErrInternalError, "InternalError", http.StatusInternalServerError},
+		{ErrSlowDown, "SlowDown", http.StatusServiceUnavailable},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		rec.Header().Set(xhttp.AmzRequestID, "16B81914FBB8345F")
+		reqURL := &url.URL{Path: "/bucket/object"}
+
+		writeErrorResponse(context.Background(), rec, errorCodes.ToAPIErr(testCase.errCode), reqURL)
+
+		if rec.Code != testCase.expectedStatus {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.expectedStatus, rec.Code)
+		}
+		if contentType := rec.Header().Get(xhttp.ContentType); contentType != string(mimeXML) {
+			t.Errorf("Test %d: expected Content-Type %s, got %s", i+1, mimeXML, contentType)
+		}
+
+		var errResp APIErrorResponse
+		if err := xml.Unmarshal(rec.Body.Bytes(), &errResp); err != nil {
+			t.Fatalf("Test %d: unable to parse XML error response: %v", i+1, err)
+		}
+		if errResp.XMLName.Local != "Error" {
+			t.Errorf("Test %d: expected root element Error, got %s", i+1, errResp.XMLName.Local)
+		}
+		if errResp.Code != testCase.expectedCode {
+			t.Errorf("Test %d: expected code %s, got %s", i+1, testCase.expectedCode, errResp.Code)
+		}
+		if errResp.Message != errorCodes.ToAPIErr(testCase.errCode).Description {
+			t.Errorf("Test %d: unexpected message %s", i+1, errResp.Message)
+		}
+		if errResp.Resource != reqURL.Path {
+			t.Errorf("Test %d: expected resource %s, got %s", i+1, reqURL.Path, errResp.Resource)
+		}
+		if errResp.RequestID != "16B81914FBB8345F" {
+			t.Errorf("Test %d: expected request id 16B81914FBB8345F, got %s", i+1, errResp.RequestID)
+		}
+	}
+}
@@ -111,199 +111,206 @@ func _() {
 	_ = x[ErrBucketAlreadyOwnedByYou-100]
 	_ = x[ErrInvalidDuration-101]
 	_ = x[ErrBucketAlreadyExists-102]
-	_ = x[ErrMetadataTooLarge-103]
-	_ = x[ErrUnsupportedMetadata-104]
-	_ = x[ErrMaximumExpires-105]
-	_ = x[ErrSlowDown-106]
-	_ = x[ErrInvalidPrefixMarker-107]
-	_ = x[ErrBadRequest-108]
-	_ = x[ErrKeyTooLongError-109]
-	_ = x[ErrInvalidBucketObjectLockConfiguration-110]
-	_ = x[ErrObjectLockConfigurationNotFound-111]
-	_ = x[ErrObjectLockConfigurationNotAllowed-112]
-	_ = x[ErrNoSuchObjectLockConfiguration-113]
-	_ = x[ErrObjectLocked-114]
-	_ = x[ErrInvalidRetentionDate-115]
-	_ = x[ErrPastObjectLockRetainDate-116]
-	_ = x[ErrUnknownWORMModeDirective-117]
-	_ = x[ErrBucketTaggingNotFound-118]
-	_ = x[ErrObjectLockInvalidHeaders-119]
-	_ = x[ErrInvalidTagDirective-120]
-	_ = x[ErrInvalidEncryptionMethod-121]
-	_ = x[ErrInsecureSSECustomerRequest-122]
-	_ = x[ErrSSEMultipartEncrypted-123]
-	_ = x[ErrSSEEncryptedObject-124]
-	_ = x[ErrInvalidEncryptionParameters-125]
-	_ = x[ErrInvalidSSECustomerAlgorithm-126]
-	_ = x[ErrInvalidSSECustomerKey-127]
-	_ = x[ErrMissingSSECustomerKey-128]
-	_ = x[ErrMissingSSECustomerKeyMD5-129]
-	_ = x[ErrSSECustomerKeyMD5Mismatch-130]
-	_ = x[ErrInvalidSSECustomerParameters-131]
-	_ = x[ErrIncompatibleEncryptionMethod-132]
-	_ = x[ErrKMSNotConfigured-133]
-	_ = x[ErrKMSKeyNotFoundException-134]
-	_ = x[ErrNoAccessKey-135]
-	_ = x[ErrInvalidToken-136]
-	_ = x[ErrEventNotification-137]
-	_ = x[ErrARNNotification-138]
-	_ = x[ErrRegionNotification-139]
-	_ = x[ErrOverlappingFilterNotification-140]
-	_ = x[ErrFilterNameInvalid-141]
-	_ = x[ErrFilterNamePrefix-142]
-	_ = x[ErrFilterNameSuffix-143]
-	_ = x[ErrFilterValueInvalid-144]
-	_ = x[ErrOverlappingConfigs-145]
-	_ = x[ErrUnsupportedNotification-146]
-	_ = x[ErrContentSHA256Mismatch-147]
-	_ = x[ErrReadQuorum-148]
-	_ = x[ErrWriteQuorum-149]
-	_ = x[ErrStorageFull-150]
-	_ = x[ErrRequestBodyParse-151]
-	_ = x[ErrObjectExistsAsDirectory-152]
-	_ = x[ErrInvalidObjectName-153]
-	_ = x[ErrInvalidObjectNamePrefixSlash-154]
-	_ = x[ErrInvalidResourceName-155]
-	_ = x[ErrServerNotInitialized-156]
-	_ = x[ErrOperationTimedOut-157]
-	_ = x[ErrClientDisconnected-158]
-	_ = x[ErrOperationMaxedOut-159]
-	_ = x[ErrInvalidRequest-160]
-	_ = x[ErrTransitionStorageClassNotFoundError-161]
-	_ = x[ErrInvalidStorageClass-162]
-	_ = x[ErrBackendDown-163]
-	_ = x[ErrMalformedJSON-164]
-	_ = x[ErrAdminNoSuchUser-165]
-	_ = x[ErrAdminNoSuchGroup-166]
-	_ = x[ErrAdminGroupNotEmpty-167]
-	_ = x[ErrAdminNoSuchPolicy-168]
-	_ = x[ErrAdminInvalidArgument-169]
-	_ = x[ErrAdminInvalidAccessKey-170]
-	_ = x[ErrAdminInvalidSecretKey-171]
-	_ = x[ErrAdminConfigNoQuorum-172]
-	_ = x[ErrAdminConfigTooLarge-173]
-	_ = x[ErrAdminConfigBadJSON-174]
-	_ = x[ErrAdminNoSuchConfigTarget-175]
-	_ = x[ErrAdminConfigEnvOverridden-176]
-	_ = x[ErrAdminConfigDuplicateKeys-177]
-	_ = x[ErrAdminCredentialsMismatch-178]
-	_ = x[ErrInsecureClientRequest-179]
-	_ = x[ErrObjectTampered-180]
-	_ = x[ErrSiteReplicationInvalidRequest-181]
-	_ = x[ErrSiteReplicationPeerResp-182]
-	_ = x[ErrSiteReplicationBackendIssue-183]
-	_ = x[ErrSiteReplicationServiceAccountError-184]
-	_ = x[ErrSiteReplicationBucketConfigError-185]
-	_ = x[ErrSiteReplicationBucketMetaError-186]
-	_ = x[ErrSiteReplicationIAMError-187]
-	_ = x[ErrSiteReplicationConfigMissing-188]
-	_ = x[ErrAdminBucketQuotaExceeded-189]
-	_ = x[ErrAdminNoSuchQuotaConfiguration-190]
-	_ = x[ErrHealNotImplemented-191]
-	_ = x[ErrHealNoSuchProcess-192]
-	_ = x[ErrHealInvalidClientToken-193]
-	_ = x[ErrHealMissingBucket-194]
-	_ = x[ErrHealAlreadyRunning-195]
-	_ = x[ErrHealOverlappingPaths-196]
-	_ = x[ErrIncorrectContinuationToken-197]
-	_ = x[ErrEmptyRequestBody-198]
-	_ = x[ErrUnsupportedFunction-199]
-	_ = x[ErrInvalidExpressionType-200]
-	_ = x[ErrBusy-201]
-	_ = x[ErrUnauthorizedAccess-202]
-	_ = x[ErrExpressionTooLong-203]
-	_ = x[ErrIllegalSQLFunctionArgument-204]
-	_ = x[ErrInvalidKeyPath-205]
-	_ = x[ErrInvalidCompressionFormat-206]
-	_ = x[ErrInvalidFileHeaderInfo-207]
-	_ = x[ErrInvalidJSONType-208]
-	_ = x[ErrInvalidQuoteFields-209]
-	_ = x[ErrInvalidRequestParameter-210]
-	_ = x[ErrInvalidDataType-211]
-	_ = x[ErrInvalidTextEncoding-212]
-	_ = x[ErrInvalidDataSource-213]
-	_ = x[ErrInvalidTableAlias-214]
-	_ = x[ErrMissingRequiredParameter-215]
-	_ = x[ErrObjectSerializationConflict-216]
-	_ = x[ErrUnsupportedSQLOperation-217]
-	_ = x[ErrUnsupportedSQLStructure-218]
-	_ = x[ErrUnsupportedSyntax-219]
-	_ = x[ErrUnsupportedRangeHeader-220]
-	_ = x[ErrLexerInvalidChar-221]
-	_ = x[ErrLexerInvalidOperator-222]
-	_ = x[ErrLexerInvalidLiteral-223]
-	_ = x[ErrLexerInvalidIONLiteral-224]
-	_ = x[ErrParseExpectedDatePart-225]
-	_ = x[ErrParseExpectedKeyword-226]
-	_ = x[ErrParseExpectedTokenType-227]
-	_ = x[ErrParseExpected2TokenTypes-228]
-	_ = x[ErrParseExpectedNumber-229]
-	_ = x[ErrParseExpectedRightParenBuiltinFunctionCall-230]
-	_ = x[ErrParseExpectedTypeName-231]
-	_ = x[ErrParseExpectedWhenClause-232]
-	_ = x[ErrParseUnsupportedToken-233]
-	_ = x[ErrParseUnsupportedLiteralsGroupBy-234]
-	_ = x[ErrParseExpectedMember-235]
-	_ = x[ErrParseUnsupportedSelect-236]
-	_ = x[ErrParseUnsupportedCase-237]
-	_ = x[ErrParseUnsupportedCaseClause-238]
-	_ = x[ErrParseUnsupportedAlias-239]
-	_ = x[ErrParseUnsupportedSyntax-240]
-	_ = x[ErrParseUnknownOperator-241]
-	_ = x[ErrParseMissingIdentAfterAt-242]
-	_ = x[ErrParseUnexpectedOperator-243]
-	_ = x[ErrParseUnexpectedTerm-244]
-	_ = x[ErrParseUnexpectedToken-245]
-	_ = x[ErrParseUnexpectedKeyword-246]
-	_ = x[ErrParseExpectedExpression-247]
-	_ = x[ErrParseExpectedLeftParenAfterCast-248]
-	_ = x[ErrParseExpectedLeftParenValueConstructor-249]
-	_ = x[ErrParseExpectedLeftParenBuiltinFunctionCall-250]
-	_ = x[ErrParseExpectedArgumentDelimiter-251]
-	_ = x[ErrParseCastArity-252]
-	_ = x[ErrParseInvalidTypeParam-253]
-	_ = x[ErrParseEmptySelect-254]
-	_ = x[ErrParseSelectMissingFrom-255]
-	_ = x[ErrParseExpectedIdentForGroupName-256]
-	_ = x[ErrParseExpectedIdentForAlias-257]
-	_ = x[ErrParseUnsupportedCallWithStar-258]
-	_ = x[ErrParseNonUnaryAgregateFunctionCall-259]
-	_ = x[ErrParseMalformedJoin-260]
-	_ = x[ErrParseExpectedIdentForAt-261]
-	_ = x[ErrParseAsteriskIsNotAloneInSelectList-262]
-	_ = x[ErrParseCannotMixSqbAndWildcardInSelectList-263]
-	_ = x[ErrParseInvalidContextForWildcardInSelectList-264]
-	_ = x[ErrIncorrectSQLFunctionArgumentType-265]
-	_ = x[ErrValueParseFailure-266]
-	_ = x[ErrEvaluatorInvalidArguments-267]
-	_ = x[ErrIntegerOverflow-268]
-	_ = x[ErrLikeInvalidInputs-269]
-	_ = x[ErrCastFailed-270]
-	_ = x[ErrInvalidCast-271]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPattern-272]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbolForParsing-273]
-	_ = x[ErrEvaluatorTimestampFormatPatternDuplicateFields-274]
-	_ = x[ErrEvaluatorTimestampFormatPatternHourClockAmPmMismatch-275]
-	_ = x[ErrEvaluatorUnterminatedTimestampFormatPatternToken-276]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternToken-277]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbol-278]
-	_ = x[ErrEvaluatorBindingDoesNotExist-279]
-	_ = x[ErrMissingHeaders-280]
-	_ = x[ErrInvalidColumnIndex-281]
-	_ = x[ErrAdminConfigNotificationTargetsFailed-282]
-	_ = x[ErrAdminProfilerNotEnabled-283]
-	_ = x[ErrInvalidDecompressedSize-284]
-	_ = x[ErrAddUserInvalidArgument-285]
-	_ = x[ErrAdminResourceInvalidArgument-286]
-	_ = x[ErrAdminAccountNotEligible-287]
-	_ = x[ErrAccountNotEligible-288]
-	_ = x[ErrAdminServiceAccountNotFound-289]
-	_ = x[ErrPostPolicyConditionInvalidFormat-290]
+	_ = x[ErrTooManyBuckets-103]
+	_ = x[ErrInvalidLocationConstraint-104]
+	_ = x[ErrMetadataTooLarge-105]
+	_ = x[ErrUnsupportedMetadata-106]
+	_ = x[ErrMaximumExpires-107]
+	_ = x[ErrSlowDown-108]
+	_ = x[ErrInvalidPrefixMarker-109]
+	_ = x[ErrBadRequest-110]
+	_ = x[ErrKeyTooLongError-111]
+	_ = x[ErrInvalidBucketObjectLockConfiguration-112]
+	_ = x[ErrObjectLockConfigurationNotFound-113]
+	_ = x[ErrObjectLockConfigurationNotAllowed-114]
+	_ = x[ErrNoSuchObjectLockConfiguration-115]
+	_ = x[ErrObjectLocked-116]
+	_ = x[ErrInvalidRetentionDate-117]
+	_ = x[ErrPastObjectLockRetainDate-118]
+	_ = x[ErrUnknownWORMModeDirective-119]
+	_ = x[ErrBucketTaggingNotFound-120]
+	_ = x[ErrObjectLockInvalidHeaders-121]
+	_ = x[ErrInvalidTagDirective-122]
+	_ = x[ErrInvalidEncryptionMethod-123]
+	_ = x[ErrInsecureSSECustomerRequest-124]
+	_ = x[ErrSSEMultipartEncrypted-125]
+	_ = x[ErrSSEEncryptedObject-126]
+	_ = x[ErrInvalidEncryptionParameters-127]
+	_ = x[ErrInvalidSSECustomerAlgorithm-128]
+	_ = x[ErrInvalidSSECustomerKey-129]
+	_ = x[ErrMissingSSECustomerKey-130]
+	_ = x[ErrMissingSSECustomerKeyMD5-131]
+	_ = x[ErrSSECustomerKeyMD5Mismatch-132]
+	_ = x[ErrInvalidSSECustomerParameters-133]
+	_ = x[ErrIncompatibleEncryptionMethod-134]
+	_ = x[ErrKMSNotConfigured-135]
+	_ = x[ErrKMSKeyNotFoundException-136]
+	_ = x[ErrNoAccessKey-137]
+	_ = x[ErrInvalidToken-138]
+	_ = x[ErrEventNotification-139]
+	_ = x[ErrARNNotification-140]
+	_ = x[ErrRegionNotification-141]
+	_ = x[ErrOverlappingFilterNotification-142]
+	_ = x[ErrFilterNameInvalid-143]
+	_ = x[ErrFilterNamePrefix-144]
+	_ = x[ErrFilterNameSuffix-145]
+	_ = x[ErrFilterValueInvalid-146]
+	_ = x[ErrOverlappingConfigs-147]
+	_ = x[ErrUnsupportedNotification-148]
+	_ = x[ErrContentSHA256Mismatch-149]
+	_ = x[ErrReadQuorum-150]
+	_ = x[ErrWriteQuorum-151]
+	_ = x[ErrStorageFull-152]
+	_ = x[ErrRequestBodyParse-153]
+	_ = x[ErrObjectExistsAsDirectory-154]
+	_ = x[ErrObjectNameCaseCollision-155]
+	_ = x[ErrInvalidObjectName-156]
+	_ = x[ErrInvalidObjectNamePrefixSlash-157]
+	_ = x[ErrInvalidObjectNameControlCharacter-158]
+	_ = x[ErrInvalidResourceName-159]
+	_ = x[ErrServerNotInitialized-160]
+	_ = x[ErrOperationTimedOut-161]
+	_ = x[ErrRequestTimeout-162]
+	_ = x[ErrClientDisconnected-163]
+	_ = x[ErrOperationMaxedOut-164]
+	_ = x[ErrInvalidRequest-165]
+	_ = x[ErrTransitionStorageClassNotFoundError-166]
+	_ = x[ErrInvalidStorageClass-167]
+	_ = x[ErrInvalidCannedACL-168]
+	_ = x[ErrMalformedACL-169]
+	_ = x[ErrBackendDown-170]
+	_ = x[ErrMalformedJSON-171]
+	_ = x[ErrAdminNoSuchUser-172]
+	_ = x[ErrAdminNoSuchGroup-173]
+	_ = x[ErrAdminGroupNotEmpty-174]
+	_ = x[ErrAdminNoSuchPolicy-175]
+	_ = x[ErrAdminInvalidArgument-176]
+	_ = x[ErrAdminInvalidAccessKey-177]
+	_ = x[ErrAdminInvalidSecretKey-178]
+	_ = x[ErrAdminConfigNoQuorum-179]
+	_ = x[ErrAdminConfigTooLarge-180]
+	_ = x[ErrAdminConfigBadJSON-181]
+	_ = x[ErrAdminNoSuchConfigTarget-182]
+	_ = x[ErrAdminConfigEnvOverridden-183]
+	_ = x[ErrAdminConfigDuplicateKeys-184]
+	_ = x[ErrAdminCredentialsMismatch-185]
+	_ = x[ErrInsecureClientRequest-186]
+	_ = x[ErrObjectTampered-187]
+	_ = x[ErrSiteReplicationInvalidRequest-188]
+	_ = x[ErrSiteReplicationPeerResp-189]
+	_ = x[ErrSiteReplicationBackendIssue-190]
+	_ = x[ErrSiteReplicationServiceAccountError-191]
+	_ = x[ErrSiteReplicationBucketConfigError-192]
+	_ = x[ErrSiteReplicationBucketMetaError-193]
+	_ = x[ErrSiteReplicationIAMError-194]
+	_ = x[ErrSiteReplicationConfigMissing-195]
+	_ = x[ErrAdminBucketQuotaExceeded-196]
+	_ = x[ErrAdminNoSuchQuotaConfiguration-197]
+	_ = x[ErrHealNotImplemented-198]
+	_ = x[ErrHealNoSuchProcess-199]
+	_ = x[ErrHealInvalidClientToken-200]
+	_ = x[ErrHealMissingBucket-201]
+	_ = x[ErrHealAlreadyRunning-202]
+	_ = x[ErrHealOverlappingPaths-203]
+	_ = x[ErrIncorrectContinuationToken-204]
+	_ = x[ErrEmptyRequestBody-205]
+	_ = x[ErrUnsupportedFunction-206]
+	_ = x[ErrInvalidExpressionType-207]
+	_ = x[ErrBusy-208]
+	_ = x[ErrUnauthorizedAccess-209]
+	_ = x[ErrExpressionTooLong-210]
+	_ = x[ErrIllegalSQLFunctionArgument-211]
+	_ = x[ErrInvalidKeyPath-212]
+	_ = x[ErrInvalidCompressionFormat-213]
+	_ = x[ErrInvalidFileHeaderInfo-214]
+	_ = x[ErrInvalidJSONType-215]
+	_ = x[ErrInvalidQuoteFields-216]
+	_ = x[ErrInvalidRequestParameter-217]
+	_ = x[ErrInvalidDataType-218]
+	_ = x[ErrInvalidTextEncoding-219]
+	_ = x[ErrInvalidDataSource-220]
+	_ = x[ErrInvalidTableAlias-221]
+	_ = x[ErrMissingRequiredParameter-222]
+	_ = x[ErrObjectSerializationConflict-223]
+	_ = x[ErrUnsupportedSQLOperation-224]
+	_ = x[ErrUnsupportedSQLStructure-225]
+	_ = x[ErrUnsupportedSyntax-226]
+	_ = x[ErrUnsupportedRangeHeader-227]
+	_ = x[ErrLexerInvalidChar-228]
+	_ = x[ErrLexerInvalidOperator-229]
+	_ = x[ErrLexerInvalidLiteral-230]
+	_ = x[ErrLexerInvalidIONLiteral-231]
+	_ = x[ErrParseExpectedDatePart-232]
+	_ = x[ErrParseExpectedKeyword-233]
+	_ = x[ErrParseExpectedTokenType-234]
+	_ = x[ErrParseExpected2TokenTypes-235]
+	_ = x[ErrParseExpectedNumber-236]
+	_ = x[ErrParseExpectedRightParenBuiltinFunctionCall-237]
+	_ = x[ErrParseExpectedTypeName-238]
+	_ = x[ErrParseExpectedWhenClause-239]
+	_ = x[ErrParseUnsupportedToken-240]
+	_ = x[ErrParseUnsupportedLiteralsGroupBy-241]
+	_ = x[ErrParseExpectedMember-242]
+	_ = x[ErrParseUnsupportedSelect-243]
+	_ = x[ErrParseUnsupportedCase-244]
+	_ = x[ErrParseUnsupportedCaseClause-245]
+	_ = x[ErrParseUnsupportedAlias-246]
+	_ = x[ErrParseUnsupportedSyntax-247]
+	_ = x[ErrParseUnknownOperator-248]
+	_ = x[ErrParseMissingIdentAfterAt-249]
+	_ = x[ErrParseUnexpectedOperator-250]
+	_ = x[ErrParseUnexpectedTerm-251]
+	_ = x[ErrParseUnexpectedToken-252]
+	_ = x[ErrParseUnexpectedKeyword-253]
+	_ = x[ErrParseExpectedExpression-254]
+	_ = x[ErrParseExpectedLeftParenAfterCast-255]
+	_ = x[ErrParseExpectedLeftParenValueConstructor-256]
+	_ = x[ErrParseExpectedLeftParenBuiltinFunctionCall-257]
+	_ = x[ErrParseExpectedArgumentDelimiter-258]
+	_ = x[ErrParseCastArity-259]
+	_ = x[ErrParseInvalidTypeParam-260]
+	_ = x[ErrParseEmptySelect-261]
+	_ = x[ErrParseSelectMissingFrom-262]
+	_ = x[ErrParseExpectedIdentForGroupName-263]
+	_ = x[ErrParseExpectedIdentForAlias-264]
+	_ = x[ErrParseUnsupportedCallWithStar-265]
+	_ = x[ErrParseNonUnaryAgregateFunctionCall-266]
+	_ = x[ErrParseMalformedJoin-267]
+	_ = x[ErrParseExpectedIdentForAt-268]
+	_ = x[ErrParseAsteriskIsNotAloneInSelectList-269]
+	_ = x[ErrParseCannotMixSqbAndWildcardInSelectList-270]
+	_ = x[ErrParseInvalidContextForWildcardInSelectList-271]
+	_ = x[ErrIncorrectSQLFunctionArgumentType-272]
+	_ = x[ErrValueParseFailure-273]
+	_ = x[ErrEvaluatorInvalidArguments-274]
+	_ = x[ErrIntegerOverflow-275]
+	_ = x[ErrLikeInvalidInputs-276]
+	_ = x[ErrCastFailed-277]
+	_ = x[ErrInvalidCast-278]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPattern-279]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbolForParsing-280]
+	_ = x[ErrEvaluatorTimestampFormatPatternDuplicateFields-281]
+	_ = x[ErrEvaluatorTimestampFormatPatternHourClockAmPmMismatch-282]
+	_ = x[ErrEvaluatorUnterminatedTimestampFormatPatternToken-283]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternToken-284]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbol-285]
+	_ = x[ErrEvaluatorBindingDoesNotExist-286]
+	_ = x[ErrMissingHeaders-287]
+	_ = x[ErrInvalidColumnIndex-288]
+	_ = x[ErrAdminConfigNotificationTargetsFailed-289]
+	_ = x[ErrAdminProfilerNotEnabled-290]
+	_ = x[ErrInvalidDecompressedSize-291]
+	_ = x[ErrAddUserInvalidArgument-292]
+	_ = x[ErrAdminResourceInvalidArgument-293]
+	_ = x[ErrAdminAccountNotEligible-294]
+	_ = x[ErrAccountNotEligible-295]
+	_ = x[ErrAdminServiceAccountNotFound-296]
+	_ = x[ErrPostPolicyConditionInvalidFormat-297]
 }
 
-const _APIErrorCode_name = "NoneAccessDeniedBadDigestEntityTooSmallEntityTooLargePolicyTooLargeIncompleteBodyInternalErrorInvalidAccessKeyIDAccessKeyDisabledInvalidBucketNameInvalidDigestInvalidRangeInvalidRangePartNumberInvalidCopyPartRangeInvalidCopyPartRangeSourceInvalidMaxKeysInvalidEncodingMethodInvalidMaxUploadsInvalidMaxPartsInvalidPartNumberMarkerInvalidPartNumberInvalidRequestBodyInvalidCopySourceInvalidMetadataDirectiveInvalidCopyDestInvalidPolicyDocumentInvalidObjectStateMalformedXMLMissingContentLengthMissingContentMD5MissingRequestBodyErrorMissingSecurityHeaderNoSuchBucketNoSuchBucketPolicyNoSuchBucketLifecycleNoSuchLifecycleConfigurationInvalidLifecycleWithObjectLockNoSuchBucketSSEConfigNoSuchCORSConfigurationNoSuchWebsiteConfigurationReplicationConfigurationNotFoundErrorRemoteDestinationNotFoundErrorReplicationDestinationMissingLockRemoteTargetNotFoundErrorReplicationRemoteConnectionErrorReplicationBandwidthLimitErrorBucketRemoteIdenticalToSourceBucketRemoteAlreadyExistsBucketRemoteLabelInUseBucketRemoteArnTypeInvalidBucketRemoteArnInvalidBucketRemoteRemoveDisallowedRemoteTargetNotVersionedErrorReplicationSourceNotVersionedErrorReplicationNeedsVersioningErrorReplicationBucketNeedsVersioningErrorReplicationDenyEditErrorReplicationNoExistingObjectsObjectRestoreAlreadyInProgressNoSuchKeyNoSuchUploadInvalidVersionIDNoSuchVersionNotImplementedPreconditionFailedRequestTimeTooSkewedSignatureDoesNotMatchMethodNotAllowedInvalidPartInvalidPartOrderAuthorizationHeaderMalformedMalformedPOSTRequestPOSTFileRequiredSignatureVersionNotSupportedBucketNotEmptyAllAccessDisabledMalformedPolicyMissingFieldsMissingCredTagCredMalformedInvalidRegionInvalidServiceS3InvalidServiceSTSInvalidRequestVersionMissingSignTagMissingSignHeadersTagMalformedDateMalformedPresignedDateMalformedCredentialDateMalformedCredentialRegionMalformedExpiresNegativeExpiresAuthHeaderEmptyExpiredPresignRequestRequestNotReadyYetUnsignedHeadersMissingDateHeaderInvalidQuerySignatureAlgoInvalidQueryParamsBucketAlreadyOwnedByYouInvalidDurationBucketAlreadyExistsMetadataTooLargeUnsupportedMetadataMaximumExpiresSlowDownInvalidPrefixMarkerBadRequestKeyTooLongErrorInvalidBucketObjectLockConfigurationObjectLockConfigurationNotFoundObjectLockConfigurationNotAllowedNoSuchObjectLockConfigurationObjectLockedInvalidRetentionDatePastObjectLockRetainDateUnknownWORMModeDirectiveBucketTaggingNotFoundObjectLockInvalidHeadersInvalidTagDirectiveInvalidEncryptionMethodInsecureSSECustomerRequestSSEMultipartEncryptedSSEEncryptedObjectInvalidEncryptionParametersInvalidSSECustomerAlgorithmInvalidSSECustomerKeyMissingSSECustomerKeyMissingSSECustomerKeyMD5SSECustomerKeyMD5MismatchInvalidSSECustomerParametersIncompatibleEncryptionMethodKMSNotConfiguredKMSKeyNotFoundExceptionNoAccessKeyInvalidTokenEventNotificationARNNotificationRegionNotificationOverlappingFilterNotificationFilterNameInvalidFilterNamePrefixFilterNameSuffixFilterValueInvalidOverlappingConfigsUnsupportedNotificationContentSHA256MismatchReadQuorumWriteQuorumStorageFullRequestBodyParseObjectExistsAsDirectoryInvalidObjectNameInvalidObjectNamePrefixSlashInvalidResourceNameServerNotInitializedOperationTimedOutClientDisconnectedOperationMaxedOutInvalidRequestTransitionStorageClassNotFoundErrorInvalidStorageClassBackendDownMalformedJSONAdminNoSuchUserAdminNoSuchGroupAdminGroupNotEmptyAdminNoSuchPolicyAdminInvalidArgumentAdminInvalidAccessKeyAdminInvalidSecretKeyAdminConfigNoQuorumAdminConfigTooLargeAdminConfigBadJSONAdminNoSuchConfigTargetAdminConfigEnvOverriddenAdminConfigDuplicateKeysAdminCredentialsMismatchInsecureClientRequestObjectTamperedSiteReplicationInvalidRequestSiteReplicationPeerRespSiteReplicationBackendIssueSiteReplicationServiceAccountErrorSiteReplicationBucketConfigErrorSiteReplicationBucketMetaErrorSiteReplicationIAMErrorSiteReplicationConfigMissingAdminBucketQuotaExceededAdminNoSuchQuotaConfigurationHealNotImplementedHealNoSuchProcessHealInvalidClientTokenHealMissingBucketHealAlreadyRunningHealOverlappingPathsIncorrectContinuationTokenEmptyRequestBodyUnsupportedFunctionInvalidExpressionTypeBusyUnauthorizedAccessExpressionTooLongIllegalSQLFunctionArgumentInvalidKeyPathInvalidCompressionFormatInvalidFileHeaderInfoInvalidJSONTypeInvalidQuoteFieldsInvalidRequestParameterInvalidDataTypeInvalidTextEncodingInvalidDataSourceInvalidTableAliasMissingRequiredParameterObjectSerializationConflictUnsupportedSQLOperationUnsupportedSQLStructureUnsupportedSyntaxUnsupportedRangeHeaderLexerInvalidCharLexerInvalidOperatorLexerInvalidLiteralLexerInvalidIONLiteralParseExpectedDatePartParseExpectedKeywordParseExpectedTokenTypeParseExpected2TokenTypesParseExpectedNumberParseExpectedRightParenBuiltinFunctionCallParseExpectedTypeNameParseExpectedWhenClauseParseUnsupportedTokenParseUnsupportedLiteralsGroupByParseExpectedMemberParseUnsupportedSelectParseUnsupportedCaseParseUnsupportedCaseClauseParseUnsupportedAliasParseUnsupportedSyntaxParseUnknownOperatorParseMissingIdentAfterAtParseUnexpectedOperatorParseUnexpectedTermParseUnexpectedTokenParseUnexpectedKeywordParseExpectedExpressionParseExpectedLeftParenAfterCastParseExpectedLeftParenValueConstructorParseExpectedLeftParenBuiltinFunctionCallParseExpectedArgumentDelimiterParseCastArityParseInvalidTypeParamParseEmptySelectParseSelectMissingFromParseExpectedIdentForGroupNameParseExpectedIdentForAliasParseUnsupportedCallWithStarParseNonUnaryAgregateFunctionCallParseMalformedJoinParseExpectedIdentForAtParseAsteriskIsNotAloneInSelectListParseCannotMixSqbAndWildcardInSelectListParseInvalidContextForWildcardInSelectListIncorrectSQLFunctionArgumentTypeValueParseFailureEvaluatorInvalidArgumentsIntegerOverflowLikeInvalidInputsCastFailedInvalidCastEvaluatorInvalidTimestampFormatPatternEvaluatorInvalidTimestampFormatPatternSymbolForParsingEvaluatorTimestampFormatPatternDuplicateFieldsEvaluatorTimestampFormatPatternHourClockAmPmMismatchEvaluatorUnterminatedTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternSymbolEvaluatorBindingDoesNotExistMissingHeadersInvalidColumnIndexAdminConfigNotificationTargetsFailedAdminProfilerNotEnabledInvalidDecompressedSizeAddUserInvalidArgumentAdminResourceInvalidArgumentAdminAccountNotEligibleAccountNotEligibleAdminServiceAccountNotFoundPostPolicyConditionInvalidFormat"
+const _APIErrorCode_name = "NoneAccessDeniedBadDigestEntityTooSmallEntityTooLargePolicyTooLargeIncompleteBodyInternalErrorInvalidAccessKeyIDAccessKeyDisabledInvalidBucketNameInvalidDigestInvalidRangeInvalidRangePartNumberInvalidCopyPartRangeInvalidCopyPartRangeSourceInvalidMaxKeysInvalidEncodingMethodInvalidMaxUploadsInvalidMaxPartsInvalidPartNumberMarkerInvalidPartNumberInvalidRequestBodyInvalidCopySourceInvalidMetadataDirectiveInvalidCopyDestInvalidPolicyDocumentInvalidObjectStateMalformedXMLMissingContentLengthMissingContentMD5MissingRequestBodyErrorMissingSecurityHeaderNoSuchBucketNoSuchBucketPolicyNoSuchBucketLifecycleNoSuchLifecycleConfigurationInvalidLifecycleWithObjectLockNoSuchBucketSSEConfigNoSuchCORSConfigurationNoSuchWebsiteConfigurationReplicationConfigurationNotFoundErrorRemoteDestinationNotFoundErrorReplicationDestinationMissingLockRemoteTargetNotFoundErrorReplicationRemoteConnectionErrorReplicationBandwidthLimitErrorBucketRemoteIdenticalToSourceBucketRemoteAlreadyExistsBucketRemoteLabelInUseBucketRemoteArnTypeInvalidBucketRemoteArnInvalidBucketRemoteRemoveDisallowedRemoteTargetNotVersionedErrorReplicationSourceNotVersionedErrorReplicationNeedsVersioningErrorReplicationBucketNeedsVersioningErrorReplicationDenyEditErrorReplicationNoExistingObjectsObjectRestoreAlreadyInProgressNoSuchKeyNoSuchUploadInvalidVersionIDNoSuchVersionNotImplementedPreconditionFailedRequestTimeTooSkewedSignatureDoesNotMatchMethodNotAllowedInvalidPartInvalidPartOrderAuthorizationHeaderMalformedMalformedPOSTRequestPOSTFileRequiredSignatureVersionNotSupportedBucketNotEmptyAllAccessDisabledMalformedPolicyMissingFieldsMissingCredTagCredMalformedInvalidRegionInvalidServiceS3InvalidServiceSTSInvalidRequestVersionMissingSignTagMissingSignHeadersTagMalformedDateMalformedPresignedDateMalformedCredentialDateMalformedCredentialRegionMalformedExpiresNegativeExpiresAuthHeaderEmptyExpiredPresignRequestRequestNotReadyYetUnsignedHeadersMissingDateHeaderInvalidQuerySignatureAlgoInvalidQueryParamsBucketAlreadyOwnedByYouInvalidDurationBucketAlreadyExistsTooManyBucketsInvalidLocationConstraintMetadataTooLargeUnsupportedMetadataMaximumExpiresSlowDownInvalidPrefixMarkerBadRequestKeyTooLongErrorInvalidBucketObjectLockConfigurationObjectLockConfigurationNotFoundObjectLockConfigurationNotAllowedNoSuchObjectLockConfigurationObjectLockedInvalidRetentionDatePastObjectLockRetainDateUnknownWORMModeDirectiveBucketTaggingNotFoundObjectLockInvalidHeadersInvalidTagDirectiveInvalidEncryptionMethodInsecureSSECustomerRequestSSEMultipartEncryptedSSEEncryptedObjectInvalidEncryptionParametersInvalidSSECustomerAlgorithmInvalidSSECustomerKeyMissingSSECustomerKeyMissingSSECustomerKeyMD5SSECustomerKeyMD5MismatchInvalidSSECustomerParametersIncompatibleEncryptionMethodKMSNotConfiguredKMSKeyNotFoundExceptionNoAccessKeyInvalidTokenEventNotificationARNNotificationRegionNotificationOverlappingFilterNotificationFilterNameInvalidFilterNamePrefixFilterNameSuffixFilterValueInvalidOverlappingConfigsUnsupportedNotificationContentSHA256MismatchReadQuorumWriteQuorumStorageFullRequestBodyParseObjectExistsAsDirectoryObjectNameCaseCollisionInvalidObjectNameInvalidObjectNamePrefixSlashInvalidObjectNameControlCharacterInvalidResourceNameServerNotInitializedOperationTimedOutRequestTimeoutClientDisconnectedOperationMaxedOutInvalidRequestTransitionStorageClassNotFoundErrorInvalidStorageClassInvalidCannedACLMalformedACLBackendDownMalformedJSONAdminNoSuchUserAdminNoSuchGroupAdminGroupNotEmptyAdminNoSuchPolicyAdminInvalidArgumentAdminInvalidAccessKeyAdminInvalidSecretKeyAdminConfigNoQuorumAdminConfigTooLargeAdminConfigBadJSONAdminNoSuchConfigTargetAdminConfigEnvOverriddenAdminConfigDuplicateKeysAdminCredentialsMismatchInsecureClientRequestObjectTamperedSiteReplicationInvalidRequestSiteReplicationPeerRespSiteReplicationBackendIssueSiteReplicationServiceAccountErrorSiteReplicationBucketConfigErrorSiteReplicationBucketMetaErrorSiteReplicationIAMErrorSiteReplicationConfigMissingAdminBucketQuotaExceededAdminNoSuchQuotaConfigurationHealNotImplementedHealNoSuchProcessHealInvalidClientTokenHealMissingBucketHealAlreadyRunningHealOverlappingPathsIncorrectContinuationTokenEmptyRequestBodyUnsupportedFunctionInvalidExpressionTypeBusyUnauthorizedAccessExpressionTooLongIllegalSQLFunctionArgumentInvalidKeyPathInvalidCompressionFormatInvalidFileHeaderInfoInvalidJSONTypeInvalidQuoteFieldsInvalidRequestParameterInvalidDataTypeInvalidTextEncodingInvalidDataSourceInvalidTableAliasMissingRequiredParameterObjectSerializationConflictUnsupportedSQLOperationUnsupportedSQLStructureUnsupportedSyntaxUnsupportedRangeHeaderLexerInvalidCharLexerInvalidOperatorLexerInvalidLiteralLexerInvalidIONLiteralParseExpectedDatePartParseExpectedKeywordParseExpectedTokenTypeParseExpected2TokenTypesParseExpectedNumberParseExpectedRightParenBuiltinFunctionCallParseExpectedTypeNameParseExpectedWhenClauseParseUnsupportedTokenParseUnsupportedLiteralsGroupByParseExpectedMemberParseUnsupportedSelectParseUnsupportedCaseParseUnsupportedCaseClauseParseUnsupportedAliasParseUnsupportedSyntaxParseUnknownOperatorParseMissingIdentAfterAtParseUnexpectedOperatorParseUnexpectedTermParseUnexpectedTokenParseUnexpectedKeywordParseExpectedExpressionParseExpectedLeftParenAfterCastParseExpectedLeftParenValueConstructorParseExpectedLeftParenBuiltinFunctionCallParseExpectedArgumentDelimiterParseCastArityParseInvalidTypeParamParseEmptySelectParseSelectMissingFromParseExpectedIdentForGroupNameParseExpectedIdentForAliasParseUnsupportedCallWithStarParseNonUnaryAgregateFunctionCallParseMalformedJoinParseExpectedIdentForAtParseAsteriskIsNotAloneInSelectListParseCannotMixSqbAndWildcardInSelectListParseInvalidContextForWildcardInSelectListIncorrectSQLFunctionArgumentTypeValueParseFailureEvaluatorInvalidArgumentsIntegerOverflowLikeInvalidInputsCastFailedInvalidCastEvaluatorInvalidTimestampFormatPatternEvaluatorInvalidTimestampFormatPatternSymbolForParsingEvaluatorTimestampFormatPatternDuplicateFieldsEvaluatorTimestampFormatPatternHourClockAmPmMismatchEvaluatorUnterminatedTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternSymbolEvaluatorBindingDoesNotExistMissingHeadersInvalidColumnIndexAdminConfigNotificationTargetsFailedAdminProfilerNotEnabledInvalidDecompressedSizeAddUserInvalidArgumentAdminResourceInvalidArgumentAdminAccountNotEligibleAccountNotEligibleAdminServiceAccountNotFoundPostPolicyConditionInvalidFormat"
 
-var _APIErrorCode_index = [...]uint16{0, 4, 16, 25, 39, 53, 67, 81, 94, 112, 129, 146, 159, 171, 193, 213, 239, 253, 274, 291, 306, 329, 346, 364, 381, 405, 420, 441, 459, 471, 491, 508, 531, 552, 564, 582, 603, 631, 661, 682, 705, 731, 768, 798, 831, 856, 888, 918, 947, 972, 994, 1020, 1042, 1070, 1099, 1133, 1164, 1201, 1225, 1253, 1283, 1292, 1304, 1320, 1333, 1347, 1365, 1385, 1406, 1422, 1433, 1449, 1477, 1497, 1513, 1541, 1555, 1572, 1587, 1600, 1614, 1627, 1640, 1656, 1673, 1694, 1708, 1729, 1742, 1764, 1787, 1812, 1828, 1843, 1858, 1879, 1897, 1912, 1929, 1954, 1972, 1995, 2010, 2029, 2045, 2064, 2078, 2086, 2105, 2115, 2130, 2166, 2197, 2230, 2259, 2271, 2291, 2315, 2339, 2360, 2384, 2403, 2426, 2452, 2473, 2491, 2518, 2545, 2566, 2587, 2611, 2636, 2664, 2692, 2708, 2731, 2742, 2754, 2771, 2786, 2804, 2833, 2850, 2866, 2882, 2900, 2918, 2941, 2962, 2972, 2983, 2994, 3010, 3033, 3050, 3078, 3097, 3117, 3134, 3152, 3169, 3183, 3218, 3237, 3248, 3261, 3276, 3292, 3310, 3327, 3347, 3368, 3389, 3408, 3427, 3445, 3468, 3492, 3516, 3540, 3561, 3575, 3604, 3627, 3654, 3688, 3720, 3750, 3773, 3801, 3825, 3854, 3872, 3889, 3911, 3928, 3946, 3966, 3992, 4008, 4027, 4048, 4052, 4070, 4087, 4113, 4127, 4151, 4172, 4187, 4205, 4228, 4243, 4262, 4279, 4296, 4320, 4347, 4370, 4393, 4410, 4432, 4448, 4468, 4487, 4509, 4530, 4550, 4572, 4596, 4615, 4657, 4678, 4701, 4722, 4753, 4772, 4794, 4814, 4840, 4861, 4883, 4903, 4927, 4950, 4969, 4989, 5011, 5034, 5065, 5103, 5144, 5174, 5188, 5209, 5225, 5247, 5277, 5303, 5331, 5364, 5382, 5405, 5440, 5480, 5522, 5554, 5571, 5596, 5611, 5628, 5638, 5649, 5687, 5741, 5787, 5839, 5887, 5930, 5974, 6002, 6016, 6034, 6070, 6093, 6116, 6138, 6166, 6189, 6207, 6234, 6266}
+var _APIErrorCode_index = [...]uint16{0, 4, 16, 25, 39, 53, 67, 81, 94, 112, 129, 146, 159, 171, 193, 213, 239, 253, 274, 291, 306, 329, 346, 364, 381, 405, 420, 441, 459, 471, 491, 508, 531, 552, 564, 582, 603, 631, 661, 682, 705, 731, 768, 798, 831, 856, 888, 918, 947, 972, 994, 1020, 1042, 1070, 1099, 1133, 1164, 1201, 1225, 1253, 1283, 1292, 1304, 1320, 1333, 1347, 1365, 1385, 1406, 1422, 1433, 1449, 1477, 1497, 1513, 1541, 1555, 1572, 1587, 1600, 1614, 1627, 1640, 1656, 1673, 1694, 1708, 1729, 1742, 1764, 1787, 1812, 1828, 1843, 1858, 1879, 1897, 1912, 1929, 1954, 1972, 1995, 2010, 2029, 2043, 2068, 2084, 2103, 2117, 2125, 2144, 2154, 2169, 2205, 2236, 2269, 2298, 2310, 2330, 2354, 2378, 2399, 2423, 2442, 2465, 2491, 2512, 2530, 2557, 2584, 2605, 2626, 2650, 2675, 2703, 2731, 2747, 2770, 2781, 2793, 2810, 2825, 2843, 2872, 2889, 2905, 2921, 2939, 2957, 2980, 3001, 3011, 3022, 3033, 3049, 3072, 3095, 3112, 3140, 3173, 3192, 3212, 3229, 3243, 3261, 3278, 3292, 3327, 3346, 3362, 3374, 3385, 3398, 3413, 3429, 3447, 3464, 3484, 3505, 3526, 3545, 3564, 3582, 3605, 3629, 3653, 3677, 3698, 3712, 3741, 3764, 3791, 3825, 3857, 3887, 3910, 3938, 3962, 3991, 4009, 4026, 4048, 4065, 4083, 4103, 4129, 4145, 4164, 4185, 4189, 4207, 4224, 4250, 4264, 4288, 4309, 4324, 4342, 4365, 4380, 4399, 4416, 4433, 4457, 4484, 4507, 4530, 4547, 4569, 4585, 4605, 4624, 4646, 4667, 4687, 4709, 4733, 4752, 4794, 4815, 4838, 4859, 4890, 4909, 4931, 4951, 4977, 4998, 5020, 5040, 5064, 5087, 5106, 5126, 5148, 5171, 5202, 5240, 5281, 5311, 5325, 5346, 5362, 5384, 5414, 5440, 5468, 5501, 5519, 5542, 5577, 5617, 5659, 5691, 5708, 5733, 5748, 5765, 5775, 5786, 5824, 5878, 5924, 5976, 6024, 6067, 6111, 6139, 6153, 6171, 6207, 6230, 6253, 6275, 6303, 6326, 6344, 6371, 6403}
 
 func (i APIErrorCode) String() string {
 	if i < 0 || i >= APIErrorCode(len(_APIErrorCode_index)-1) {
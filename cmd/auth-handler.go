@@ -35,6 +35,7 @@ import (
 
 	"github.com/minio/minio/internal/auth"
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
+	"github.com/minio/minio/internal/config/api"
 	"github.com/minio/minio/internal/etag"
 	"github.com/minio/minio/internal/hash"
 	xhttp "github.com/minio/minio/internal/http"
@@ -101,6 +102,30 @@ const (
 	authTypeSTS
 )
 
+func (a authType) String() string {
+	switch a {
+	case authTypeAnonymous:
+		return api.AuthTypeAnonymous
+	case authTypePresigned:
+		return api.AuthTypePresigned
+	case authTypePresignedV2:
+		return api.AuthTypePresignedV2
+	case authTypePostPolicy:
+		return api.AuthTypePostPolicy
+	case authTypeStreamingSigned:
+		return api.AuthTypeStreamingSigned
+	case authTypeSigned:
+		return api.AuthTypeSigned
+	case authTypeSignedV2:
+		return api.AuthTypeSignedV2
+	case authTypeJWT:
+		return "jwt"
+	case authTypeSTS:
+		return api.AuthTypeSTS
+	}
+	return "unknown"
+}
+
 // Get request authentication type.
 func getRequestAuthType(r *http.Request) authType {
 	if r.URL != nil {
@@ -149,6 +174,7 @@ func validateAdminSignature(ctx context.Context, r *http.Request, region string)
 		s3Err = isReqAuthenticated(ctx, r, region, serviceS3)
 	}
 	if s3Err != ErrNone {
+		setAuthOutcome(ctx, s3Err)
 		reqInfo := (&logger.ReqInfo{}).AppendTags("requestHeaders", dumpRequest(r))
 		ctx := logger.SetReqInfo(ctx, reqInfo)
 		logger.LogIf(ctx, errors.New(getAPIError(s3Err).Description), logger.Application)
@@ -178,6 +204,7 @@ func checkAdminRequestAuth(ctx context.Context, r *http.Request, action iampolic
 		return cred, ErrNone
 	}
 
+	setAuthOutcome(ctx, ErrAccessDenied)
 	return cred, ErrAccessDenied
 }
 
@@ -304,6 +331,8 @@ func checkRequestAuthType(ctx context.Context, r *http.Request, action policy.Ac
 // returns APIErrorCode if any to be replied to the client.
 // Additionally returns the accessKey used in the request, and if this request is by an admin.
 func checkRequestAuthTypeCredential(ctx context.Context, r *http.Request, action policy.Action, bucketName, objectName string) (cred auth.Credentials, owner bool, s3Err APIErrorCode) {
+	defer func() { setAuthOutcome(ctx, s3Err) }()
+
 	switch getRequestAuthType(r) {
 	case authTypeUnknown, authTypeStreamingSigned:
 		return cred, owner, ErrSignatureVersionNotSupported
@@ -333,6 +362,11 @@ func checkRequestAuthTypeCredential(ctx context.Context, r *http.Request, action
 		// To extract region from XML in request body, get copy of request body.
 		payload, err := ioutil.ReadAll(io.LimitReader(r.Body, maxLocationConstraintSize))
 		if err != nil {
+			switch err.(type) {
+			case hash.SHA256Mismatch, hash.BadDigest:
+				// The body did not match the signed payload.
+				return cred, owner, toAPIErrorCode(ctx, err)
+			}
 			logger.LogIf(ctx, err, logger.Application)
 			return cred, owner, ErrMalformedXML
 		}
@@ -383,6 +417,19 @@ func checkRequestAuthTypeCredential(ctx context.Context, r *http.Request, action
 			}
 		}
 
+		// Object ACLs may grant access to anonymous users unless
+		// the bucket policy explicitly denies the request.
+		if !globalPolicySys.IsDenied(policy.Args{
+			AccountName:     cred.AccessKey,
+			Action:          action,
+			BucketName:      bucketName,
+			ConditionValues: getConditionValues(r, locationConstraint, "", nil),
+			IsOwner:         false,
+			ObjectName:      objectName,
+		}) && isObjectACLAllowed(ctx, r, action, bucketName, objectName, "") {
+			return cred, owner, ErrNone
+		}
+
 		return cred, owner, ErrAccessDenied
 	}
 
@@ -418,11 +465,27 @@ func checkRequestAuthTypeCredential(ctx context.Context, r *http.Request, action
 		}
 	}
 
+	// Object ACLs may grant access to users not allowed by IAM policies.
+	if isObjectACLApplicable(iampolicy.Args{
+		AccountName:     cred.AccessKey,
+		Groups:          cred.Groups,
+		Action:          iampolicy.Action(action),
+		BucketName:      bucketName,
+		ConditionValues: getConditionValues(r, "", cred.AccessKey, cred.Claims),
+		ObjectName:      objectName,
+		IsOwner:         owner,
+		Claims:          cred.Claims,
+	}) && isObjectACLAllowed(ctx, r, action, bucketName, objectName, cred.AccessKey) {
+		return cred, owner, ErrNone
+	}
+
 	return cred, owner, ErrAccessDenied
 }
 
 // Verify if request has valid AWS Signature Version '2'.
 func isReqAuthenticatedV2(r *http.Request) (s3Error APIErrorCode) {
+	defer func() { setAuthOutcome(r.Context(), s3Error) }()
+
 	if isRequestSignatureV2(r) {
 		return doesSignV2Match(r)
 	}
@@ -430,9 +493,16 @@ func isReqAuthenticatedV2(r *http.Request) (s3Error APIErrorCode) {
 }
 
 func reqSignatureV4Verify(r *http.Request, region string, stype serviceType) (s3Error APIErrorCode) {
+	defer func() { setAuthOutcome(r.Context(), s3Error) }()
+
 	sha256sum := getContentSha256Cksum(r, stype)
 	switch {
 	case isRequestSignatureV4(r):
+		// S3 requires header signed requests to send the payload hash,
+		// unless the operator allows it to be left out.
+		if _, ok := r.Header[xhttp.AmzContentSha256]; !ok && stype == serviceS3 && globalAPIConfig.isContentSHA256Required() {
+			return ErrMissingSecurityHeader
+		}
 		return doesSignatureMatch(sha256sum, r, region, stype)
 	case isRequestPresignedSignatureV4(r):
 		return doesPresignedSignatureMatch(sha256sum, r, region, stype)
@@ -496,6 +566,80 @@ func isSupportedS3AuthType(aType authType) bool {
 	return ok
 }
 
+// Auth types which may be disabled by the operator, keyed
+// by their name in the API configuration.
+var configurableAuthTypes = map[string]authType{
+	api.AuthTypeAnonymous:       authTypeAnonymous,
+	api.AuthTypePresigned:       authTypePresigned,
+	api.AuthTypePresignedV2:     authTypePresignedV2,
+	api.AuthTypePostPolicy:      authTypePostPolicy,
+	api.AuthTypeStreamingSigned: authTypeStreamingSigned,
+	api.AuthTypeSigned:          authTypeSigned,
+	api.AuthTypeSignedV2:        authTypeSignedV2,
+	api.AuthTypeSTS:             authTypeSTS,
+}
+
+type contextAuthOutcomeType string
+
+const contextAuthOutcomeKey = contextAuthOutcomeType("request-auth-outcome")
+
+// authOutcome holds the API error code of a request which failed
+// authentication or authorization, it is set by setAuthOutcome to
+// record the auth metrics.
+type authOutcome struct {
+	errCode string
+}
+
+// setAuthOutcome records the failure of an authentication or
+// authorization check of the request, only the first one is kept.
+func setAuthOutcome(ctx context.Context, s3Err APIErrorCode) {
+	if s3Err == ErrNone {
+		return
+	}
+	if outcome, ok := ctx.Value(contextAuthOutcomeKey).(*authOutcome); ok && outcome.errCode == "" {
+		outcome.errCode = errorCodes.ToAPIErr(s3Err).Code
+	}
+}
+
+type contextAuthInfoType string
+
+const contextAuthInfoKey = contextAuthInfoType("request-auth-info")
+
+// reqAuthInfo holds the credential scope of a request, it is set
+// once the request signature has been verified. Signature V2
+// requests carry no scope and only set the access key.
+type reqAuthInfo struct {
+	accessKey string
+	date      time.Time
+	region    string
+	service   string
+	authType  authType
+}
+
+// setReqAuthInfo records the credential scope of a request whose
+// signature was verified, handlers read it back with getReqAuthInfo.
+func setReqAuthInfo(r *http.Request, ch credentialHeader) {
+	if info, ok := r.Context().Value(contextAuthInfoKey).(*reqAuthInfo); ok {
+		*info = reqAuthInfo{
+			accessKey: ch.accessKey,
+			date:      ch.scope.date,
+			region:    ch.scope.region,
+			service:   ch.scope.service,
+			authType:  getRequestAuthType(r),
+		}
+	}
+}
+
+// getReqAuthInfo returns the credential scope of the request, ok
+// is false for anonymous requests and before the signature of the
+// request has been verified.
+func getReqAuthInfo(r *http.Request) (info reqAuthInfo, ok bool) {
+	if v, ok := r.Context().Value(contextAuthInfoKey).(*reqAuthInfo); ok && v.accessKey != "" {
+		return *v, true
+	}
+	return info, false
+}
+
 // setAuthHandler to validate authorization header for the incoming request.
 func setAuthHandler(h http.Handler) http.Handler {
 	// handler for validating incoming authorization headers.
@@ -503,6 +647,27 @@ func setAuthHandler(h http.Handler) http.Handler {
 		tc, ok := r.Context().Value(contextTraceReqKey).(*traceCtxt)
 
 		aType := getRequestAuthType(r)
+		r = r.WithContext(context.WithValue(r.Context(), contextAuthInfoKey, &reqAuthInfo{}))
+		if !guessIsRPCReq(r) {
+			outcome := &authOutcome{}
+			r = r.WithContext(context.WithValue(r.Context(), contextAuthOutcomeKey, outcome))
+			defer func() {
+				globalHTTPStats.totalS3AuthOutcomes.Inc(aType.String(), authOutcomeFromErrCode(outcome.errCode))
+			}()
+		}
+		// Admin requests are exempt, disabling an auth type must
+		// not lock the operator out of the admin API.
+		if globalAPIConfig.isAuthTypeDisabled(aType) && !guessIsRPCReq(r) && !guessIsHealthCheckReq(r) && !guessIsMetricsReq(r) && !isAdminReq(r) {
+			if ok {
+				tc.funcName = "handler.Auth"
+				tc.responseRecorder.LogErrBody = true
+			}
+
+			setAuthOutcome(r.Context(), ErrAccessDenied)
+			writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+			atomic.AddUint64(&globalHTTPStats.rejectedRequestsAuth, 1)
+			return
+		}
 		if aType == authTypeSigned || aType == authTypeSignedV2 || aType == authTypeStreamingSigned {
 			// Verify if date headers are set, if not reject the request
 			amzDate, errCode := parseAmzDateHeader(r)
@@ -515,19 +680,22 @@ func setAuthHandler(h http.Handler) http.Handler {
 				// All our internal APIs are sensitive towards Date
 				// header, for all requests where Date header is not
 				// present we will reject such clients.
+				setAuthOutcome(r.Context(), errCode)
 				writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(errCode), r.URL)
 				atomic.AddUint64(&globalHTTPStats.rejectedRequestsTime, 1)
 				return
 			}
-			// Verify if the request date header is shifted by less than globalMaxSkewTime parameter in the past
-			// or in the future, reject request otherwise.
+			// Verify if the request date header is shifted by less than the configured clock skew
+			// in the past or in the future, reject request otherwise.
 			curTime := UTCNow()
-			if curTime.Sub(amzDate) > globalMaxSkewTime || amzDate.Sub(curTime) > globalMaxSkewTime {
+			maxSkew := globalAPIConfig.getClockSkew()
+			if curTime.Sub(amzDate) > maxSkew || amzDate.Sub(curTime) > maxSkew {
 				if ok {
 					tc.funcName = "handler.Auth"
 					tc.responseRecorder.LogErrBody = true
 				}
 
+				setAuthOutcome(r.Context(), ErrRequestTimeTooSkewed)
 				writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrRequestTimeTooSkewed), r.URL)
 				atomic.AddUint64(&globalHTTPStats.rejectedRequestsTime, 1)
 				return
@@ -543,6 +711,7 @@ func setAuthHandler(h http.Handler) http.Handler {
 			tc.responseRecorder.LogErrBody = true
 		}
 
+		setAuthOutcome(r.Context(), ErrSignatureVersionNotSupported)
 		writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrSignatureVersionNotSupported), r.URL)
 		atomic.AddUint64(&globalHTTPStats.rejectedRequestsAuth, 1)
 	})
@@ -620,6 +789,8 @@ func isPutRetentionAllowed(bucketName, objectName string, retDays int, retDate t
 // call verifies bucket policies and IAM policies, supports multi user
 // checks etc.
 func isPutActionAllowed(ctx context.Context, atype authType, bucketName, objectName string, r *http.Request, action iampolicy.Action) (s3Err APIErrorCode) {
+	defer func() { setAuthOutcome(ctx, s3Err) }()
+
 	var cred auth.Credentials
 	var owner bool
 	switch atype {
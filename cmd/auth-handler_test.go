@@ -23,12 +23,15 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/minio/minio-go/v7/pkg/signer"
 	"github.com/minio/minio/internal/auth"
+	xhttp "github.com/minio/minio/internal/http"
 	iampolicy "github.com/minio/pkg/iam/policy"
 )
 
@@ -358,6 +361,33 @@ func mustNewSignedBadMD5Request(method string, urlStr string, contentLength int6
 	return req
 }
 
+// This is similar to mustNewSignedRequest but the body is replaced after
+// signing, such that it no longer matches the signed X-Amz-Content-Sha256.
+func mustNewSignedTamperedBodyRequest(method string, urlStr string, contentLength int64,
+	body io.ReadSeeker, tamperedBody []byte, t *testing.T,
+) *http.Request {
+	req := mustNewRequest(method, urlStr, contentLength, body, t)
+	req.Header.Del("Content-Md5")
+	cred := globalActiveCred
+	if err := signRequestV4(req, cred.AccessKey, cred.SecretKey); err != nil {
+		t.Fatalf("Unable to initialized new signed http request %s", err)
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(tamperedBody))
+	return req
+}
+
+// This is similar to mustNewSignedRequest but the payload is not part
+// of the signature, x-amz-content-sha256 is set to UNSIGNED-PAYLOAD.
+func mustNewSignedUnsignedPayloadRequest(method string, urlStr string, contentLength int64, body io.ReadSeeker, t *testing.T) *http.Request {
+	req := mustNewRequest(method, urlStr, contentLength, body, t)
+	req.Header.Set("x-amz-content-sha256", unsignedPayload)
+	cred := globalActiveCred
+	if err := signRequestV4(req, cred.AccessKey, cred.SecretKey); err != nil {
+		t.Fatalf("Unable to initialized new signed http request %s", err)
+	}
+	return req
+}
+
 // Tests is requested authenticated function, tests replies for s3 errors.
 func TestIsReqAuthenticated(t *testing.T) {
 	objLayer, fsDir, err := prepareFS()
@@ -398,6 +428,8 @@ func TestIsReqAuthenticated(t *testing.T) {
 		{mustNewSignedShortMD5Request(http.MethodPut, "http://127.0.0.1:9000/", 5, bytes.NewReader([]byte("hello")), t), ErrInvalidDigest},
 		// When request is properly signed, but has bad Content-MD5 header.
 		{mustNewSignedBadMD5Request(http.MethodPut, "http://127.0.0.1:9000/", 5, bytes.NewReader([]byte("hello")), t), ErrBadDigest},
+		// When request is properly signed, but the body does not match the signed payload hash.
+		{mustNewSignedTamperedBodyRequest(http.MethodPut, "http://127.0.0.1:9000/", 5, bytes.NewReader([]byte("hello")), []byte("world"), t), ErrContentSHA256Mismatch},
 		// When request is properly signed, error is none.
 		{mustNewSignedRequest(http.MethodGet, "http://127.0.0.1:9000", 0, nil, t), ErrNone},
 	}
@@ -413,6 +445,461 @@ func TestIsReqAuthenticated(t *testing.T) {
 	}
 }
 
+// Tests that signed requests with UNSIGNED-PAYLOAD are verified without hashing the body.
+func TestIsReqAuthenticatedUnsignedPayload(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Replacing the marker with a real payload hash invalidates the signature.
+	tamperedReq := mustNewSignedUnsignedPayloadRequest(http.MethodPut, "http://127.0.0.1:9000/bucket/object", 5, bytes.NewReader([]byte("hello")), t)
+	tamperedReq.Header.Set("x-amz-content-sha256", getSHA256Hash([]byte("hello")))
+
+	testCases := []struct {
+		req     *http.Request
+		body    string
+		s3Error APIErrorCode
+	}{
+		// Signed GET without payload.
+		{mustNewSignedUnsignedPayloadRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object", 0, nil, t), "", ErrNone},
+		// Signed PUT, the body is not covered by the signature.
+		{mustNewSignedUnsignedPayloadRequest(http.MethodPut, "http://127.0.0.1:9000/bucket/object", 5, bytes.NewReader([]byte("hello")), t), "hello", ErrNone},
+		// Signed PUT with the payload marker replaced after signing.
+		{tamperedReq, "hello", ErrSignatureDoesNotMatch},
+	}
+
+	for i, testCase := range testCases {
+		if s3Error := isReqAuthenticated(ctx, testCase.req, globalSite.Region, serviceS3); s3Error != testCase.s3Error {
+			t.Fatalf("Test %d: expected %s, got %s", i, niceError(testCase.s3Error), niceError(s3Error))
+		}
+		if testCase.s3Error != ErrNone {
+			continue
+		}
+		body, err := ioutil.ReadAll(testCase.req.Body)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error reading body: %v", i, err)
+		}
+		if string(body) != testCase.body {
+			t.Fatalf("Test %d: expected body %q, got %q", i, testCase.body, string(body))
+		}
+	}
+}
+
+// Tests that HEAD requests on buckets and objects verify like GET requests,
+// signed both with the test signer and the minio-go signer.
+func TestIsReqAuthenticatedHead(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cred := globalActiveCred
+	newSDKSignedRequest := func(method, urlStr string) *http.Request {
+		req := mustNewRequest(method, urlStr, 0, nil, t)
+		req.Header.Set(xhttp.AmzContentSha256, emptySHA256)
+		return signer.SignV4(*req, cred.AccessKey, cred.SecretKey, "", globalSite.Region)
+	}
+	newSDKPresignedRequest := func(method, urlStr string) *http.Request {
+		req := mustNewRequest(method, urlStr, 0, nil, t)
+		return signer.PreSignV4(*req, cred.AccessKey, cred.SecretKey, "", globalSite.Region, 600)
+	}
+
+	// A presigned GET URL must not be usable for HEAD, and vice versa.
+	getAsHead := newSDKPresignedRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object")
+	getAsHead.Method = http.MethodHead
+	headAsGet := newSDKPresignedRequest(http.MethodHead, "http://127.0.0.1:9000/bucket/object")
+	headAsGet.Method = http.MethodGet
+
+	testCases := []struct {
+		req     *http.Request
+		s3Error APIErrorCode
+	}{
+		{mustNewSignedRequest(http.MethodHead, "http://127.0.0.1:9000/bucket", 0, nil, t), ErrNone},
+		{mustNewSignedRequest(http.MethodHead, "http://127.0.0.1:9000/bucket/object", 0, nil, t), ErrNone},
+		{newSDKSignedRequest(http.MethodHead, "http://127.0.0.1:9000/bucket"), ErrNone},
+		{newSDKSignedRequest(http.MethodHead, "http://127.0.0.1:9000/bucket/object"), ErrNone},
+		{newSDKPresignedRequest(http.MethodHead, "http://127.0.0.1:9000/bucket"), ErrNone},
+		{newSDKPresignedRequest(http.MethodHead, "http://127.0.0.1:9000/bucket/object"), ErrNone},
+		{getAsHead, ErrSignatureDoesNotMatch},
+		{headAsGet, ErrSignatureDoesNotMatch},
+	}
+
+	for i, testCase := range testCases {
+		// Presigned query parameters are parsed by setAuthHandler.
+		testCase.req.ParseForm()
+		if s3Error := isReqAuthenticated(ctx, testCase.req, globalSite.Region, serviceS3); s3Error != testCase.s3Error {
+			t.Errorf("Test %d: %s %s: expected %s, got %s", i+1, testCase.req.Method, testCase.req.URL.Path,
+				niceError(testCase.s3Error), niceError(s3Error))
+		}
+	}
+}
+
+// Tests that signed PUT requests carrying SSE-C headers verify.
+func TestIsReqAuthenticatedSSEC(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cred := globalActiveCred
+	data := []byte("hello")
+	newSSECRequest := func() *http.Request {
+		req := mustNewRequest(http.MethodPut, "http://127.0.0.1:9000/bucket/object", int64(len(data)), bytes.NewReader(data), t)
+		req.RequestURI = req.URL.RequestURI() // Signature V2 is computed over the raw request URI.
+		req.Header.Set(xhttp.AmzContentSha256, getSHA256Hash(data))
+		req.Header.Set(xhttp.AmzServerSideEncryptionCustomerAlgorithm, xhttp.AmzEncryptionAES)
+		req.Header.Set(xhttp.AmzServerSideEncryptionCustomerKey, "MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0cHJvdmlkZWQ=")
+		req.Header.Set(xhttp.AmzServerSideEncryptionCustomerKeyMD5, "7PpPLAK26ONlVUGOWlusfg==")
+		return req
+	}
+
+	signedV4 := signer.SignV4(*newSSECRequest(), cred.AccessKey, cred.SecretKey, "", globalSite.Region)
+	signedV2 := signer.SignV2(*newSSECRequest(), cred.AccessKey, cred.SecretKey, false)
+	tampered := signer.SignV4(*newSSECRequest(), cred.AccessKey, cred.SecretKey, "", globalSite.Region)
+	tampered.Header.Set(xhttp.AmzServerSideEncryptionCustomerKey, "DzJieXRlc2xvbmdzZWNyZXRrZXltdXN0cHJvdmlkZWQ=")
+
+	if s3Error := isReqAuthenticated(ctx, signedV4, globalSite.Region, serviceS3); s3Error != ErrNone {
+		t.Errorf("expected V4 signed SSE-C request to verify, got %s", niceError(s3Error))
+	}
+	if s3Error := isReqAuthenticatedV2(signedV2); s3Error != ErrNone {
+		t.Errorf("expected V2 signed SSE-C request to verify, got %s", niceError(s3Error))
+	}
+	if s3Error := isReqAuthenticated(ctx, tampered, globalSite.Region, serviceS3); s3Error != ErrSignatureDoesNotMatch {
+		t.Errorf("expected %s for tampered SSE-C key, got %s", niceError(ErrSignatureDoesNotMatch), niceError(s3Error))
+	}
+}
+
+// Tests that header signed requests without x-amz-content-sha256 are
+// rejected unless the operator allows them.
+func TestIsReqAuthenticatedMissingContentSHA256(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+
+	defer func(required bool) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.requireContentSHA256 = required
+		globalAPIConfig.mu.Unlock()
+	}(globalAPIConfig.isContentSHA256Required())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cred := globalActiveCred
+	newSignedRequest := func(contentSHA256 string) *http.Request {
+		req := mustNewRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object", 0, nil, t)
+		req.Header.Del(xhttp.AmzContentSha256)
+		if contentSHA256 != "" {
+			req.Header.Set(xhttp.AmzContentSha256, contentSHA256)
+			return signer.SignV4(*req, cred.AccessKey, cred.SecretKey, "", globalSite.Region)
+		}
+
+		// Sign without x-amz-content-sha256, the payload hash of
+		// such requests defaults to the hash of an empty body.
+		now := UTCNow()
+		req.Header.Set(xhttp.AmzDate, now.Format(iso8601Format))
+		extractedSignedHeaders, errCode := extractSignedHeaders([]string{"host", "x-amz-date"}, req)
+		if errCode != ErrNone {
+			t.Fatal(niceError(errCode))
+		}
+		canonicalRequest := getCanonicalRequest(extractedSignedHeaders, emptySHA256, "", req.URL.EscapedPath(), req.Method)
+		scope := getScope(now, globalSite.Region)
+		signature := getSignature(getSigningKey(cred.SecretKey, now, globalSite.Region, serviceS3),
+			getStringToSign(canonicalRequest, now, scope))
+		req.Header.Set(xhttp.Authorization, signV4Algorithm+" Credential="+cred.AccessKey+"/"+scope+
+			", SignedHeaders=host;x-amz-date, Signature="+signature)
+		return req
+	}
+	newPresignedRequest := func() *http.Request {
+		req := mustNewRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object", 0, nil, t)
+		req = signer.PreSignV4(*req, cred.AccessKey, cred.SecretKey, "", globalSite.Region, 600)
+		req.ParseForm()
+		return req
+	}
+
+	testCases := []struct {
+		required bool
+		req      *http.Request
+		s3Error  APIErrorCode
+	}{
+		{true, newSignedRequest(""), ErrMissingSecurityHeader},
+		{true, newSignedRequest(emptySHA256), ErrNone},
+		{true, newSignedRequest(unsignedPayload), ErrNone},
+		// Presigned requests carry no payload hash.
+		{true, newPresignedRequest(), ErrNone},
+		{false, newSignedRequest(""), ErrNone},
+		{false, newSignedRequest(emptySHA256), ErrNone},
+	}
+
+	for i, testCase := range testCases {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.requireContentSHA256 = testCase.required
+		globalAPIConfig.mu.Unlock()
+
+		if s3Error := isReqAuthenticated(ctx, testCase.req, globalSite.Region, serviceS3); s3Error != testCase.s3Error {
+			t.Errorf("Test %d: expected %s, got %s", i+1, niceError(testCase.s3Error), niceError(s3Error))
+		}
+	}
+}
+
+// Tests that setAuthHandler rejects signed requests outside the configured clock skew.
+func TestAuthHandlerClockSkew(t *testing.T) {
+	defer func(skew time.Duration) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.clockSkew = skew
+		globalAPIConfig.mu.Unlock()
+	}(globalAPIConfig.clockSkew)
+
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	testCases := []struct {
+		clockSkew      time.Duration
+		dateOffset     time.Duration
+		expectedStatus int
+	}{
+		// Default skew, request date within window.
+		{0, -10 * time.Minute, http.StatusOK},
+		// Default skew, request date too far in the past.
+		{0, -20 * time.Minute, http.StatusForbidden},
+		// Default skew, request date too far in the future.
+		{0, 20 * time.Minute, http.StatusForbidden},
+		// Larger configured skew allows older requests.
+		{30 * time.Minute, -20 * time.Minute, http.StatusOK},
+		// Smaller configured skew rejects recent requests.
+		{time.Minute, -5 * time.Minute, http.StatusForbidden},
+	}
+
+	for i, testCase := range testCases {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.clockSkew = testCase.clockSkew
+		globalAPIConfig.mu.Unlock()
+
+		req := mustNewRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object", 0, nil, t)
+		req.Header.Set(xhttp.Authorization, signV4Algorithm+" Credential=access/20220101/us-east-1/s3/aws4_request")
+		req.Header.Set(xhttp.AmzDate, UTCNow().Add(testCase.dateOffset).Format(iso8601Format))
+
+		rec := httptest.NewRecorder()
+		setAuthHandler(okHandler).ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedStatus {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.expectedStatus, rec.Code)
+		}
+	}
+}
+
+// Tests that setAuthHandler rejects requests of auth types disabled by the operator.
+func TestAuthHandlerDisabledAuthTypes(t *testing.T) {
+	defer func(disabled map[authType]struct{}) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.disabledAuthTypes = disabled
+		globalAPIConfig.mu.Unlock()
+	}(globalAPIConfig.disabledAuthTypes)
+
+	globalAPIConfig.mu.Lock()
+	globalAPIConfig.disabledAuthTypes = map[authType]struct{}{
+		authTypeAnonymous: {},
+		authTypePresigned: {},
+	}
+	globalAPIConfig.mu.Unlock()
+
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	signedReq := mustNewRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object", 0, nil, t)
+	signedReq.Header.Set(xhttp.Authorization, signV4Algorithm+" Credential=access/20220101/us-east-1/s3/aws4_request")
+	signedReq.Header.Set(xhttp.AmzDate, UTCNow().Format(iso8601Format))
+
+	testCases := []struct {
+		req            *http.Request
+		expectedStatus int
+	}{
+		// Anonymous requests are disabled.
+		{mustNewRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object", 0, nil, t), http.StatusForbidden},
+		// Presigned requests are disabled.
+		{mustNewRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object?X-Amz-Credential=access", 0, nil, t), http.StatusForbidden},
+		// Signed requests are still allowed.
+		{signedReq, http.StatusOK},
+		// Anonymous health checks are never rejected.
+		{mustNewRequest(http.MethodGet, "http://127.0.0.1:9000"+healthCheckPathPrefix+healthCheckLivenessPath, 0, nil, t), http.StatusOK},
+		// Admin requests are left to the admin API to authenticate.
+		{mustNewRequest(http.MethodGet, "http://127.0.0.1:9000"+adminPathPrefix+adminAPIVersionPrefix+"/info", 0, nil, t), http.StatusOK},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		setAuthHandler(okHandler).ServeHTTP(rec, testCase.req)
+		if rec.Code != testCase.expectedStatus {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.expectedStatus, rec.Code)
+		}
+	}
+}
+
+// Tests that setAuthHandler records the outcome of requests per auth type.
+func TestAuthHandlerAuthOutcomes(t *testing.T) {
+	testCases := []struct {
+		authErrCode     APIErrorCode
+		errCode         APIErrorCode
+		signed          bool
+		expectedType    string
+		expectedOutcome string
+	}{
+		{ErrNone, ErrNone, false, "anonymous", authOutcomeSuccess},
+		{ErrAccessDenied, ErrNone, false, "anonymous", authOutcomeAccessDenied},
+		{ErrSignatureDoesNotMatch, ErrNone, true, "signed", authOutcomeSignatureMismatch},
+		{ErrInternalError, ErrNone, true, "signed", authOutcomeInternalError},
+		{ErrInvalidAccessKeyID, ErrNone, true, "signed", authOutcomeOtherError},
+		// Errors after the auth checks passed are not auth failures.
+		{ErrNone, ErrNoSuchKey, true, "signed", authOutcomeSuccess},
+		{ErrNone, ErrInternalError, false, "anonymous", authOutcomeSuccess},
+	}
+
+	for i, testCase := range testCases {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := newContext(r, w, "Test")
+			if testCase.authErrCode != ErrNone {
+				setAuthOutcome(ctx, testCase.authErrCode)
+				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(testCase.authErrCode), r.URL)
+				return
+			}
+			if testCase.errCode != ErrNone {
+				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(testCase.errCode), r.URL)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+
+		req := mustNewRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object", 0, nil, t)
+		if testCase.signed {
+			req.Header.Set(xhttp.Authorization, signV4Algorithm+" Credential=access/20220101/us-east-1/s3/aws4_request")
+			req.Header.Set(xhttp.AmzDate, UTCNow().Format(iso8601Format))
+		}
+
+		before := globalHTTPStats.totalS3AuthOutcomes.Load()[testCase.expectedType][testCase.expectedOutcome]
+		setAuthHandler(handler).ServeHTTP(httptest.NewRecorder(), req)
+		after := globalHTTPStats.totalS3AuthOutcomes.Load()[testCase.expectedType][testCase.expectedOutcome]
+		if after != before+1 {
+			t.Errorf("Test %d: expected %s/%s to be incremented, got %d -> %d", i+1, testCase.expectedType, testCase.expectedOutcome, before, after)
+		}
+	}
+}
+
+// Tests that the credential scope of verified requests is available to handlers.
+func TestAuthHandlerReqAuthInfo(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+
+	cred := globalActiveCred
+	newRequest := func() *http.Request {
+		req := mustNewRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object", 0, nil, t)
+		req.RequestURI = req.URL.RequestURI() // Signature V2 is computed over the raw request URI.
+		return req
+	}
+	signedV4 := newRequest()
+	signedV4.Header.Set(xhttp.AmzContentSha256, emptySHA256)
+	signedV4 = signer.SignV4(*signedV4, cred.AccessKey, cred.SecretKey, "", globalSite.Region)
+	presignedV4 := signer.PreSignV4(*newRequest(), cred.AccessKey, cred.SecretKey, "", globalSite.Region, 600)
+	presignedV4.RequestURI = presignedV4.URL.RequestURI()
+	badSignedV4 := newRequest()
+	badSignedV4.Header.Set(xhttp.AmzContentSha256, emptySHA256)
+	badSignedV4 = signer.SignV4(*badSignedV4, cred.AccessKey, "wrongsecret", "", globalSite.Region)
+
+	testCases := []struct {
+		req          *http.Request
+		expectedOk   bool
+		expectedType authType
+		region       string
+		service      string
+	}{
+		{signedV4, true, authTypeSigned, globalSite.Region, string(serviceS3)},
+		{presignedV4, true, authTypePresigned, globalSite.Region, string(serviceS3)},
+		{signer.SignV2(*newRequest(), cred.AccessKey, cred.SecretKey, false), true, authTypeSignedV2, "", ""},
+		{badSignedV4, false, authTypeUnknown, "", ""},
+		{newRequest(), false, authTypeUnknown, "", ""},
+	}
+
+	for i, testCase := range testCases {
+		var (
+			info reqAuthInfo
+			ok   bool
+		)
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := getReqAuthInfo(r); ok {
+				t.Errorf("Test %d: expected no auth info before the signature is verified", i+1)
+			}
+			r.ParseForm()
+			switch getRequestAuthType(r) {
+			case authTypeSignedV2, authTypePresignedV2:
+				isReqAuthenticatedV2(r)
+			case authTypeSigned, authTypePresigned:
+				isReqAuthenticated(r.Context(), r, globalSite.Region, serviceS3)
+			}
+			info, ok = getReqAuthInfo(r)
+			if !ok {
+				return
+			}
+			// The verified access key is used without parsing the request again.
+			r.Header.Del(xhttp.Authorization)
+			r.Form = nil
+			if c, _, s3Err := getReqAccessKeyV4(r, globalSite.Region, serviceS3); s3Err != ErrNone || c.AccessKey != cred.AccessKey {
+				t.Errorf("Test %d: expected the verified access key from V4, got %q (%v)", i+1, c.AccessKey, s3Err)
+			}
+			if c, _, s3Err := getReqAccessKeyV2(r); s3Err != ErrNone || c.AccessKey != cred.AccessKey {
+				t.Errorf("Test %d: expected the verified access key from V2, got %q (%v)", i+1, c.AccessKey, s3Err)
+			}
+		})
+		setAuthHandler(handler).ServeHTTP(httptest.NewRecorder(), testCase.req)
+
+		if ok != testCase.expectedOk {
+			t.Fatalf("Test %d: expected ok to be %t, got %t", i+1, testCase.expectedOk, ok)
+		}
+		if !ok {
+			continue
+		}
+		if info.accessKey != cred.AccessKey || info.authType != testCase.expectedType ||
+			info.region != testCase.region || info.service != testCase.service {
+			t.Errorf("Test %d: unexpected auth info %+v", i+1, info)
+		}
+		if testCase.service != "" && !info.date.Equal(UTCNow().Truncate(24*time.Hour)) {
+			t.Errorf("Test %d: unexpected credential date %s", i+1, info.date)
+		}
+	}
+}
+
 func TestCheckAdminRequestAuthType(t *testing.T) {
 	objLayer, fsDir, err := prepareFS()
 	if err != nil {
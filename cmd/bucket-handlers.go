@@ -24,6 +24,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/textproto"
 	"net/url"
@@ -302,12 +303,6 @@ func (api objectAPIHandlers) ListBucketsHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	// Anonymous users, should be rejected.
-	if cred.AccessKey == "" {
-		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
-		return
-	}
-
 	// If etcd, dns federation configured list buckets from etcd.
 	var bucketsInfo []BucketInfo
 	if globalDNSConfig != nil && globalBucketFederation {
@@ -339,7 +334,30 @@ func (api objectAPIHandlers) ListBucketsHandler(w http.ResponseWriter, r *http.R
 		}
 	}
 
-	if s3Error == ErrAccessDenied {
+	if cred.AccessKey == "" {
+		// Anonymous users only see buckets with a
+		// bucket policy allowing anyone to list them.
+		r.Header.Set("prefix", "")
+		r.Header.Set("delimiter", SlashSeparator)
+
+		n := 0
+		for _, bucketInfo := range bucketsInfo {
+			if globalPolicySys.IsAllowed(policy.Args{
+				Action:          policy.ListBucketAction,
+				BucketName:      bucketInfo.Name,
+				ConditionValues: getConditionValues(r, "", "", nil),
+				IsOwner:         false,
+			}) {
+				bucketsInfo[n] = bucketInfo
+				n++
+			}
+		}
+		bucketsInfo = bucketsInfo[:n]
+		if len(bucketsInfo) == 0 {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+			return
+		}
+	} else if s3Error == ErrAccessDenied {
 		// Set prefix value for "s3:prefix" policy conditionals.
 		r.Header.Set("prefix", "")
 
@@ -421,9 +439,28 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 		return
 	}
 
+	// Call checkRequestAuthType to populate ReqInfo.AccessKey before GetBucketInfo()
+	// and to verify the request body against the signed payload hash when it is read.
+	// Ignore access denied errors here to preserve the S3 error behavior of GetBucketInfo(),
+	// permissions are checked for each object below.
+	if s3Error := checkRequestAuthType(ctx, r, policy.DeleteObjectAction, bucket, ""); s3Error != ErrNone && s3Error != ErrAccessDenied {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
 	// The max. XML contains 100000 object names (each at most 1024 bytes long) + XML overhead
 	const maxBodySize = 2 * 100000 * 1024
 
+	// Read the whole body, the payload hash is only verified once the body is drained.
+	deleteObjectsBytes, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Populate payload again, per object auth checks below wrap the body again.
+	r.Body = ioutil.NopCloser(bytes.NewReader(deleteObjectsBytes))
+
 	// Unmarshal list of keys to be deleted.
 	deleteObjectsReq := &DeleteObjectsRequest{}
 	if err := xmlDecoder(r.Body, deleteObjectsReq, maxBodySize); err != nil {
@@ -442,12 +479,8 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 	// Make sure to update context to print ObjectNames for multi objects.
 	ctx = updateReqContext(ctx, objects...)
 
-	// Call checkRequestAuthType to populate ReqInfo.AccessKey before GetBucketInfo()
-	// Ignore errors here to preserve the S3 error behavior of GetBucketInfo()
-	checkRequestAuthType(ctx, r, policy.DeleteObjectAction, bucket, "")
-
 	// Before proceeding validate if bucket exists.
-	_, err := objectAPI.GetBucketInfo(ctx, bucket)
+	_, err = objectAPI.GetBucketInfo(ctx, bucket)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
@@ -491,7 +524,12 @@ func (api objectAPIHandlers) DeleteMultipleObjectsHandler(w http.ResponseWriter,
 	vc, _ := globalBucketVersioningSys.Get(bucket)
 	oss := make([]*objSweeper, len(deleteObjectsReq.Objects))
 	for index, object := range deleteObjectsReq.Objects {
-		if apiErrCode := checkRequestAuthType(ctx, r, policy.DeleteObjectAction, bucket, object.ObjectName); apiErrCode != ErrNone {
+		apiErrCode := checkRequestAuthType(ctx, r, policy.DeleteObjectAction, bucket, object.ObjectName)
+		if apiErrCode == ErrNone && object.VersionID != "" {
+			// Deleting a specific version additionally requires s3:DeleteObjectVersion.
+			apiErrCode = checkRequestAuthType(ctx, r, policy.DeleteObjectVersionAction, bucket, object.ObjectName)
+		}
+		if apiErrCode != ErrNone {
 			if apiErrCode == ErrSignatureDoesNotMatch || apiErrCode == ErrInvalidAccessKeyID {
 				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(apiErrCode), r.URL)
 				return
@@ -726,11 +764,32 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
+	acl := r.Header.Get(xhttp.AmzACL)
+	if acl != "" && !isValidCannedACL(acl) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidCannedACL), r.URL)
+		return
+	}
+
 	if s3Error := checkRequestAuthType(ctx, r, policy.CreateBucketAction, bucket, ""); s3Error != ErrNone {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
 		return
 	}
 
+	// Public canned ACLs are stored as the bucket policy.
+	if isPublicCannedACL(acl) {
+		if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+			return
+		}
+	}
+
+	// New buckets must follow the DNS naming rules, reject
+	// invalid names before consulting the DNS federation.
+	if !IsValidBucketName(bucket) || isMinioMetaBucketName(bucket) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidBucketName), r.URL)
+		return
+	}
+
 	// Parse incoming location constraint.
 	location, s3Error := parseLocationConstraint(r)
 	if s3Error != ErrNone {
@@ -741,10 +800,43 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 	// Validate if location sent by the client is valid, reject
 	// requests which do not follow valid region requirements.
 	if !isValidLocation(location) {
-		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidRegion), r.URL)
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidLocationConstraint), r.URL)
 		return
 	}
 
+	// Count the existing buckets against the configured limit, counting
+	// from the backend keeps the limit accurate across restarts. Creating
+	// an existing bucket is left to fail with the usual error.
+	if maxBuckets := globalAPIConfig.getMaxBuckets(); maxBuckets > 0 {
+		// Serialize bucket creation while a limit is set, concurrent
+		// requests could otherwise all pass the count below.
+		nsLock := objectAPI.NewNSLock(minioMetaBucket, "max-buckets.lock")
+		lkctx, err := nsLock.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		ctx = lkctx.Context()
+		defer nsLock.Unlock(lkctx.Cancel)
+
+		buckets, err := objectAPI.ListBuckets(ctx)
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		exists := false
+		for _, b := range buckets {
+			if b.Name == bucket {
+				exists = true
+				break
+			}
+		}
+		if !exists && len(buckets) >= maxBuckets {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrTooManyBuckets), r.URL)
+			return
+		}
+	}
+
 	opts := BucketOptions{
 		Location:    location,
 		LockEnabled: objectLockEnabled,
@@ -772,6 +864,11 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 				// Load updated bucket metadata into memory.
 				globalNotificationSys.LoadBucketMetadata(GlobalContext, bucket)
 
+				if err = setBucketCannedACL(ctx, bucket, acl); err != nil {
+					writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+					return
+				}
+
 				// Make sure to add Location information here only for bucket
 				w.Header().Set(xhttp.Location,
 					getObjectLocation(r, globalDomainNames, bucket, ""))
@@ -827,6 +924,12 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Translate a public canned ACL into the equivalent bucket policy.
+	if err := setBucketCannedACL(ctx, bucket, acl); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
 	// Make sure to add Location information here only for bucket
 	if cp := pathClean(r.URL.Path); cp != "" {
 		w.Header().Set(xhttp.Location, cp) // Clean any trailing slashes.
@@ -934,6 +1037,10 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		formValues.Set("Key", strings.ReplaceAll(formValues.Get("Key"), "${filename}", fileName))
 	}
 	object := trimLeadingSlash(formValues.Get("Key"))
+	if hasBadControlCharacter(object) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidObjectNameControlCharacter), r.URL)
+		return
+	}
 
 	successRedirect := formValues.Get("success_action_redirect")
 	successStatus := formValues.Get("success_action_status")
@@ -949,6 +1056,7 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 	// Verify policy signature.
 	cred, errCode := doesPolicySignatureMatch(formValues)
 	if errCode != ErrNone {
+		setAuthOutcome(ctx, errCode)
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(errCode), r.URL)
 		return
 	}
@@ -965,6 +1073,7 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		IsOwner:         globalActiveCred.AccessKey == cred.AccessKey,
 		Claims:          cred.Claims,
 	}) {
+		setAuthOutcome(ctx, ErrAccessDenied)
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
 		return
 	}
@@ -1007,6 +1116,11 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		}
 	}
 
+	if err = checkObjectNameCaseCollision(ctx, objectAPI, bucket, object); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
 	// Extract metadata to be saved from received Form.
 	metadata := make(map[string]string)
 	err = extractMetadataFromMime(ctx, textproto.MIMEHeader(formValues), metadata)
@@ -1218,6 +1332,13 @@ func (api objectAPIHandlers) HeadBucketHandler(w http.ResponseWriter, r *http.Re
 	}
 
 	if s3Error := checkRequestAuthType(ctx, r, policy.ListBucketAction, bucket, ""); s3Error != ErrNone {
+		// Callers lacking access are still told when the
+		// bucket does not exist, as S3 does.
+		if s3Error == ErrAccessDenied {
+			if _, err := objectAPI.GetBucketInfo(ctx, bucket); isErrBucketNotFound(err) {
+				s3Error = ErrNoSuchBucket
+			}
+		}
 		writeErrorResponseHeadersOnly(w, errorCodes.ToAPIErr(s3Error))
 		return
 	}
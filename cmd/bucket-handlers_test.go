@@ -19,15 +19,19 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/minio/minio/internal/auth"
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 // Wrapper for calling RemoveBucket HTTP handler tests for both Erasure multiple disks and single node setup.
@@ -89,6 +93,7 @@ func testGetBucketLocationHandler(obj ObjectLayer, instanceType, bucketName stri
 		bucketName string
 		accessKey  string
 		secretKey  string
+		region     string
 		// expected Response.
 		expectedRespStatus int
 		locationResponse   []byte
@@ -122,11 +127,28 @@ func testGetBucketLocationHandler(obj ObjectLayer, instanceType, bucketName stri
 			},
 			shouldPass: false,
 		},
+		// Test case - 3.
+		// Tests for a configured region other than the default.
+		{
+			bucketName:         bucketName,
+			accessKey:          credentials.AccessKey,
+			secretKey:          credentials.SecretKey,
+			region:             "us-west-2",
+			expectedRespStatus: http.StatusOK,
+			locationResponse: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-west-2</LocationConstraint>`),
+			errorResponse: APIErrorResponse{},
+			shouldPass:    true,
+		},
 	}
 
+	defaultRegion := globalSite.Region
+	defer func() { globalSite.Region = defaultRegion }()
+
 	for i, testCase := range testCases {
-		if i != 1 {
-			continue
+		globalSite.Region = defaultRegion
+		if testCase.region != "" {
+			globalSite.Region = testCase.region
 		}
 		// initialize httptest Recorder, this records any mutations to response writer inside the handler.
 		rec := httptest.NewRecorder()
@@ -218,6 +240,106 @@ func testGetBucketLocationHandler(obj ObjectLayer, instanceType, bucketName stri
 	ExecObjectLayerAPINilTest(t, nilBucket, "", instanceType, apiRouter, nilReq)
 }
 
+// Wrapper for calling PutBucket HTTP handler tests with a bucket limit for both Erasure multiple disks and single node setup.
+func TestPutBucketHandlerMaxBuckets(t *testing.T) {
+	ExecObjectLayerAPITest(t, testPutBucketHandlerMaxBuckets, []string{"PutBucket"})
+}
+
+func testPutBucketHandlerMaxBuckets(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T,
+) {
+	globalAPIConfig.mu.Lock()
+	globalAPIConfig.maxBuckets = 3
+	globalAPIConfig.mu.Unlock()
+	defer func() {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.maxBuckets = 0
+		globalAPIConfig.mu.Unlock()
+	}()
+
+	// bucketName already exists, so two more buckets fit under the limit.
+	testCases := []struct {
+		bucketName         string
+		expectedRespStatus int
+		expectedErrCode    string
+	}{
+		{bucketName + "-1", http.StatusOK, ""},
+		{bucketName + "-2", http.StatusOK, ""},
+		{bucketName + "-3", http.StatusBadRequest, "TooManyBuckets"},
+		// Existing buckets report the usual error.
+		{bucketName, http.StatusConflict, "BucketAlreadyOwnedByYou"},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getMakeBucketURL("", testCase.bucketName),
+			0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for PutBucket: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`",
+				i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		if testCase.expectedErrCode == "" {
+			continue
+		}
+		errResp := APIErrorResponse{}
+		if err = xml.Unmarshal(rec.Body.Bytes(), &errResp); err != nil {
+			t.Fatalf("Test %d: %s: Failed to unmarshal error response: <ERROR> %v", i+1, instanceType, err)
+		}
+		if errResp.Code != testCase.expectedErrCode {
+			t.Fatalf("Test %d: %s: Expected error code %s, got %s", i+1, instanceType, testCase.expectedErrCode, errResp.Code)
+		}
+	}
+
+	// Concurrent requests must not exceed the limit, three buckets
+	// exist so only two of them can succeed.
+	globalAPIConfig.mu.Lock()
+	globalAPIConfig.maxBuckets = 5
+	globalAPIConfig.mu.Unlock()
+
+	const concurrent = 4
+	var wg sync.WaitGroup
+	codes := make([]int, concurrent)
+	for i := 0; i < concurrent; i++ {
+		req, err := newTestSignedRequestV4(http.MethodPut, getMakeBucketURL("", fmt.Sprintf("%s-c%d", bucketName, i)),
+			0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for PutBucket: <ERROR> %v", instanceType, err)
+		}
+		wg.Add(1)
+		go func(i int, req *http.Request) {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			apiRouter.ServeHTTP(rec, req)
+			codes[i] = rec.Code
+		}(i, req)
+	}
+	wg.Wait()
+	created := 0
+	for _, code := range codes {
+		if code == http.StatusOK {
+			created++
+		}
+	}
+	if created != 2 {
+		t.Fatalf("%s: Expected 2 concurrent PutBucket requests to succeed, got %d (%v)", instanceType, created, codes)
+	}
+
+	// HTTP request for testing when `objectLayer` is set to `nil`.
+	nilBucket := "dummy-bucket"
+	nilReq, err := newTestSignedRequestV4(http.MethodPut, getMakeBucketURL("", nilBucket),
+		0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+	if err != nil {
+		t.Errorf("MinIO %s: Failed to create HTTP request for testing the response when object Layer is set to `nil`.", instanceType)
+	}
+	// execute the object layer set to `nil` test.
+	// `ExecObjectLayerAPINilTest` manages the operation.
+	ExecObjectLayerAPINilTest(t, nilBucket, "", instanceType, apiRouter, nilReq)
+}
+
 // Wrapper for calling HeadBucket HTTP handler tests for both Erasure multiple disks and single node setup.
 func TestHeadBucketHandler(t *testing.T) {
 	ExecObjectLayerAPITest(t, testHeadBucketHandler, []string{"HeadBucket"})
@@ -259,8 +381,25 @@ func testHeadBucketHandler(obj ObjectLayer, instanceType, bucketName string, api
 			secretKey:          "abcd",
 			expectedRespStatus: http.StatusForbidden,
 		},
+		// Test case - 4.
+		// Anonymous request for an existing bucket.
+		{
+			bucketName:         bucketName,
+			expectedRespStatus: http.StatusForbidden,
+		},
+		// Test case - 5.
+		// Anonymous request for a non-existent bucket.
+		{
+			bucketName:         "2333",
+			expectedRespStatus: http.StatusNotFound,
+		},
 	}
 
+	// The bucket region is sent along with every response.
+	defaultRegion := globalSite.Region
+	defer func() { globalSite.Region = defaultRegion }()
+	globalSite.Region = "us-west-2"
+
 	for i, testCase := range testCases {
 		// initialize HTTP NewRecorder, this records any mutations to response writer inside the handler.
 		rec := httptest.NewRecorder()
@@ -275,6 +414,12 @@ func testHeadBucketHandler(obj ObjectLayer, instanceType, bucketName string, api
 		if rec.Code != testCase.expectedRespStatus {
 			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
 		}
+		if rec.Body.Len() != 0 {
+			t.Errorf("Test %d: %s: Expected no response body, but found `%s`", i+1, instanceType, rec.Body)
+		}
+		if region := rec.Header().Get(xhttp.AmzBucketRegion); region != "us-west-2" {
+			t.Errorf("Test %d: %s: Expected the bucket region to be `us-west-2`, but instead found `%s`", i+1, instanceType, region)
+		}
 
 		// Verify response the V2 signed HTTP request.
 		// initialize HTTP NewRecorder, this records any mutations to response writer inside the handler.
@@ -556,6 +701,94 @@ func testListMultipartUploadsHandler(obj ObjectLayer, instanceType, bucketName s
 	ExecObjectLayerAPINilTest(t, nilBucket, "", instanceType, apiRouter, nilReq)
 }
 
+// Wrapper for calling TestListMultipartUploadsPagination tests for both Erasure multiple disks and single node setup.
+func TestListMultipartUploadsPagination(t *testing.T) {
+	ExecObjectLayerAPITest(t, testListMultipartUploadsPagination, []string{"ListMultipartUploads"})
+}
+
+// testListMultipartUploadsPagination - Tests paging through the uploads of an object using max-uploads and the markers.
+func testListMultipartUploadsPagination(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T,
+) {
+	objectName := "paginated-object"
+	var uploadIDs []string
+	for i := 0; i < 3; i++ {
+		uploadID, err := obj.NewMultipartUpload(context.Background(), bucketName, objectName, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		uploadIDs = append(uploadIDs, uploadID)
+	}
+
+	listUploads := func(keyMarker, uploadIDMarker, maxUploads string) ListMultipartUploadsResponse {
+		rec := httptest.NewRecorder()
+		u := getListMultipartUploadsURLWithParams("", bucketName, objectName, keyMarker, uploadIDMarker, "", maxUploads)
+		req, err := newTestSignedRequestV4(http.MethodGet, u, 0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for ListMultipartUploadsHandler: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+		}
+		var resp ListMultipartUploadsResponse
+		if err = xml.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		return resp
+	}
+
+	seen := make(map[string]bool)
+	resp := listUploads("", "", "2")
+	if !resp.IsTruncated || len(resp.Uploads) != 2 {
+		t.Fatalf("%s: Expected 2 uploads in a truncated response, got %d (truncated: %v)", instanceType, len(resp.Uploads), resp.IsTruncated)
+	}
+	if resp.MaxUploads != 2 {
+		t.Errorf("%s: Expected MaxUploads to be 2, got %d", instanceType, resp.MaxUploads)
+	}
+	if resp.NextKeyMarker != objectName || resp.NextUploadIDMarker != resp.Uploads[1].UploadID {
+		t.Errorf("%s: Unexpected next markers %q, %q", instanceType, resp.NextKeyMarker, resp.NextUploadIDMarker)
+	}
+	for _, upload := range resp.Uploads {
+		if upload.Key != objectName {
+			t.Errorf("%s: Expected key %s, got %s", instanceType, objectName, upload.Key)
+		}
+		if upload.Initiator.ID != globalMinioDefaultOwnerID || upload.Owner.ID != globalMinioDefaultOwnerID {
+			t.Errorf("%s: Expected initiator and owner to be set, got %v, %v", instanceType, upload.Initiator, upload.Owner)
+		}
+		if upload.StorageClass != globalMinioDefaultStorageClass {
+			t.Errorf("%s: Expected storage class %s, got %s", instanceType, globalMinioDefaultStorageClass, upload.StorageClass)
+		}
+		seen[upload.UploadID] = true
+	}
+
+	resp = listUploads(resp.NextKeyMarker, resp.NextUploadIDMarker, "2")
+	if resp.IsTruncated || len(resp.Uploads) != 1 {
+		t.Fatalf("%s: Expected the last upload in a complete response, got %d (truncated: %v)", instanceType, len(resp.Uploads), resp.IsTruncated)
+	}
+	if resp.KeyMarker != objectName || resp.UploadIDMarker == "" {
+		t.Errorf("%s: Expected the markers to be echoed, got %q, %q", instanceType, resp.KeyMarker, resp.UploadIDMarker)
+	}
+	if resp.NextKeyMarker != "" || resp.NextUploadIDMarker != "" {
+		t.Errorf("%s: Expected no next markers, got %q, %q", instanceType, resp.NextKeyMarker, resp.NextUploadIDMarker)
+	}
+	seen[resp.Uploads[0].UploadID] = true
+
+	for _, uploadID := range uploadIDs {
+		if !seen[uploadID] {
+			t.Errorf("%s: Upload %s was not listed", instanceType, uploadID)
+		}
+	}
+
+	// HTTP request for testing when `objectLayer` is set to `nil`.
+	nilBucket := "dummy-bucket"
+	nilReq, err := newTestRequest(http.MethodGet, getListMultipartUploadsURLWithParams("", nilBucket, "", "", "", "", ""), 0, nil)
+	if err != nil {
+		t.Errorf("MinIO %s: Failed to create HTTP request for testing the response when object Layer is set to `nil`.", instanceType)
+	}
+	ExecObjectLayerAPINilTest(t, nilBucket, "", instanceType, apiRouter, nilReq)
+}
+
 // Wrapper for calling TestListBucketsHandler tests for both Erasure multiple disks and single node setup.
 func TestListBucketsHandler(t *testing.T) {
 	ExecObjectLayerAPITest(t, testListBucketsHandler, []string{"ListBuckets"})
@@ -622,7 +855,7 @@ func testListBucketsHandler(obj ObjectLayer, instanceType, bucketName string, ap
 	}
 
 	// Test for Anonymous/unsigned http request.
-	// ListBucketsHandler doesn't support bucket policies, setting the policies shouldn't make a difference.
+	// No bucket has a policy allowing anonymous listing, the request should be rejected.
 	anonReq, err := newTestRequest(http.MethodGet, getListBucketURL(""), 0, nil)
 	if err != nil {
 		t.Fatalf("MinIO %s: Failed to create an anonymous request.", instanceType)
@@ -647,6 +880,55 @@ func testListBucketsHandler(obj ObjectLayer, instanceType, bucketName string, ap
 	ExecObjectLayerAPINilTest(t, "", "", instanceType, apiRouter, nilReq)
 }
 
+// Wrapper for calling anonymous ListBuckets tests for both Erasure multiple disks and single node setup.
+func TestListBucketsHandlerAnonymous(t *testing.T) {
+	ExecObjectLayerAPITest(t, testListBucketsHandlerAnonymous, []string{"ListBuckets", "PutBucketPolicy"})
+}
+
+// testListBucketsHandlerAnonymous - Tests that anonymous requests only
+// list buckets with a policy allowing anyone to list them.
+func testListBucketsHandlerAnonymous(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T,
+) {
+	privateBucket := getRandomBucketName()
+	if err := obj.MakeBucketWithLocation(context.Background(), privateBucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s: Failed to make bucket: <ERROR> %v", instanceType, err)
+	}
+
+	bucketPolicyBuf, err := json.Marshal(getAnonReadOnlyBucketPolicy(bucketName))
+	if err != nil {
+		t.Fatalf("%s: Failed to marshal bucket policy: <ERROR> %v", instanceType, err)
+	}
+	rec := httptest.NewRecorder()
+	req, err := newTestSignedRequestV4(http.MethodPut, getPutPolicyURL("", bucketName),
+		int64(len(bucketPolicyBuf)), bytes.NewReader(bucketPolicyBuf), credentials.AccessKey, credentials.SecretKey, nil)
+	if err != nil {
+		t.Fatalf("%s: Failed to create HTTP request for PutBucketPolicyHandler: <ERROR> %v", instanceType, err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	anonReq, err := newTestRequest(http.MethodGet, getListBucketURL(""), 0, nil)
+	if err != nil {
+		t.Fatalf("%s: Failed to create an anonymous request: <ERROR> %v", instanceType, err)
+	}
+	apiRouter.ServeHTTP(rec, anonReq)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+
+	var listBuckets ListBucketsResponse
+	if err = xml.Unmarshal(rec.Body.Bytes(), &listBuckets); err != nil {
+		t.Fatalf("%s: Failed to parse ListBuckets response: <ERROR> %v", instanceType, err)
+	}
+	if len(listBuckets.Buckets.Buckets) != 1 || listBuckets.Buckets.Buckets[0].Name != bucketName {
+		t.Errorf("%s: Expected only bucket %s to be listed, got %v", instanceType, bucketName, listBuckets.Buckets.Buckets)
+	}
+}
+
 // Wrapper for calling DeleteMultipleObjects HTTP handler tests for both Erasure multiple disks and single node setup.
 func TestAPIDeleteMultipleObjectsHandler(t *testing.T) {
 	ExecObjectLayerAPITest(t, testAPIDeleteMultipleObjectsHandler, []string{"DeleteMultipleObjects", "PutBucketPolicy"})
@@ -897,6 +1179,25 @@ func testAPIDeleteMultipleObjectsHandler(obj ObjectLayer, instanceType, bucketNa
 		}
 	}
 
+	// A body which does not match the signed payload hash must be rejected
+	// before any object is deleted.
+	signedBody := encodeResponse(DeleteObjectsRequest{Objects: getObjectToDeleteList([]string{"private/objecz"})})
+	tamperedBody := encodeResponse(DeleteObjectsRequest{Objects: getObjectToDeleteList([]string{"private/object"})})
+	req, err = newTestSignedRequestV4(http.MethodPost, getDeleteMultipleObjectsURL("", bucketName),
+		int64(len(signedBody)), bytes.NewReader(signedBody), credentials.AccessKey, credentials.SecretKey, nil)
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request for DeleteMultipleObjects: <ERROR> %v", err)
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(tamperedBody))
+	rec = httptest.NewRecorder()
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("MinIO %s: Expected the response status to be `%d` for a tampered body, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	if _, err = obj.GetObjectInfo(GlobalContext, bucketName, "private/object", ObjectOptions{}); err != nil {
+		t.Errorf("MinIO %s: Expected private/object to survive a tampered request, got <ERROR> %v", instanceType, err)
+	}
+
 	// HTTP request to test the case of `objectLayer` being set to `nil`.
 	// There is no need to use an existing bucket or valid input for creating the request,
 	// since the `objectLayer==nil`  check is performed before any other checks inside the handlers.
@@ -50,6 +50,8 @@ const (
 	TransitionedVersionID = "transitioned-versionID"
 	// TransitionTier name of transition storage class
 	TransitionTier = "transition-tier"
+	// AbortMultipartDate date after which an incomplete multipart upload is aborted
+	AbortMultipartDate = "abort-multipart-date"
 )
 
 // LifecycleSys - Bucket lifecycle subsystem.
@@ -776,6 +778,21 @@ func isRestoredObjectOnDisk(meta map[string]string) (onDisk bool) {
 	return onDisk
 }
 
+// isMultipartAbortDue returns true if the metadata of an incomplete multipart
+// upload has an abort date, computed from the bucket lifecycle rules when the
+// upload was initiated, which has passed.
+func isMultipartAbortDue(metadata map[string]string, now time.Time) bool {
+	v, ok := metadata[ReservedMetadataPrefixLower+AbortMultipartDate]
+	if !ok {
+		return false
+	}
+	abortDate, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return false
+	}
+	return !now.Before(abortDate)
+}
+
 // ToLifecycleOpts returns lifecycle.ObjectOpts value for oi.
 func (oi ObjectInfo) ToLifecycleOpts() lifecycle.ObjectOpts {
 	return lifecycle.ObjectOpts{
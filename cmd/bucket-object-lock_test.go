@@ -0,0 +1,100 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+// Tests validation of object lock headers sent along with PutObject.
+func TestCheckPutObjectLockAllowed(t *testing.T) {
+	ExecObjectLayerTest(t, testCheckPutObjectLockAllowed)
+}
+
+func testCheckPutObjectLockAllowed(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket := "lockbucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{LockEnabled: true}); err != nil {
+		t.Fatalf("%s: unable to create bucket: %v", instanceType, err)
+	}
+
+	retainUntil := time.Now().UTC().Add(24 * time.Hour).Format(time.RFC3339)
+	testCases := []struct {
+		headers          map[string]string
+		retentionPermErr APIErrorCode
+		legalHoldPermErr APIErrorCode
+		expectedErr      APIErrorCode
+	}{
+		// No object lock headers.
+		{
+			headers:     map[string]string{},
+			expectedErr: ErrNone,
+		},
+		// Valid retention headers.
+		{
+			headers: map[string]string{
+				xhttp.AmzObjectLockMode:            "GOVERNANCE",
+				xhttp.AmzObjectLockRetainUntilDate: retainUntil,
+			},
+			expectedErr: ErrNone,
+		},
+		// Retention requested without s3:PutObjectRetention.
+		{
+			headers: map[string]string{
+				xhttp.AmzObjectLockMode:            "GOVERNANCE",
+				xhttp.AmzObjectLockRetainUntilDate: retainUntil,
+			},
+			retentionPermErr: ErrAccessDenied,
+			expectedErr:      ErrAccessDenied,
+		},
+		// Valid legal hold header.
+		{
+			headers:     map[string]string{xhttp.AmzObjectLockLegalHold: "ON"},
+			expectedErr: ErrNone,
+		},
+		// Malformed legal hold header.
+		{
+			headers:     map[string]string{xhttp.AmzObjectLockLegalHold: "maybe"},
+			expectedErr: ErrUnknownWORMModeDirective,
+		},
+		// Legal hold requested without s3:PutObjectLegalHold.
+		{
+			headers:          map[string]string{xhttp.AmzObjectLockLegalHold: "ON"},
+			legalHoldPermErr: ErrAccessDenied,
+			expectedErr:      ErrAccessDenied,
+		},
+	}
+
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(http.MethodPut, "/"+bucket+"/object", nil)
+		for k, v := range testCase.headers {
+			req.Header.Set(k, v)
+		}
+		_, _, _, errCode := checkPutObjectLockAllowed(ctx, req, bucket, "object", obj.GetObjectInfo,
+			testCase.retentionPermErr, testCase.legalHoldPermErr)
+		if errCode != testCase.expectedErr {
+			t.Errorf("%s: test %d: expected %v, got %v", instanceType, i+1, testCase.expectedErr, errCode)
+		}
+	}
+}
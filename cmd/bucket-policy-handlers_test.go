@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -172,6 +173,14 @@ func testPutBucketPolicyHandler(obj ObjectLayer, instanceType, bucketName string
 
 	bucketPolicyTemplateWithoutVersion := `{"Version":"","Statement":[{"Sid":"","Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetBucketLocation","s3:ListBucket"],"Resource":["arn:aws:s3:::%s"]},{"Sid":"","Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/this*"]}]}`
 
+	// malformed policies, rejected by the policy parser.
+	malformedPolicies := []string{
+		fmt.Sprintf(`{"Version":"2011-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"]}]}`, bucketName),
+		fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:Unknown"],"Resource":["arn:aws:s3:::%s/*"]}]}`, bucketName),
+		fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Maybe","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"]}]}`, bucketName),
+		fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"],"Condition":{"Unknown":{"aws:SourceIp":"10.0.0.0/8"}}}]}`, bucketName),
+	}
+
 	// test cases with sample input and expected output.
 	testCases := []struct {
 		bucketName string
@@ -184,6 +193,8 @@ func testPutBucketPolicyHandler(obj ObjectLayer, instanceType, bucketName string
 		secretKey string
 		// expected Response.
 		expectedRespStatus int
+		// expected error code, if any.
+		expectedErrCode string
 	}{
 		// Test case - 1.
 		{
@@ -304,6 +315,55 @@ func testPutBucketPolicyHandler(obj ObjectLayer, instanceType, bucketName string
 			accessKey:          credentials.AccessKey,
 			secretKey:          credentials.SecretKey,
 			expectedRespStatus: http.StatusBadRequest,
+			expectedErrCode:    "MalformedPolicy",
+		},
+		// Test case - 11.
+		// Policy with an unsupported version.
+		{
+			bucketName:         bucketName,
+			bucketPolicyReader: bytes.NewReader([]byte(malformedPolicies[0])),
+
+			policyLen:          len(malformedPolicies[0]),
+			accessKey:          credentials.AccessKey,
+			secretKey:          credentials.SecretKey,
+			expectedRespStatus: http.StatusBadRequest,
+			expectedErrCode:    "MalformedPolicy",
+		},
+		// Test case - 12.
+		// Policy with an unsupported action.
+		{
+			bucketName:         bucketName,
+			bucketPolicyReader: bytes.NewReader([]byte(malformedPolicies[1])),
+
+			policyLen:          len(malformedPolicies[1]),
+			accessKey:          credentials.AccessKey,
+			secretKey:          credentials.SecretKey,
+			expectedRespStatus: http.StatusBadRequest,
+			expectedErrCode:    "MalformedPolicy",
+		},
+		// Test case - 13.
+		// Policy statement with an invalid effect.
+		{
+			bucketName:         bucketName,
+			bucketPolicyReader: bytes.NewReader([]byte(malformedPolicies[2])),
+
+			policyLen:          len(malformedPolicies[2]),
+			accessKey:          credentials.AccessKey,
+			secretKey:          credentials.SecretKey,
+			expectedRespStatus: http.StatusBadRequest,
+			expectedErrCode:    "MalformedPolicy",
+		},
+		// Test case - 14.
+		// Policy statement with an unsupported condition.
+		{
+			bucketName:         bucketName,
+			bucketPolicyReader: bytes.NewReader([]byte(malformedPolicies[3])),
+
+			policyLen:          len(malformedPolicies[3]),
+			accessKey:          credentials.AccessKey,
+			secretKey:          credentials.SecretKey,
+			expectedRespStatus: http.StatusBadRequest,
+			expectedErrCode:    "MalformedPolicy",
 		},
 	}
 
@@ -324,6 +384,15 @@ func testPutBucketPolicyHandler(obj ObjectLayer, instanceType, bucketName string
 		if recV4.Code != testCase.expectedRespStatus {
 			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, recV4.Code)
 		}
+		if testCase.expectedErrCode != "" {
+			errorResponse := APIErrorResponse{}
+			if err = xml.Unmarshal(recV4.Body.Bytes(), &errorResponse); err != nil {
+				t.Fatalf("Test %d: %s: Unable to unmarshal response body %s", i+1, instanceType, recV4.Body.String())
+			}
+			if errorResponse.Code != testCase.expectedErrCode {
+				t.Errorf("Test %d: %s: Expected the error code to be `%s`, but instead found `%s`", i+1, instanceType, testCase.expectedErrCode, errorResponse.Code)
+			}
+		}
 		// initialize HTTP NewRecorder, this records any mutations to response writer inside the handler.
 		recV2 := httptest.NewRecorder()
 		// construct HTTP request for PUT bucket policy endpoint.
@@ -59,6 +59,21 @@ func (sys *PolicySys) IsAllowed(args policy.Args) bool {
 	return args.IsOwner
 }
 
+// IsDenied - checks if a statement of the bucket policy explicitly denies given policy args.
+func (sys *PolicySys) IsDenied(args policy.Args) bool {
+	p, err := sys.Get(args.BucketName)
+	if err != nil {
+		return false
+	}
+
+	for _, statement := range p.Statements {
+		if statement.Effect == policy.Deny && !statement.IsAllowed(args) {
+			return true
+		}
+	}
+	return false
+}
+
 // NewPolicySys - creates new policy system.
 func NewPolicySys() *PolicySys {
 	return &PolicySys{}
@@ -168,6 +183,13 @@ func getConditionValues(r *http.Request, lc string, username string, claims map[
 		}
 	}
 
+	// Expose the payload hash the V4 signature is verified with, it
+	// defaults to UNSIGNED-PAYLOAD for presigned requests.
+	switch authType {
+	case authTypeSigned, authTypePresigned, authTypeStreamingSigned:
+		args[xhttp.AmzContentSha256] = []string{getContentSha256Cksum(r, serviceS3)}
+	}
+
 	// JWT specific values
 	for k, v := range claims {
 		vStr, ok := v.(string)
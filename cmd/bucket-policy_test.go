@@ -0,0 +1,130 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/pkg/bucket/policy"
+)
+
+// Tests that the authentication type, signature version and payload
+// hash of a request can be matched by bucket policy conditions.
+func TestGetConditionValuesAuthType(t *testing.T) {
+	accessKey, secretKey := "minioadmin", "minioadmin"
+	urlStr := "http://127.0.0.1:9000/bucket/object"
+
+	anonymous, err := newTestRequest(http.MethodGet, urlStr, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedV4, err := newTestSignedRequestV4(http.MethodGet, urlStr, 0, nil, accessKey, secretKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsignedPayloadV4, err := newTestSignedRequestV4(http.MethodGet, urlStr, 0, nil, accessKey, secretKey,
+		map[string]string{xhttp.AmzContentSha256: unsignedPayload})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedV2, err := newTestSignedRequestV2(http.MethodGet, urlStr, 0, nil, accessKey, secretKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	presignedV4, err := newTestRequest(http.MethodGet, urlStr, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = preSignV4(presignedV4, accessKey, secretKey, 60); err != nil {
+		t.Fatal(err)
+	}
+	// Presigned URLs usually carry no payload hash.
+	presignedV4.Header.Del(xhttp.AmzContentSha256)
+	query := presignedV4.URL.Query()
+	query.Del(xhttp.AmzContentSha256)
+	presignedV4.URL.RawQuery = query.Encode()
+	streaming, err := newTestStreamingSignedRequest(http.MethodPut, urlStr, 1, 1, bytes.NewReader([]byte("a")), accessKey, secretKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	denyPolicy := func(condition string) *policy.Policy {
+		p, err := policy.ParseConfig(bytes.NewReader([]byte(fmt.Sprintf(`{
+	"Version": "2012-10-17",
+	"Statement": [
+		{"Effect": "Allow", "Principal": {"AWS": ["*"]}, "Action": ["s3:GetObject", "s3:PutObject"], "Resource": ["arn:aws:s3:::bucket/*"]},
+		{"Effect": "Deny", "Principal": {"AWS": ["*"]}, "Action": ["s3:GetObject", "s3:PutObject"], "Resource": ["arn:aws:s3:::bucket/*"], "Condition": %s}
+	]
+}`, condition))), "bucket")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	denyPresigned := denyPolicy(`{"StringEquals": {"s3:authType": "REST-QUERY-STRING"}}`)
+	denyUnsignedPayload := denyPolicy(`{"StringEquals": {"s3:x-amz-content-sha256": "UNSIGNED-PAYLOAD"}}`)
+	requireV4 := denyPolicy(`{"StringNotEquals": {"s3:signatureversion": "AWS4-HMAC-SHA256"}}`)
+
+	testCases := []struct {
+		r       *http.Request
+		p       *policy.Policy
+		allowed bool
+	}{
+		{anonymous, denyPresigned, true},
+		{signedV4, denyPresigned, true},
+		{signedV2, denyPresigned, true},
+		{streaming, denyPresigned, true},
+		{presignedV4, denyPresigned, false},
+
+		{anonymous, denyUnsignedPayload, true},
+		{signedV4, denyUnsignedPayload, true},
+		{signedV2, denyUnsignedPayload, true},
+		{streaming, denyUnsignedPayload, true},
+		{unsignedPayloadV4, denyUnsignedPayload, false},
+		{presignedV4, denyUnsignedPayload, false},
+
+		{anonymous, requireV4, false},
+		{signedV4, requireV4, true},
+		{signedV2, requireV4, false},
+		{streaming, requireV4, true},
+		{presignedV4, requireV4, true},
+	}
+
+	for i, testCase := range testCases {
+		if err := testCase.r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		var action policy.Action = policy.GetObjectAction
+		if testCase.r.Method == http.MethodPut {
+			action = policy.PutObjectAction
+		}
+		allowed := testCase.p.IsAllowed(policy.Args{
+			Action:          action,
+			BucketName:      "bucket",
+			ConditionValues: getConditionValues(testCase.r, "", "", nil),
+			ObjectName:      "object",
+		})
+		if allowed != testCase.allowed {
+			t.Errorf("Test %d: expected allowed to be %v, got %v", i+1, testCase.allowed, allowed)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/minio/madmin-go"
@@ -112,7 +113,7 @@ func (sys *BucketQuotaSys) enforceQuotaHard(ctx context.Context, bucket string,
 			return err
 		}
 
-		if bui.Size > 0 && ((bui.Size + uint64(size)) >= q.Quota) {
+		if bui.Size+uint64(size) > q.Quota {
 			return BucketQuotaExceeded{Bucket: bucket}
 		}
 	}
@@ -126,3 +127,79 @@ func enforceBucketQuotaHard(ctx context.Context, bucket string, size int64) erro
 	}
 	return globalBucketQuotaSys.enforceQuotaHard(ctx, bucket, size)
 }
+
+// bucketQuotaHardReader fails with BucketQuotaExceeded once more than
+// the space left within the bucket hard quota has been read, enforcing
+// the quota on uploads whose size is not known upfront.
+type bucketQuotaHardReader struct {
+	io.Reader
+	bucket    string
+	remaining int64
+}
+
+func newBucketQuotaHardReader(ctx context.Context, bucket string, r io.Reader) (io.Reader, error) {
+	if globalBucketQuotaSys == nil {
+		return r, nil
+	}
+
+	q, err := globalBucketQuotaSys.Get(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+	if q == nil || q.Type != madmin.HardQuota || q.Quota == 0 {
+		return r, nil
+	}
+
+	bui, err := globalBucketQuotaSys.GetBucketUsageInfo(bucket)
+	if err != nil {
+		return nil, err
+	}
+	if bui.Size >= q.Quota {
+		return nil, BucketQuotaExceeded{Bucket: bucket}
+	}
+	return &bucketQuotaHardReader{Reader: r, bucket: bucket, remaining: int64(q.Quota - bui.Size)}, nil
+}
+
+func (r *bucketQuotaHardReader) Read(p []byte) (n int, err error) {
+	n, err = r.Reader.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n, BucketQuotaExceeded{Bucket: r.bucket}
+	}
+	return n, err
+}
+
+// enforceBucketQuotaHardMultipart checks the size of the object a multipart
+// upload completes to against the bucket quota. Parts are checked one at a
+// time while uploaded, parts uploaded in parallel may add up to more.
+func enforceBucketQuotaHardMultipart(ctx context.Context, objAPI ObjectLayer, bucket, object, uploadID string, parts []CompletePart) error {
+	if globalBucketQuotaSys == nil {
+		return nil
+	}
+
+	q, err := globalBucketQuotaSys.Get(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	if q == nil || q.Type != madmin.HardQuota || q.Quota == 0 {
+		return nil
+	}
+
+	listPartsInfo, err := objAPI.ListObjectParts(ctx, bucket, object, uploadID, 0, maxPartsList, ObjectOptions{})
+	if err != nil {
+		return err
+	}
+
+	completed := make(map[int]struct{}, len(parts))
+	for _, part := range parts {
+		completed[part.PartNumber] = struct{}{}
+	}
+
+	var size int64
+	for _, part := range listPartsInfo.Parts {
+		if _, ok := completed[part.PartNumber]; ok {
+			size += part.Size
+		}
+	}
+	return globalBucketQuotaSys.enforceQuotaHard(ctx, bucket, size)
+}
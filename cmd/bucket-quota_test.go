@@ -0,0 +1,115 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+// Wrapper for calling bucket quota tests for both Erasure multiple disks and single node setup.
+func TestBucketQuotaHard(t *testing.T) {
+	// Do not leave the object layer behind for tests relying on it being unset.
+	defer setObjectLayer(nil)
+	ExecObjectLayerTest(t, testBucketQuotaHard)
+}
+
+func testBucketQuotaHard(obj ObjectLayer, instanceType string, t1 TestErrHandler) {
+	t, _ := t1.(*testing.T)
+	ctx := context.Background()
+	bucket, object := "quota-bucket", "object"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	quota, err := json.Marshal(madmin.BucketQuota{Quota: 100, Type: madmin.HardQuota})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = globalBucketMetadataSys.Update(ctx, bucket, bucketQuotaConfigFile, quota); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+
+	// Serve the bucket usage from memory instead of the scanner results.
+	var usage uint64
+	sys := NewBucketQuotaSys()
+	sys.bucketStorageCache.Once.Do(func() {
+		sys.bucketStorageCache.TTL = time.Nanosecond
+		sys.bucketStorageCache.Update = func() (interface{}, error) {
+			return DataUsageInfo{BucketsUsage: map[string]BucketUsageInfo{bucket: {Size: usage}}}, nil
+		}
+	})
+	defer func(sys *BucketQuotaSys) { globalBucketQuotaSys = sys }(globalBucketQuotaSys)
+	globalBucketQuotaSys = sys
+
+	testCases := []struct {
+		usage    uint64
+		size     int64
+		exceeded bool
+	}{
+		{0, 50, false},
+		{0, 100, false},
+		// A single object larger than the quota of an empty bucket.
+		{0, 101, true},
+		{60, 40, false},
+		{60, 41, true},
+		{100, 0, false},
+		{100, 1, true},
+	}
+
+	for i, testCase := range testCases {
+		usage = testCase.usage
+		err := enforceBucketQuotaHard(ctx, bucket, testCase.size)
+		if _, exceeded := err.(BucketQuotaExceeded); exceeded != testCase.exceeded {
+			t.Errorf("%s: Test %d: expected quota exceeded to be %v, got %v", instanceType, i+1, testCase.exceeded, err)
+		}
+	}
+
+	// Parts uploaded within the quota may add up to an object exceeding it.
+	usage = 0
+	uploadID, err := obj.NewMultipartUpload(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	var parts []CompletePart
+	for partID := 1; partID <= 3; partID++ {
+		data := bytes.Repeat([]byte{'a'}, 40)
+		if err = enforceBucketQuotaHard(ctx, bucket, int64(len(data))); err != nil {
+			t.Fatalf("%s: part %d: %s", instanceType, partID, err)
+		}
+		pi, err := obj.PutObjectPart(ctx, bucket, object, uploadID, partID, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: %s", instanceType, err)
+		}
+		parts = append(parts, CompletePart{PartNumber: pi.PartNumber, ETag: pi.ETag})
+	}
+
+	err = enforceBucketQuotaHardMultipart(ctx, obj, bucket, object, uploadID, parts)
+	if _, ok := err.(BucketQuotaExceeded); !ok {
+		t.Errorf("%s: expected quota exceeded completing 120 bytes, got %v", instanceType, err)
+	}
+	// Only the parts completed count towards the quota.
+	if err = enforceBucketQuotaHardMultipart(ctx, obj, bucket, object, uploadID, parts[:2]); err != nil {
+		t.Errorf("%s: expected completing 80 bytes to pass, got %v", instanceType, err)
+	}
+}
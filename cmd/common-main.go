@@ -59,6 +59,7 @@ import (
 	"github.com/minio/minio/internal/auth"
 	"github.com/minio/minio/internal/color"
 	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/config/api"
 	"github.com/minio/minio/internal/handlers"
 	"github.com/minio/minio/internal/kms"
 	"github.com/minio/minio/internal/logger"
@@ -675,6 +676,11 @@ func handleCommonEnvVars() {
 		globalMinioEndpoint = u.String()
 	}
 
+	globalHSTSEnabled, err = config.ParseBool(env.Get(api.EnvAPIHSTS, config.EnableOn))
+	if err != nil {
+		logger.Fatal(err, fmt.Sprintf("Invalid %s value in environment variable", api.EnvAPIHSTS))
+	}
+
 	globalFSOSync, err = config.ParseBool(env.Get(config.EnvFSOSync, config.EnableOff))
 	if err != nil {
 		logger.Fatal(config.ErrInvalidFSOSyncValue(err), "Invalid MINIO_FS_OSYNC value in environment variable")
@@ -580,7 +580,7 @@ var getDefaultOptsTests = []struct {
 		copySource:     false,
 		metadata:       nil,
 		encryptionType: "",
-		err:            crypto.ErrInvalidCustomerKey,
+		err:            crypto.ErrMalformedCustomerKey,
 	}, // 2
 	{
 		headers:        http.Header{xhttp.AmzServerSideEncryption: []string{"AES256"}},
@@ -27,6 +27,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/klauspost/readahead"
@@ -45,6 +46,16 @@ func (er erasureObjects) getMultipartSHADir(bucket, object string) string {
 	return getSHA256Hash([]byte(pathJoin(bucket, object)))
 }
 
+// multipartObjectKey records the bucket and object of an upload in its
+// metadata, the stale uploads cleanup derives the upload ID lock from it.
+const multipartObjectKey = ReservedMetadataPrefixLower + "multipart-object"
+
+// newUploadIDLock returns the lock of an upload ID. The lock name must
+// not change, nodes of different releases take it during rolling upgrades.
+func (er erasureObjects) newUploadIDLock(bucket, object, uploadID string) RWLocker {
+	return er.NewNSLock(bucket, pathJoin(object, uploadID))
+}
+
 // checkUploadIDExists - verify if a given uploadID exists and is valid.
 func (er erasureObjects) checkUploadIDExists(ctx context.Context, bucket, object, uploadID string) (err error) {
 	defer func() {
@@ -157,8 +168,8 @@ func (er erasureObjects) cleanupStaleUploadsOnDisk(ctx context.Context, disk Sto
 				return nil
 			}
 			wait := er.deletedCleanupSleeper.Timer(ctx)
-			if now.Sub(fi.ModTime) > expiry {
-				er.renameAll(ctx, minioMetaMultipartBucket, uploadIDPath)
+			if now.Sub(fi.ModTime) > expiry || isMultipartAbortDue(fi.Metadata, now) {
+				er.removeStaleUpload(ctx, disk, uploadIDPath, fi)
 			}
 			wait()
 			return nil
@@ -182,6 +193,31 @@ func (er erasureObjects) cleanupStaleUploadsOnDisk(ctx context.Context, disk Sto
 	})
 }
 
+// removeStaleUpload removes an expired upload while holding its upload ID
+// lock, so that it is not removed while it is being completed. Uploads
+// created by older releases do not record their object and are removed
+// without the lock.
+func (er erasureObjects) removeStaleUpload(ctx context.Context, disk StorageAPI, uploadIDPath string, fi FileInfo) {
+	if bucketObject, ok := fi.Metadata[multipartObjectKey]; ok {
+		bucket, object := path2BucketObject(bucketObject)
+		lk := er.newUploadIDLock(bucket, object, path.Base(uploadIDPath))
+		lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return
+		}
+		defer lk.Unlock(lkctx.Cancel)
+		ctx = lkctx.Context()
+	}
+
+	// The upload might have been completed, aborted or removed
+	// while waiting for the lock.
+	if _, err := disk.ReadVersion(ctx, minioMetaMultipartBucket, uploadIDPath, "", false); err != nil {
+		return
+	}
+	er.renameAll(ctx, minioMetaMultipartBucket, uploadIDPath)
+	atomic.AddUint64(&globalStaleUploadsReaped, 1)
+}
+
 // ListMultipartUploads - lists all the pending multipart
 // uploads for a particular object in a bucket.
 //
@@ -196,6 +232,8 @@ func (er erasureObjects) ListMultipartUploads(ctx context.Context, bucket, objec
 	result.KeyMarker = keyMarker
 	result.Prefix = object
 	result.Delimiter = delimiter
+	result.NextKeyMarker = object
+	result.UploadIDMarker = uploadIDMarker
 
 	var uploadIDs []string
 	var disk StorageAPI
@@ -351,6 +389,9 @@ func (er erasureObjects) newMultipartUpload(ctx context.Context, bucket string,
 
 	// Fill all the necessary metadata.
 	// Update `xl.meta` content on each disks.
+	// Record the object for the stale uploads cleanup.
+	userDefined[multipartObjectKey] = pathJoin(bucket, object)
+
 	for index := range partsMetadata {
 		partsMetadata[index].Fresh = true
 		partsMetadata[index].ModTime = modTime
@@ -462,7 +503,7 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 
 	// Read lock for upload id.
 	// Only held while reading the upload metadata.
-	uploadIDRLock := er.NewNSLock(bucket, pathJoin(object, uploadID))
+	uploadIDRLock := er.newUploadIDLock(bucket, object, uploadID)
 	rlkctx, err := uploadIDRLock.GetRLock(ctx, globalOperationTimeout)
 	if err != nil {
 		return PartInfo{}, err
@@ -607,7 +648,7 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 	}
 
 	// Acquire write lock to update metadata.
-	uploadIDWLock := er.NewNSLock(bucket, pathJoin(object, uploadID))
+	uploadIDWLock := er.newUploadIDLock(bucket, object, uploadID)
 	wlkctx, err := uploadIDWLock.GetLock(pctx, globalOperationTimeout)
 	if err != nil {
 		return PartInfo{}, err
@@ -695,7 +736,7 @@ func (er erasureObjects) GetMultipartInfo(ctx context.Context, bucket, object, u
 		UploadID: uploadID,
 	}
 
-	uploadIDLock := er.NewNSLock(bucket, pathJoin(object, uploadID))
+	uploadIDLock := er.newUploadIDLock(bucket, object, uploadID)
 	lkctx, err := uploadIDLock.GetRLock(ctx, globalOperationTimeout)
 	if err != nil {
 		return MultipartInfo{}, err
@@ -747,7 +788,7 @@ func (er erasureObjects) GetMultipartInfo(ctx context.Context, bucket, object, u
 func (er erasureObjects) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker, maxParts int, opts ObjectOptions) (result ListPartsInfo, err error) {
 	auditObjectErasureSet(ctx, object, &er)
 
-	uploadIDLock := er.NewNSLock(bucket, pathJoin(object, uploadID))
+	uploadIDLock := er.newUploadIDLock(bucket, object, uploadID)
 	lkctx, err := uploadIDLock.GetRLock(ctx, globalOperationTimeout)
 	if err != nil {
 		return ListPartsInfo{}, err
@@ -844,7 +885,7 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 
 	// Hold read-locks to verify uploaded parts, also disallows
 	// parallel part uploads as well.
-	uploadIDLock := er.NewNSLock(bucket, pathJoin(object, uploadID))
+	uploadIDLock := er.newUploadIDLock(bucket, object, uploadID)
 	rlkctx, err := uploadIDLock.GetRLock(ctx, globalOperationTimeout)
 	if err != nil {
 		return oi, err
@@ -960,6 +1001,10 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 	// Save the consolidated actual size.
 	fi.Metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
 
+	// The abort date and the recorded object only apply to the incomplete upload.
+	delete(fi.Metadata, ReservedMetadataPrefixLower+AbortMultipartDate)
+	delete(fi.Metadata, multipartObjectKey)
+
 	// Update all erasure metadata, make sure to not modify fields like
 	// checksum which are different on each disks.
 	for index := range partsMetadata {
@@ -980,6 +1025,12 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 	ctx = lkctx.Context()
 	defer lk.Unlock(lkctx.Cancel)
 
+	// Validate pre-conditions against the current object, if any,
+	// while holding the lock.
+	if err := checkPutPreconditions(ctx, bucket, object, er.getObjectInfo, opts); err != nil {
+		return oi, err
+	}
+
 	// Write final `xl.meta` at uploadID location
 	onlineDisks, err = writeUniqueFileInfo(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath, partsMetadata, writeQuorum)
 	if err != nil {
@@ -1040,7 +1091,7 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 func (er erasureObjects) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string, opts ObjectOptions) (err error) {
 	auditObjectErasureSet(ctx, object, &er)
 
-	lk := er.NewNSLock(bucket, pathJoin(object, uploadID))
+	lk := er.newUploadIDLock(bucket, object, uploadID)
 	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
 	if err != nil {
 		return err
@@ -0,0 +1,165 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Tests cleanup of stale multipart uploads for erasure backend.
+func TestErasureCleanupStaleUploads(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	bucketName := "bucket"
+	objectName := "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucketName, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	z := obj.(*erasureServerPools)
+	er := z.serverPools[0].sets[0]
+
+	// Stale uploads are removed once, even though every drive is cleaned up.
+	uploadID, err := obj.NewMultipartUpload(ctx, bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reaped := atomic.LoadUint64(&globalStaleUploadsReaped)
+	er.cleanupStaleUploads(ctx, time.Nanosecond)
+	if _, err = obj.ListObjectParts(ctx, bucketName, objectName, uploadID, 0, 1, ObjectOptions{}); err == nil {
+		t.Fatal("Expected the stale upload to be removed")
+	}
+	if _, ok := err.(InvalidUploadID); !ok {
+		t.Fatal("Unexpected err: ", err)
+	}
+	if got := atomic.LoadUint64(&globalStaleUploadsReaped); got != reaped+1 {
+		t.Fatalf("Expected %d reaped uploads, got %d", reaped+1, got)
+	}
+
+	// Uploads being completed are left alone, the cleanup
+	// waits for the upload ID lock and checks the upload again.
+	uploadID, err = obj.NewMultipartUpload(ctx, bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Take the lock by name, as nodes of older releases do.
+	lk := er.NewNSLock(bucketName, pathJoin(objectName, uploadID))
+	lkctx, err := lk.GetRLock(ctx, globalOperationTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reaped = atomic.LoadUint64(&globalStaleUploadsReaped)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		er.cleanupStaleUploads(ctx, time.Nanosecond)
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	if err = er.checkUploadIDExists(lkctx.Context(), bucketName, objectName, uploadID); err != nil {
+		t.Fatal("Expected the upload to survive while locked: ", err)
+	}
+
+	// Complete the upload, simulated by removing it, before releasing the lock.
+	er.renameAll(lkctx.Context(), minioMetaMultipartBucket, er.getUploadIDDir(bucketName, objectName, uploadID))
+	lk.RUnlock(lkctx.Cancel)
+	wg.Wait()
+
+	if got := atomic.LoadUint64(&globalStaleUploadsReaped); got != reaped {
+		t.Fatalf("Expected %d reaped uploads, got %d", reaped, got)
+	}
+}
+
+// Tests abortion of incomplete multipart uploads per bucket lifecycle rules for erasure backend.
+func TestErasureCleanupAbortDueUploads(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	bucketName := "bucket"
+	objectName := "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucketName, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	z := obj.(*erasureServerPools)
+	er := z.serverPools[0].sets[0]
+
+	newUpload := func(abortDate time.Time) string {
+		uploadID, err := obj.NewMultipartUpload(ctx, bucketName, objectName, ObjectOptions{
+			UserDefined: map[string]string{
+				ReservedMetadataPrefixLower + AbortMultipartDate: abortDate.Format(time.RFC3339),
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return uploadID
+	}
+
+	dueUploadID := newUpload(UTCNow().Add(-time.Hour))
+	pendingUploadID := newUpload(UTCNow().Add(24 * time.Hour))
+
+	// Uploads are well within the stale uploads expiry,
+	// only the one past its abort date is removed.
+	er.cleanupStaleUploads(ctx, 24*time.Hour)
+	if _, err = obj.ListObjectParts(ctx, bucketName, objectName, dueUploadID, 0, 1, ObjectOptions{}); err == nil {
+		t.Fatal("Expected the upload past its abort date to be removed")
+	}
+	if _, ok := err.(InvalidUploadID); !ok {
+		t.Fatal("Unexpected err: ", err)
+	}
+	if _, err = obj.ListObjectParts(ctx, bucketName, objectName, pendingUploadID, 0, 1, ObjectOptions{}); err != nil {
+		t.Fatal("Expected the upload before its abort date to survive: ", err)
+	}
+
+	// The abort date is not kept on the completed object.
+	data := []byte("abcd")
+	part, err := obj.PutObjectPart(ctx, bucketName, objectName, pendingUploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objInfo, err := obj.CompleteMultipartUpload(ctx, bucketName, objectName, pendingUploadID, []CompletePart{{PartNumber: 1, ETag: part.ETag}}, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := objInfo.UserDefined[ReservedMetadataPrefixLower+AbortMultipartDate]; ok {
+		t.Fatal("Expected the abort date to be removed from the completed object")
+	}
+}
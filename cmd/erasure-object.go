@@ -1081,6 +1081,12 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 		defer lk.Unlock(lkctx.Cancel)
 	}
 
+	// Validate pre-conditions against the current object, if any,
+	// while holding the lock.
+	if err := checkPutPreconditions(ctx, bucket, object, er.getObjectInfo, opts); err != nil {
+		return ObjectInfo{}, err
+	}
+
 	for i, w := range writers {
 		if w == nil {
 			onlineDisks[i] = nil
@@ -1650,6 +1656,13 @@ func (er erasureObjects) PutObjectMetadata(ctx context.Context, bucket, object s
 			return ObjectInfo{}, err
 		}
 	}
+	for k := range cleanMetadata(fi.Metadata) {
+		// Internal keys deleted by EvalMetadataFn are sent with an
+		// empty value, which removes them from the stored version.
+		if _, ok := objInfo.UserDefined[k]; !ok && strings.HasPrefix(strings.ToLower(k), ReservedMetadataPrefixLower) {
+			fi.Metadata[k] = ""
+		}
+	}
 	for k, v := range objInfo.UserDefined {
 		fi.Metadata[k] = v
 	}
@@ -31,6 +31,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -1082,6 +1083,12 @@ func (es *erasureSingle) putObject(ctx context.Context, bucket string, object st
 		defer lk.Unlock(lkctx.Cancel)
 	}
 
+	// Validate pre-conditions against the current object, if any,
+	// while holding the lock.
+	if err := checkPutPreconditions(ctx, bucket, object, es.getObjectInfo, opts); err != nil {
+		return ObjectInfo{}, err
+	}
+
 	for i, w := range writers {
 		if w == nil {
 			onlineDisks[i] = nil
@@ -1566,6 +1573,13 @@ func (es *erasureSingle) PutObjectMetadata(ctx context.Context, bucket, object s
 			return ObjectInfo{}, err
 		}
 	}
+	for k := range cleanMetadata(fi.Metadata) {
+		// Internal keys deleted by EvalMetadataFn are sent with an
+		// empty value, which removes them from the stored version.
+		if _, ok := objInfo.UserDefined[k]; !ok && strings.HasPrefix(strings.ToLower(k), ReservedMetadataPrefixLower) {
+			fi.Metadata[k] = ""
+		}
+	}
 	for k, v := range objInfo.UserDefined {
 		fi.Metadata[k] = v
 	}
@@ -1870,6 +1884,12 @@ func (es *erasureSingle) getMultipartSHADir(bucket, object string) string {
 	return getSHA256Hash([]byte(pathJoin(bucket, object)))
 }
 
+// newUploadIDLock returns the lock of an upload ID. The lock name must
+// not change, it is shared with the erasure backend.
+func (es *erasureSingle) newUploadIDLock(bucket, object, uploadID string) RWLocker {
+	return es.NewNSLock(bucket, pathJoin(object, uploadID))
+}
+
 // checkUploadIDExists - verify if a given uploadID exists and is valid.
 func (es *erasureSingle) checkUploadIDExists(ctx context.Context, bucket, object, uploadID string) (err error) {
 	defer func() {
@@ -1936,8 +1956,8 @@ func (es *erasureSingle) cleanupStaleUploadsOnDisk(ctx context.Context, disk Sto
 				return nil
 			}
 			wait := es.deletedCleanupSleeper.Timer(ctx)
-			if now.Sub(fi.ModTime) > expiry {
-				es.disk.RenameFile(context.Background(), minioMetaMultipartBucket, uploadIDPath, minioMetaTmpDeletedBucket, mustGetUUID())
+			if now.Sub(fi.ModTime) > expiry || isMultipartAbortDue(fi.Metadata, now) {
+				es.removeStaleUpload(ctx, uploadIDPath, fi)
 			}
 			wait()
 			return nil
@@ -1961,6 +1981,31 @@ func (es *erasureSingle) cleanupStaleUploadsOnDisk(ctx context.Context, disk Sto
 	})
 }
 
+// removeStaleUpload removes an expired upload while holding its upload ID
+// lock, so that it is not removed while it is being completed. Uploads
+// created by older releases do not record their object and are removed
+// without the lock.
+func (es *erasureSingle) removeStaleUpload(ctx context.Context, uploadIDPath string, fi FileInfo) {
+	if bucketObject, ok := fi.Metadata[multipartObjectKey]; ok {
+		bucket, object := path2BucketObject(bucketObject)
+		lk := es.newUploadIDLock(bucket, object, path.Base(uploadIDPath))
+		lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return
+		}
+		defer lk.Unlock(lkctx.Cancel)
+		ctx = lkctx.Context()
+	}
+
+	// The upload might have been completed or aborted
+	// while waiting for the lock.
+	if _, err := es.disk.ReadVersion(ctx, minioMetaMultipartBucket, uploadIDPath, "", false); err != nil {
+		return
+	}
+	es.disk.RenameFile(context.Background(), minioMetaMultipartBucket, uploadIDPath, minioMetaTmpDeletedBucket, mustGetUUID())
+	atomic.AddUint64(&globalStaleUploadsReaped, 1)
+}
+
 // ListMultipartUploads - lists all the pending multipart
 // uploads for a particular object in a bucket.
 //
@@ -1977,6 +2022,8 @@ func (es *erasureSingle) ListMultipartUploads(ctx context.Context, bucket, objec
 	result.KeyMarker = keyMarker
 	result.Prefix = object
 	result.Delimiter = delimiter
+	result.NextKeyMarker = object
+	result.UploadIDMarker = uploadIDMarker
 
 	uploadIDs, err := es.disk.ListDir(ctx, minioMetaMultipartBucket, es.getMultipartSHADir(bucket, object), -1)
 	if err != nil {
@@ -2097,6 +2144,9 @@ func (es *erasureSingle) newMultipartUpload(ctx context.Context, bucket string,
 
 	onlineDisks, partsMetadata = shuffleDisksAndPartsMetadata(onlineDisks, partsMetadata, fi)
 
+	// Record the object for the stale uploads cleanup.
+	opts.UserDefined[multipartObjectKey] = pathJoin(bucket, object)
+
 	// Fill all the necessary metadata.
 	// Update `xl.meta` content on each disks.
 	for index := range partsMetadata {
@@ -2171,7 +2221,7 @@ func (es *erasureSingle) PutObjectPart(ctx context.Context, bucket, object, uplo
 
 	// Read lock for upload id.
 	// Only held while reading the upload metadata.
-	uploadIDRLock := es.NewNSLock(bucket, pathJoin(object, uploadID))
+	uploadIDRLock := es.newUploadIDLock(bucket, object, uploadID)
 	rlkctx, err := uploadIDRLock.GetRLock(ctx, globalOperationTimeout)
 	if err != nil {
 		return PartInfo{}, err
@@ -2316,7 +2366,7 @@ func (es *erasureSingle) PutObjectPart(ctx context.Context, bucket, object, uplo
 	}
 
 	// Acquire write lock to update metadata.
-	uploadIDWLock := es.NewNSLock(bucket, pathJoin(object, uploadID))
+	uploadIDWLock := es.newUploadIDLock(bucket, object, uploadID)
 	wlkctx, err := uploadIDWLock.GetLock(pctx, globalOperationTimeout)
 	if err != nil {
 		return PartInfo{}, err
@@ -2406,7 +2456,7 @@ func (es *erasureSingle) GetMultipartInfo(ctx context.Context, bucket, object, u
 		UploadID: uploadID,
 	}
 
-	uploadIDLock := es.NewNSLock(bucket, pathJoin(object, uploadID))
+	uploadIDLock := es.newUploadIDLock(bucket, object, uploadID)
 	lkctx, err := uploadIDLock.GetRLock(ctx, globalOperationTimeout)
 	if err != nil {
 		return MultipartInfo{}, err
@@ -2460,7 +2510,7 @@ func (es *erasureSingle) ListObjectParts(ctx context.Context, bucket, object, up
 		return ListPartsInfo{}, err
 	}
 
-	uploadIDLock := es.NewNSLock(bucket, pathJoin(object, uploadID))
+	uploadIDLock := es.newUploadIDLock(bucket, object, uploadID)
 	lkctx, err := uploadIDLock.GetRLock(ctx, globalOperationTimeout)
 	if err != nil {
 		return ListPartsInfo{}, err
@@ -2559,7 +2609,7 @@ func (es *erasureSingle) CompleteMultipartUpload(ctx context.Context, bucket str
 
 	// Hold read-locks to verify uploaded parts, also disallows
 	// parallel part uploads as well.
-	uploadIDLock := es.NewNSLock(bucket, pathJoin(object, uploadID))
+	uploadIDLock := es.newUploadIDLock(bucket, object, uploadID)
 	rlkctx, err := uploadIDLock.GetRLock(ctx, globalOperationTimeout)
 	if err != nil {
 		return oi, err
@@ -2675,6 +2725,10 @@ func (es *erasureSingle) CompleteMultipartUpload(ctx context.Context, bucket str
 	// Save the consolidated actual size.
 	fi.Metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
 
+	// The abort date and the recorded object only apply to the incomplete upload.
+	delete(fi.Metadata, ReservedMetadataPrefixLower+AbortMultipartDate)
+	delete(fi.Metadata, multipartObjectKey)
+
 	// Update all erasure metadata, make sure to not modify fields like
 	// checksum which are different on each disks.
 	for index := range partsMetadata {
@@ -2695,6 +2749,12 @@ func (es *erasureSingle) CompleteMultipartUpload(ctx context.Context, bucket str
 	ctx = lkctx.Context()
 	defer lk.Unlock(lkctx.Cancel)
 
+	// Validate pre-conditions against the current object, if any,
+	// while holding the lock.
+	if err := checkPutPreconditions(ctx, bucket, object, es.getObjectInfo, opts); err != nil {
+		return oi, err
+	}
+
 	// Write final `xl.meta` at uploadID location
 	onlineDisks, err = writeUniqueFileInfo(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath, partsMetadata, writeQuorum)
 	if err != nil {
@@ -2748,7 +2808,7 @@ func (es *erasureSingle) AbortMultipartUpload(ctx context.Context, bucket, objec
 		return err
 	}
 
-	lk := es.NewNSLock(bucket, pathJoin(object, uploadID))
+	lk := es.newUploadIDLock(bucket, object, uploadID)
 	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
 	if err != nil {
 		return err
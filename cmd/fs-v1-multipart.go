@@ -28,6 +28,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
@@ -713,6 +714,11 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 	ctx = lkctx.Context()
 	defer destLock.Unlock(lkctx.Cancel)
 
+	// Validate pre-conditions against the current object, if any.
+	if err := checkPutPreconditions(ctx, bucket, object, fs.getObjectInfoWithOpts, opts); err != nil {
+		return oi, err
+	}
+
 	bucketMetaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix)
 	fsMetaPath := pathJoin(bucketMetaDir, bucket, object, fs.metaJSONFile)
 	metaFile, err := fs.rwPool.Write(fsMetaPath)
@@ -765,6 +771,9 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 
 	// Save consolidated actual size.
 	fsMeta.Meta[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
+
+	// The abort date only applies to the incomplete upload.
+	delete(fsMeta.Meta, ReservedMetadataPrefixLower+AbortMultipartDate)
 	if _, err = fsMeta.WriteTo(metaFile); err != nil {
 		logger.LogIf(ctx, err)
 		return oi, toObjectErr(err, bucket, object)
@@ -872,6 +881,21 @@ func (fs *FSObjects) getAllUploadIDs(ctx context.Context) (result map[string]str
 	return
 }
 
+// isUploadAbortDue returns true if the upload in the given directory
+// is due to be aborted per the bucket lifecycle rules.
+func (fs *FSObjects) isUploadAbortDue(uploadIDDir string, now time.Time) bool {
+	fsMetaBytes, err := xioutil.ReadFile(pathJoin(uploadIDDir, fs.metaJSONFile))
+	if err != nil {
+		return false
+	}
+	var fsMeta fsMetaV1
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	if err = json.Unmarshal(fsMetaBytes, &fsMeta); err != nil {
+		return false
+	}
+	return isMultipartAbortDue(fsMeta.Meta, now)
+}
+
 // Removes multipart uploads if any older than `expiry` duration
 // on all buckets for every `cleanupInterval`, this function is
 // blocking and should be run in a go-routine.
@@ -925,8 +949,9 @@ func (fs *FSObjects) cleanupStaleUploads(ctx context.Context) {
 				if err != nil {
 					continue
 				}
-				if now.Sub(fi.ModTime()) > expiry {
+				if now.Sub(fi.ModTime()) > expiry || fs.isUploadAbortDue(path, now) {
 					fsRemoveAll(ctx, path)
+					atomic.AddUint64(&globalStaleUploadsReaped, 1)
 					// Remove upload ID parent directory if empty
 					fsRemoveDir(ctx, filepath.Base(path))
 
@@ -953,6 +953,16 @@ func (fs *FSObjects) getObjectInfo(ctx context.Context, bucket, object string) (
 	return fsMeta.ToObjectInfo(bucket, object, fi), nil
 }
 
+// getObjectInfoWithOpts - wraps getObjectInfo as a GetObjectInfoFn,
+// opts are ignored and errors are converted to object layer errors.
+func (fs *FSObjects) getObjectInfoWithOpts(ctx context.Context, bucket, object string, _ ObjectOptions) (ObjectInfo, error) {
+	oi, err := fs.getObjectInfo(ctx, bucket, object)
+	if err != nil {
+		return oi, toObjectErr(err, bucket, object)
+	}
+	return oi, nil
+}
+
 // getObjectInfoWithLock - reads object metadata and replies back ObjectInfo.
 func (fs *FSObjects) getObjectInfoWithLock(ctx context.Context, bucket, object string) (oi ObjectInfo, err error) {
 	// Lock the object before reading.
@@ -1035,6 +1045,11 @@ func (fs *FSObjects) PutObject(ctx context.Context, bucket string, object string
 	ctx = lkctx.Context()
 	defer lk.Unlock(lkctx.Cancel)
 
+	// Validate pre-conditions against the current object, if any.
+	if err := checkPutPreconditions(ctx, bucket, object, fs.getObjectInfoWithOpts, opts); err != nil {
+		return objInfo, err
+	}
+
 	return fs.putObject(ctx, bucket, object, r, opts)
 }
 
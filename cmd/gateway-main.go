@@ -275,9 +275,13 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	}
 
 	httpServer := xhttp.NewServer(addrs).
-		UseHandler(setCriticalErrorHandler(corsHandler(router))).
+		UseHandler(setCriticalErrorHandler(setBasePathHandler(corsHandler(router)))).
 		UseTLSConfig(newTLSConfig(getCert)).
 		UseShutdownTimeout(ctx.Duration("shutdown-timeout")).
+		UseIdleTimeout(ctx.Duration("idle-timeout")).
+		UseReadHeaderTimeout(ctx.Duration("read-header-timeout")).
+		UseReadBodyTimeout(ctx.Duration("read-body-timeout")).
+		UseWriteResponseTimeout(ctx.Duration("write-response-timeout")).
 		UseBaseContext(GlobalContext).
 		UseCustomLogger(log.New(ioutil.Discard, "", 0)) // Turn-off random logging by Go stdlib
 
@@ -463,6 +463,9 @@ func (l *s3EncObjects) NewMultipartUpload(ctx context.Context, bucket string, ob
 
 // PutObject creates a new object with the incoming data,
 func (l *s3EncObjects) PutObject(ctx context.Context, bucket string, object string, data *minio.PutObjReader, opts minio.ObjectOptions) (objInfo minio.ObjectInfo, err error) {
+	if opts.CheckPrecondFn != nil {
+		return objInfo, minio.NotImplemented{}
+	}
 	var sseOpts encrypt.ServerSide
 	// Decide if sse options needed to be passed to backend
 	if opts.ServerSideEncryption != nil &&
@@ -628,6 +631,9 @@ func (l *s3EncObjects) AbortMultipartUpload(ctx context.Context, bucket string,
 
 // CompleteMultipartUpload completes ongoing multipart upload and finalizes object
 func (l *s3EncObjects) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []minio.CompletePart, opts minio.ObjectOptions) (oi minio.ObjectInfo, e error) {
+	if opts.CheckPrecondFn != nil {
+		return oi, minio.NotImplemented{}
+	}
 	tmpMeta, err := l.getGWMetadata(ctx, bucket, getTmpDareMetaPath(object, uploadID))
 	if err != nil {
 		oi, e = l.s3Objects.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
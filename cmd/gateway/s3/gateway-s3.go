@@ -501,6 +501,11 @@ func (l *s3Objects) GetObjectInfo(ctx context.Context, bucket string, object str
 
 // PutObject creates a new object with the incoming data,
 func (l *s3Objects) PutObject(ctx context.Context, bucket string, object string, r *minio.PutObjReader, opts minio.ObjectOptions) (objInfo minio.ObjectInfo, err error) {
+	// Conditional writes cannot be evaluated atomically against the backend.
+	if opts.CheckPrecondFn != nil {
+		return objInfo, minio.NotImplemented{}
+	}
+
 	data := r.Reader
 
 	userDefined := minio.CloneMSS(opts.UserDefined)
@@ -718,6 +723,10 @@ func (l *s3Objects) AbortMultipartUpload(ctx context.Context, bucket string, obj
 
 // CompleteMultipartUpload completes ongoing multipart upload and finalizes object
 func (l *s3Objects) CompleteMultipartUpload(ctx context.Context, bucket string, object string, uploadID string, uploadedParts []minio.CompletePart, opts minio.ObjectOptions) (oi minio.ObjectInfo, e error) {
+	if opts.CheckPrecondFn != nil {
+		return oi, minio.NotImplemented{}
+	}
+
 	etag, err := l.Client.CompleteMultipartUpload(ctx, bucket, object, uploadID, minio.ToMinioClientCompleteParts(uploadedParts), miniogo.PutObjectOptions{})
 	if err != nil {
 		return oi, minio.ErrorRespToObjectError(err, bucket, object)
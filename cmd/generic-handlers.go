@@ -18,9 +18,11 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"path"
 	"runtime/debug"
 	"strings"
@@ -78,8 +80,11 @@ func containsReservedMetadata(header http.Header) bool {
 // is larger than 2 KB.
 func isHTTPHeaderSizeTooLarge(header http.Header) bool {
 	var size, usersize int
-	for key := range header {
-		length := len(key) + len(header.Get(key))
+	for key, values := range header {
+		length := len(key)
+		for _, value := range values {
+			length += len(value)
+		}
 		size += length
 		for _, prefix := range userMetadataKeyPrefixes {
 			if strings.HasPrefix(strings.ToLower(key), prefix) {
@@ -367,6 +372,40 @@ func hasBadPathComponent(path string) bool {
 	return false
 }
 
+// Check if the incoming path has control characters which
+// cannot be represented in XML, such as NUL, or DEL. Tab, CR
+// and LF are allowed in object names.
+func hasBadControlCharacter(path string) bool {
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; {
+		case c == '\t', c == '\n', c == '\r':
+		case c < 0x20, c == 0x7f:
+			return true
+		}
+	}
+	return false
+}
+
+// isObjectCreateReq returns true if the request names a new object
+// in its path, such as PutObject, CopyObject and NewMultipartUpload.
+func isObjectCreateReq(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPut:
+		// Sub-resources of existing objects and parts of existing uploads.
+		query := r.URL.Query()
+		for _, subResource := range []string{"acl", "tagging", "retention", "legal-hold", "uploadId"} {
+			if _, ok := query[subResource]; ok {
+				return false
+			}
+		}
+		return true
+	case http.MethodPost:
+		_, ok := r.URL.Query()["uploads"]
+		return ok
+	}
+	return false
+}
+
 // Check if client is sending a malicious request.
 func hasMultipleAuth(r *http.Request) bool {
 	authTypeCount := 0
@@ -382,6 +421,25 @@ func hasMultipleAuth(r *http.Request) bool {
 	return authTypeCount > 1
 }
 
+// hasConflictingSecurityToken returns true if the request carries
+// different security tokens in the header and the query string.
+func hasConflictingSecurityToken(r *http.Request) bool {
+	var token string
+	for _, tokens := range [][]string{
+		r.Header.Values(xhttp.AmzSecurityToken),
+		r.URL.Query()[xhttp.AmzSecurityToken],
+	} {
+		for _, t := range tokens {
+			if token == "" {
+				token = t
+			} else if t != token {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // requestValidityHandler validates all the incoming paths for
 // any malicious requests.
 func setRequestValidityHandler(h http.Handler) http.Handler {
@@ -412,6 +470,18 @@ func setRequestValidityHandler(h http.Handler) http.Handler {
 			atomic.AddUint64(&globalHTTPStats.rejectedRequestsInvalid, 1)
 			return
 		}
+		// Check for control characters in the name of new objects,
+		// existing objects can still be read and removed.
+		if isObjectCreateReq(r) && hasBadControlCharacter(r.URL.Path) {
+			if ok {
+				tc.funcName = "handler.ValidRequest"
+				tc.responseRecorder.LogErrBody = true
+			}
+
+			writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrInvalidObjectNameControlCharacter), r.URL)
+			atomic.AddUint64(&globalHTTPStats.rejectedRequestsInvalid, 1)
+			return
+		}
 		// Check for bad components in URL query values.
 		for _, vv := range r.Form {
 			for _, v := range vv {
@@ -439,6 +509,18 @@ func setRequestValidityHandler(h http.Handler) http.Handler {
 			atomic.AddUint64(&globalHTTPStats.rejectedRequestsInvalid, 1)
 			return
 		}
+		if hasConflictingSecurityToken(r) {
+			if ok {
+				tc.funcName = "handler.Auth"
+				tc.responseRecorder.LogErrBody = true
+			}
+
+			invalidReq := errorCodes.ToAPIErr(ErrInvalidRequest)
+			invalidReq.Description = fmt.Sprintf("%s (request has conflicting security tokens)", invalidReq.Description)
+			writeErrorResponse(r.Context(), w, invalidReq, r.URL)
+			atomic.AddUint64(&globalHTTPStats.rejectedRequestsInvalid, 1)
+			return
+		}
 		// For all other requests reject access to reserved buckets
 		bucketName, _ := request2BucketObjectName(r)
 		if isMinioReservedBucket(bucketName) || isMinioMetaBucket(bucketName) {
@@ -475,6 +557,81 @@ func setRequestValidityHandler(h http.Handler) http.Handler {
 	})
 }
 
+// setBucketAccessModeHandler rejects write requests on read-only
+// buckets and read requests on write-only buckets, regardless of
+// the credentials and policies allowing the request.
+func setBucketAccessModeHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if guessIsHealthCheckReq(r) || guessIsMetricsReq(r) ||
+			guessIsRPCReq(r) || guessIsLoginSTSReq(r) || isAdminReq(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		if isBucketAccessModeDenied(r) {
+			if tc, ok := r.Context().Value(contextTraceReqKey).(*traceCtxt); ok {
+				tc.funcName = "handler.BucketAccessMode"
+				tc.responseRecorder.LogErrBody = true
+			}
+
+			writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrMethodNotAllowed), r.URL)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// isBucketAccessModeDenied returns true if the S3 operation of the
+// request writes to a read-only bucket or reads from a write-only bucket.
+func isBucketAccessModeDenied(r *http.Request) bool {
+	bucket, _ := request2BucketObjectName(r)
+	if bucket == "" {
+		return false
+	}
+
+	readOnly, writeOnly := globalAPIConfig.getBucketAccessMode(bucket)
+	reads, writes := bucketAccessModeOp(r)
+	if (readOnly && writes) || (writeOnly && reads) {
+		return true
+	}
+
+	// CopyObject and UploadPartCopy read from the source bucket.
+	if copySource := r.Header.Get(xhttp.AmzCopySource); copySource != "" {
+		if u, err := url.Parse(copySource); err == nil {
+			srcBucket, _ := path2BucketObject(u.Path)
+			if _, writeOnly = globalAPIConfig.getBucketAccessMode(srcBucket); writeOnly {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bucketAccessModeOp classifies the S3 operation of the request as
+// reading and/or writing the bucket it is sent to.
+func bucketAccessModeOp(r *http.Request) (reads, writes bool) {
+	query := r.URL.Query()
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		// ListParts and ListMultipartUploads are part of an
+		// upload, they do not return any object content.
+		_, listParts := query["uploadId"]
+		_, listUploads := query["uploads"]
+		return !listParts && !listUploads, false
+	case http.MethodPost:
+		// SelectObjectContent and RestoreObject only read objects.
+		_, selectObject := query["select"]
+		_, restoreObject := query["restore"]
+		if selectObject || restoreObject {
+			return true, false
+		}
+		return false, true
+	case http.MethodPut, http.MethodDelete:
+		return false, true
+	}
+	return false, false
+}
+
 // setBucketForwardingHandler middleware forwards the path style requests
 // on a bucket to the right bucket location, bucket to IP configuration
 // is obtained from centralized etcd configuration service.
@@ -547,10 +704,12 @@ func setBucketForwardingHandler(h http.Handler) http.Handler {
 func addCustomHeaders(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		header := w.Header()
-		header.Set("X-XSS-Protection", "1; mode=block")                                // Prevents against XSS attacks
-		header.Set("Content-Security-Policy", "block-all-mixed-content")               // prevent mixed (HTTP / HTTPS content)
-		header.Set("X-Content-Type-Options", "nosniff")                                // Prevent mime-sniff
-		header.Set("Strict-Transport-Security", "max-age=31536000; includeSubDomains") // HSTS mitigates variants of MITM attacks
+		header.Set("X-XSS-Protection", "1; mode=block")                  // Prevents against XSS attacks
+		header.Set("Content-Security-Policy", "block-all-mixed-content") // prevent mixed (HTTP / HTTPS content)
+		header.Set("X-Content-Type-Options", "nosniff")                  // Prevent mime-sniff
+		if globalHSTSEnabled {
+			header.Set("Strict-Transport-Security", "max-age=31536000; includeSubDomains") // HSTS mitigates variants of MITM attacks
+		}
 
 		// Previously, this value was set right before a response was sent to
 		// the client. So, logger and Error response XML were not using this
@@ -558,6 +717,9 @@ func addCustomHeaders(h http.Handler) http.Handler {
 		// part of the log entry, Error response XML and auditing.
 		// Set custom headers such as x-amz-request-id for each request.
 		w.Header().Set(xhttp.AmzRequestID, mustGetRequestID(UTCNow()))
+		if globalLocalNodeNameHex != "" {
+			w.Header().Set(xhttp.AmzRequestHostID, globalLocalNodeNameHex)
+		}
 		h.ServeHTTP(logger.NewResponseWriter(w), r)
 	})
 }
@@ -585,3 +747,83 @@ func setCriticalErrorHandler(h http.Handler) http.Handler {
 		h.ServeHTTP(w, r)
 	})
 }
+
+type contextBasePathType string
+
+const contextBasePathKey = contextBasePathType("signed-base-path")
+
+// signedBasePath is the base path stripped from a request whose
+// signature was computed including it.
+type signedBasePath struct {
+	// Escaped form of the base path.
+	path string
+	// The request path was the base path itself.
+	root bool
+}
+
+// setBasePathHandler strips the configured base path from the request
+// URL before routing, for deployments behind a reverse proxy serving
+// MinIO under a sub-path. Requests outside of the base path are served
+// unmodified. A bucket named after the first segment of the base path,
+// e.g. "storage" for "/storage", is only reachable through the base path.
+func setBasePathHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		basePath, signed := globalAPIConfig.getBasePath()
+		if basePath == "" || (r.URL.Path != basePath && !strings.HasPrefix(r.URL.Path, basePath+SlashSeparator)) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		trimBasePath := func(p, prefix string) string {
+			if p = strings.TrimPrefix(p, prefix); !strings.HasPrefix(p, SlashSeparator) {
+				p = SlashSeparator + p
+			}
+			return p
+		}
+
+		// RawPath and RequestURI carry the escaped form of the path.
+		escapedBasePath := (&url.URL{Path: basePath}).EscapedPath()
+
+		u := *r.URL
+		u.Path = trimBasePath(u.Path, basePath)
+		if u.RawPath != "" {
+			if strings.HasPrefix(u.RawPath, escapedBasePath) {
+				u.RawPath = trimBasePath(u.RawPath, escapedBasePath)
+			} else {
+				// The client escaped the base path differently,
+				// fall back to the default escaping of Path.
+				u.RawPath = ""
+			}
+		}
+
+		ctx := r.Context()
+		if signed {
+			// Remember the stripped prefix to verify signatures
+			// computed over the path as sent by the client.
+			ctx = context.WithValue(ctx, contextBasePathKey, signedBasePath{
+				path: escapedBasePath,
+				root: r.URL.Path == basePath,
+			})
+		}
+		r = r.WithContext(ctx)
+		r.URL = &u
+		if strings.HasPrefix(r.RequestURI, escapedBasePath) {
+			r.RequestURI = trimBasePath(r.RequestURI, escapedBasePath)
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// signedRequestPath returns the encoded path the client signed the
+// request with, restoring the base path stripped by setBasePathHandler
+// when clients are configured to sign including it.
+func signedRequestPath(r *http.Request, encodedPath string) string {
+	basePath, ok := r.Context().Value(contextBasePathKey).(signedBasePath)
+	if !ok {
+		return encodedPath
+	}
+	if basePath.root && encodedPath == SlashSeparator {
+		return basePath.path
+	}
+	return basePath.path + encodedPath
+}
@@ -18,10 +18,14 @@
 package cmd
 
 import (
+	"context"
+	"encoding/xml"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/minio/minio/internal/crypto"
@@ -67,6 +71,9 @@ var isHTTPHeaderSizeTooLargeTests = []struct {
 	{header: generateHeader(0, 1024), shouldFail: false},
 	{header: generateHeader(0, 2048), shouldFail: true},
 	{header: generateHeader(0, 2048+1), shouldFail: true},
+	{header: http.Header{"X-Amz-Meta-Key": make([]string, 1024)}, shouldFail: false},
+	{header: http.Header{"X-Amz-Meta-Key": []string{"", strings.Repeat("a", 2048)}}, shouldFail: true},
+	{header: http.Header{"Authorization": []string{strings.Repeat("a", 8*1024+1)}}, shouldFail: true},
 }
 
 func generateHeader(size, usersize int) http.Header {
@@ -90,6 +97,37 @@ func TestIsHTTPHeaderSizeTooLarge(t *testing.T) {
 	}
 }
 
+func TestSetRequestLimitHandlerMetadataTooLarge(t *testing.T) {
+	var okHandler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	h := setRequestLimitHandler(okHandler)
+
+	testCases := []struct {
+		header     http.Header
+		expectCode int
+	}{
+		{header: generateHeader(0, 1024), expectCode: http.StatusOK},
+		{header: generateHeader(0, 2048+1), expectCode: http.StatusBadRequest},
+		{header: generateHeader(8*1024+1, 0), expectCode: http.StatusBadRequest},
+	}
+	for i, testCase := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, "http://127.0.0.1:9000/bucket/object", nil)
+		for k, v := range testCase.header {
+			r.Header[k] = v
+		}
+		h.ServeHTTP(w, r)
+
+		if w.Code != testCase.expectCode {
+			t.Fatalf("Test %d: expected HTTP %d, got %d", i, testCase.expectCode, w.Code)
+		}
+		if w.Code != http.StatusOK && !strings.Contains(w.Body.String(), "<Code>MetadataTooLarge</Code>") {
+			t.Fatalf("Test %d: expected MetadataTooLarge error, got %s", i, w.Body.String())
+		}
+	}
+}
+
 var containsReservedMetadataTests = []struct {
 	header     http.Header
 	shouldFail bool
@@ -129,6 +167,113 @@ func TestContainsReservedMetadata(t *testing.T) {
 	}
 }
 
+func TestHasMultipleAuth(t *testing.T) {
+	testCases := []struct {
+		url        string
+		header     http.Header
+		shouldFail bool
+	}{
+		// Signature V4 header only.
+		{"/bucket/object", http.Header{xhttp.Authorization: []string{signV4Algorithm + " Credential=access"}}, false},
+		// Presigned V4 query only.
+		{"/bucket/object?X-Amz-Credential=access", nil, false},
+		// Signature V4 header and presigned V4 query.
+		{"/bucket/object?X-Amz-Credential=access", http.Header{xhttp.Authorization: []string{signV4Algorithm + " Credential=access"}}, true},
+		// Signature V2 header and presigned V4 query.
+		{"/bucket/object?X-Amz-Credential=access", http.Header{xhttp.Authorization: []string{signV2Algorithm + " access:signature"}}, true},
+		// Presigned V2 and presigned V4 query.
+		{"/bucket/object?X-Amz-Credential=access&AWSAccessKeyId=access", nil, true},
+	}
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:9000"+testCase.url, nil)
+		for k, v := range testCase.header {
+			r.Header[k] = v
+		}
+		r.ParseForm()
+		if res := hasMultipleAuth(r); res != testCase.shouldFail {
+			t.Errorf("Test %d: Expected %v got %v", i+1, testCase.shouldFail, res)
+		}
+	}
+}
+
+func TestHasConflictingSecurityToken(t *testing.T) {
+	testCases := []struct {
+		url         string
+		headerToken string
+		shouldFail  bool
+	}{
+		{"/bucket/object", "", false},
+		{"/bucket/object", "token", false},
+		{"/bucket/object?X-Amz-Security-Token=token", "", false},
+		{"/bucket/object?X-Amz-Security-Token=token", "token", false},
+		{"/bucket/object?X-Amz-Security-Token=token", "other", true},
+		{"/bucket/object?X-Amz-Security-Token=token&X-Amz-Security-Token=other", "", true},
+	}
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:9000"+testCase.url, nil)
+		if testCase.headerToken != "" {
+			r.Header.Set(xhttp.AmzSecurityToken, testCase.headerToken)
+		}
+		if res := hasConflictingSecurityToken(r); res != testCase.shouldFail {
+			t.Errorf("Test %d: Expected %v got %v", i+1, testCase.shouldFail, res)
+		}
+	}
+}
+
+func TestRequestValidityHandlerObjectName(t *testing.T) {
+	testCases := []struct {
+		method     string
+		path       string
+		query      string
+		shouldFail bool
+		errCode    string
+	}{
+		{http.MethodGet, "/bucket/object", "", false, ""},
+		{http.MethodPut, "/bucket/dir/object\twith\ttabs", "", false, ""},
+		{http.MethodGet, "/bucket/../../etc/passwd", "", true, "XMinioInvalidResourceName"},
+		{http.MethodGet, "/bucket/dir/./object", "", true, "XMinioInvalidResourceName"},
+		{http.MethodPut, "/bucket/obj\x00ect", "", true, "InvalidArgument"},
+		{http.MethodPut, "/bucket/\x1bobject", "", true, "InvalidArgument"},
+		{http.MethodPut, "/bucket/object\x7f", "", true, "InvalidArgument"},
+		{http.MethodPost, "/bucket/obj\x00ect", "uploads=", true, "InvalidArgument"},
+		// Existing objects can still be read, modified and removed.
+		{http.MethodGet, "/bucket/obj\x00ect", "", false, ""},
+		{http.MethodDelete, "/bucket/obj\x00ect", "", false, ""},
+		{http.MethodPut, "/bucket/obj\x00ect", "tagging=", false, ""},
+		{http.MethodPut, "/bucket/obj\x00ect", "partNumber=1&uploadId=abc", false, ""},
+		{http.MethodPost, "/bucket/obj\x00ect", "uploadId=abc", false, ""},
+	}
+
+	var okHandler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	for i, testCase := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(testCase.method, "http://127.0.0.1:9000/", nil)
+		r.URL.Path = testCase.path
+		r.URL.RawQuery = testCase.query
+
+		setRequestValidityHandler(okHandler).ServeHTTP(w, r)
+
+		if !testCase.shouldFail {
+			if w.Code != http.StatusOK {
+				t.Errorf("Test %d: should not fail but status code is HTTP %d", i+1, w.Code)
+			}
+			continue
+		}
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Test %d: expected HTTP %d, got HTTP %d", i+1, http.StatusBadRequest, w.Code)
+		}
+		var errResp APIErrorResponse
+		if err := xml.Unmarshal(w.Body.Bytes(), &errResp); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if errResp.Code != testCase.errCode {
+			t.Errorf("Test %d: expected error code %s, got %s", i+1, testCase.errCode, errResp.Code)
+		}
+	}
+}
+
 var sseTLSHandlerTests = []struct {
 	URL               *url.URL
 	Header            http.Header
@@ -166,3 +311,188 @@ func TestSSETLSHandler(t *testing.T) {
 		}
 	}
 }
+
+func TestAddCustomHeaders(t *testing.T) {
+	defer func(hostID string) { globalLocalNodeNameHex = hostID }(globalLocalNodeNameHex)
+	globalLocalNodeNameHex = "6bd7d5b6a1a2bfe7e5d3f1ee3cb6ba17d6e3ed6e5f29bc9ec0a2f4e31e0aa1e2"
+
+	var requestID string
+	var okHandler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		requestID = w.Header().Get(xhttp.AmzRequestID)
+		writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object", nil)
+	addCustomHeaders(okHandler).ServeHTTP(w, r)
+
+	if requestID == "" || w.Header().Get(xhttp.AmzRequestID) != requestID {
+		t.Fatalf("expected request id %q to be set on the response, got %q", requestID, w.Header().Get(xhttp.AmzRequestID))
+	}
+	if hostID := w.Header().Get(xhttp.AmzRequestHostID); hostID != globalLocalNodeNameHex {
+		t.Fatalf("expected host id %q, got %q", globalLocalNodeNameHex, hostID)
+	}
+	if !strings.Contains(w.Body.String(), "<RequestId>"+requestID+"</RequestId>") {
+		t.Fatalf("expected error response to carry request id %q, got %s", requestID, w.Body.String())
+	}
+}
+
+func TestSetBucketAccessModeHandler(t *testing.T) {
+	defer func(readOnly, writeOnly map[string]struct{}) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.readOnlyBuckets = readOnly
+		globalAPIConfig.writeOnlyBuckets = writeOnly
+		globalAPIConfig.mu.Unlock()
+	}(globalAPIConfig.readOnlyBuckets, globalAPIConfig.writeOnlyBuckets)
+
+	globalAPIConfig.mu.Lock()
+	globalAPIConfig.readOnlyBuckets = map[string]struct{}{"archive": {}}
+	globalAPIConfig.writeOnlyBuckets = map[string]struct{}{"dropbox": {}}
+	globalAPIConfig.mu.Unlock()
+
+	var okHandler http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	h := setBucketAccessModeHandler(okHandler)
+
+	testCases := []struct {
+		method       string
+		path         string
+		copySource   string
+		expectedCode int
+	}{
+		{http.MethodGet, "/archive/object", "", http.StatusOK},
+		{http.MethodHead, "/archive/object", "", http.StatusOK},
+		{http.MethodPut, "/archive/object", "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/archive/object", "", http.StatusMethodNotAllowed},
+		{http.MethodDelete, "/archive/object", "", http.StatusMethodNotAllowed},
+		{http.MethodPut, "/dropbox/object", "", http.StatusOK},
+		{http.MethodPost, "/dropbox/object", "", http.StatusOK},
+		{http.MethodGet, "/dropbox/object", "", http.StatusMethodNotAllowed},
+		{http.MethodHead, "/dropbox", "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/archive/object?select&select-type=2", "", http.StatusOK},
+		{http.MethodPost, "/archive/object?restore", "", http.StatusOK},
+		{http.MethodPost, "/archive/object?uploads", "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/archive?delete", "", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/archive/object?uploadId=abc", "", http.StatusOK},
+		{http.MethodGet, "/dropbox/object?uploadId=abc", "", http.StatusOK},
+		{http.MethodGet, "/dropbox?uploads", "", http.StatusOK},
+		{http.MethodPost, "/dropbox/object?select&select-type=2", "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/dropbox/object?restore", "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/dropbox/object?uploadId=abc", "", http.StatusOK},
+		{http.MethodPut, "/bucket/object", "/dropbox/object", http.StatusMethodNotAllowed},
+		{http.MethodPut, "/bucket/object", "/archive/object", http.StatusOK},
+		{http.MethodDelete, "/bucket/object", "", http.StatusOK},
+		{http.MethodGet, "/", "", http.StatusOK},
+	}
+
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(testCase.method, "http://127.0.0.1:9000"+testCase.path, nil)
+		if testCase.copySource != "" {
+			r.Header.Set(xhttp.AmzCopySource, testCase.copySource)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != testCase.expectedCode {
+			t.Errorf("Test %d: %s %s: expected status %d, got %d", i+1, testCase.method, testCase.path, testCase.expectedCode, w.Code)
+		}
+	}
+}
+
+func TestSetBasePathHandler(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+
+	basePath, signed := globalAPIConfig.getBasePath()
+	defer func() {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.basePath, globalAPIConfig.basePathSigned = basePath, signed
+		globalAPIConfig.mu.Unlock()
+	}()
+
+	cred := globalActiveCred
+	var (
+		routedPath string
+		authErr    APIErrorCode
+	)
+	handler := setBasePathHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		routedPath = r.URL.Path
+		if isRequestSignatureV2(r) {
+			authErr = isReqAuthenticatedV2(r)
+		} else {
+			authErr = isReqAuthenticated(context.Background(), r, globalSite.Region, serviceS3)
+		}
+	}))
+
+	// newSignedRequest signs a request for signedPath and sends it to sentPath.
+	newSignedRequest := func(v2 bool, signedPath, sentPath string) *http.Request {
+		var req *http.Request
+		if v2 {
+			req, err = newTestSignedRequestV2(http.MethodGet, "http://127.0.0.1:9000"+signedPath, 0, nil, cred.AccessKey, cred.SecretKey, nil)
+		} else {
+			req, err = newTestSignedRequestV4(http.MethodGet, "http://127.0.0.1:9000"+signedPath, 0, nil, cred.AccessKey, cred.SecretKey, nil)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		sentURL, err := url.Parse(sentPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.URL.Path, req.URL.RawPath = sentURL.Path, sentURL.RawPath
+		req.RequestURI = req.URL.RequestURI()
+		return req
+	}
+
+	testCases := []struct {
+		basePath     string
+		signed       bool
+		v2           bool
+		signedPath   string
+		sentPath     string
+		expectedPath string
+		expectedErr  APIErrorCode
+	}{
+		// No base path configured.
+		{"", true, false, "/bucket/object", "/bucket/object", "/bucket/object", ErrNone},
+		// Clients signing with the base path.
+		{"/storage", true, false, "/storage/bucket/object", "/storage/bucket/object", "/bucket/object", ErrNone},
+		{"/storage", true, true, "/storage/bucket/object", "/storage/bucket/object", "/bucket/object", ErrNone},
+		{"/storage", true, false, "/storage/bucket", "/storage/bucket", "/bucket", ErrNone},
+		{"/storage", true, false, "/storage", "/storage", "/", ErrNone},
+		// Base paths which need escaping.
+		{"/my storage", true, false, "/my%20storage/bucket/object", "/my%20storage/bucket/object", "/bucket/object", ErrNone},
+		{"/my storage", true, true, "/my%20storage/bucket/object", "/my%20storage/bucket/object", "/bucket/object", ErrNone},
+		{"/my storage", true, false, "/my%20storage/bucket/a%2Fb", "/my%20storage/bucket/a%2Fb", "/bucket/a/b", ErrNone},
+		// Clients signing without the base path.
+		{"/storage", false, false, "/bucket/object", "/storage/bucket/object", "/bucket/object", ErrNone},
+		{"/storage", false, true, "/bucket/object", "/storage/bucket/object", "/bucket/object", ErrNone},
+		// Mismatching signing modes are rejected.
+		{"/storage", false, false, "/storage/bucket/object", "/storage/bucket/object", "/bucket/object", ErrSignatureDoesNotMatch},
+		{"/storage", true, false, "/bucket/object", "/storage/bucket/object", "/bucket/object", ErrSignatureDoesNotMatch},
+		// Requests outside of the base path are not rewritten.
+		{"/storage", true, false, "/storagebucket/object", "/storagebucket/object", "/storagebucket/object", ErrNone},
+		{"/storage", true, false, "/bucket/object", "/bucket/object", "/bucket/object", ErrNone},
+	}
+
+	for i, testCase := range testCases {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.basePath, globalAPIConfig.basePathSigned = testCase.basePath, testCase.signed
+		globalAPIConfig.mu.Unlock()
+
+		routedPath, authErr = "", ErrNone
+		handler.ServeHTTP(httptest.NewRecorder(), newSignedRequest(testCase.v2, testCase.signedPath, testCase.sentPath))
+		if routedPath != testCase.expectedPath {
+			t.Errorf("Test %d: expected routed path %s, got %s", i+1, testCase.expectedPath, routedPath)
+		}
+		if authErr != testCase.expectedErr {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expectedErr, authErr)
+		}
+	}
+}
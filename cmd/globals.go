@@ -93,8 +93,9 @@ const (
 	// Limit memory allocation to store multipart data
 	maxFormMemory = int64(5 * humanize.MiByte)
 
-	// The maximum allowed time difference between the incoming request
-	// date and server date during signature verification.
+	// The default maximum allowed time difference between the incoming
+	// request date and server date during signature verification, can
+	// be overridden with the API `clock_skew` setting.
 	globalMaxSkewTime = 15 * time.Minute // 15 minutes skew allowed.
 
 	// GlobalStaleUploadsExpiry - Expiry duration after which the uploads in multipart,
@@ -158,6 +159,9 @@ var (
 	// This flag is set to 'true' by default
 	globalBrowserEnabled = true
 
+	// This flag is set to 'true' by default, disabled with MINIO_API_HSTS=off
+	globalHSTSEnabled = true
+
 	// Custom browser redirect URL, not set by default
 	// and it is automatically deduced.
 	globalBrowserRedirectURL *xnet.URL
@@ -203,7 +207,7 @@ var (
 	globalBucketTargetSys    *BucketTargetSys
 	// globalAPIConfig controls S3 API requests throttling,
 	// healthcheck readiness deadlines and cors settings.
-	globalAPIConfig = apiConfig{listQuorum: "strict"}
+	globalAPIConfig = apiConfig{listQuorum: "strict", requireContentSHA256: true}
 
 	globalStorageClass storageclass.Config
 
@@ -242,6 +246,10 @@ var (
 	// The name of this local node, fetched from arguments
 	globalLocalNodeName string
 
+	// The hex encoded SHA256 of the local node name, sent as the
+	// x-amz-id-2 response header to identify the serving node.
+	globalLocalNodeNameHex string
+
 	// The global subnet config
 	globalSubnetConfig subnet.Config
 
@@ -377,6 +385,9 @@ var (
 	// MinIO version unix timestamp
 	globalVersionUnix uint64
 
+	// Number of stale multipart uploads removed since server start
+	globalStaleUploadsReaped uint64
+
 	// Add new variable global values here.
 )
 
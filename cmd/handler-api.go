@@ -19,15 +19,19 @@ package cmd
 
 import (
 	"io/ioutil"
+	"math"
+	"net"
 	"net/http"
 	"runtime"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/shirou/gopsutil/v3/mem"
 
 	"github.com/minio/minio/internal/config/api"
+	xhttp "github.com/minio/minio/internal/http"
 	xioutil "github.com/minio/minio/internal/ioutil"
 	"github.com/minio/minio/internal/logger"
 )
@@ -51,6 +55,26 @@ type apiConfig struct {
 	deleteCleanupInterval       time.Duration
 	disableODirect              bool
 	gzipObjects                 bool
+	clockSkew                   time.Duration
+	clockSkewPresigned          time.Duration
+	requestsRate                float64
+	requestsRateBurst           int
+	disabledAuthTypes           map[authType]struct{}
+	ipAllow                     []*net.IPNet
+	ipDeny                      []*net.IPNet
+	trustedProxies              []*net.IPNet
+	disableRegionCheck          bool
+	objectMaxSize               int64
+	multipartMaxParts           int
+	multipartMinPartSize        int64
+	multipartMaxPartSize        int64
+	readOnlyBuckets             map[string]struct{}
+	writeOnlyBuckets            map[string]struct{}
+	maxBuckets                  int
+	requireContentSHA256        bool
+	basePath                    string
+	basePathSigned              bool
+	rejectCaseCollisions        bool
 }
 
 const cgroupLimitFile = "/sys/fs/cgroup/memory/memory.limit_in_bytes"
@@ -153,6 +177,38 @@ func (t *apiConfig) init(cfg api.Config, setDriveCounts []int) {
 	t.deleteCleanupInterval = cfg.DeleteCleanupInterval
 	t.disableODirect = cfg.DisableODirect
 	t.gzipObjects = cfg.GzipObjects
+	t.clockSkew = cfg.ClockSkew
+	t.clockSkewPresigned = cfg.ClockSkewPresigned
+	t.requestsRate = cfg.RequestsRate
+	t.requestsRateBurst = cfg.RequestsRateBurst
+	t.disabledAuthTypes = make(map[authType]struct{}, len(cfg.DisableAuthTypes))
+	for _, name := range cfg.DisableAuthTypes {
+		if aType, ok := configurableAuthTypes[name]; ok {
+			t.disabledAuthTypes[aType] = struct{}{}
+		}
+	}
+	t.ipAllow = cfg.IPAllow
+	t.ipDeny = cfg.IPDeny
+	t.trustedProxies = cfg.TrustedProxies
+	t.disableRegionCheck = cfg.DisableRegionCheck
+	t.objectMaxSize = cfg.ObjectMaxSize
+	t.multipartMaxParts = cfg.MultipartMaxParts
+	t.multipartMinPartSize = cfg.MultipartMinPartSize
+	t.multipartMaxPartSize = cfg.MultipartMaxPartSize
+	t.readOnlyBuckets = make(map[string]struct{}, len(cfg.ReadOnlyBuckets))
+	for _, bucket := range cfg.ReadOnlyBuckets {
+		t.readOnlyBuckets[bucket] = struct{}{}
+	}
+	t.writeOnlyBuckets = make(map[string]struct{}, len(cfg.WriteOnlyBuckets))
+	for _, bucket := range cfg.WriteOnlyBuckets {
+		t.writeOnlyBuckets[bucket] = struct{}{}
+	}
+	logger.SetAnonymousAuditSample(cfg.AuditAnonymousSample)
+	t.maxBuckets = cfg.MaxBuckets
+	t.requireContentSHA256 = cfg.RequireContentSHA256
+	t.basePath = cfg.BasePath
+	t.basePathSigned = cfg.BasePathSigned
+	t.rejectCaseCollisions = cfg.RejectCaseCollisions
 }
 
 func (t *apiConfig) isDisableODirect() bool {
@@ -162,6 +218,93 @@ func (t *apiConfig) isDisableODirect() bool {
 	return t.disableODirect
 }
 
+func (t *apiConfig) isRegionCheckDisabled() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.disableRegionCheck
+}
+
+// getObjectMaxSize returns the maximum size of an object
+// uploaded in a single operation.
+func (t *apiConfig) getObjectMaxSize() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.objectMaxSize <= 0 {
+		return globalMaxObjectSize
+	}
+	return t.objectMaxSize
+}
+
+// getMultipartMaxParts returns the maximum number
+// of parts of a multipart upload.
+func (t *apiConfig) getMultipartMaxParts() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.multipartMaxParts <= 0 {
+		return globalMaxPartID
+	}
+	return t.multipartMaxParts
+}
+
+// getMultipartMinPartSize returns the minimum size of
+// every part but the last one of a multipart upload.
+func (t *apiConfig) getMultipartMinPartSize() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.multipartMinPartSize <= 0 {
+		return globalMinPartSize
+	}
+	return t.multipartMinPartSize
+}
+
+// getMultipartMaxPartSize returns the maximum size
+// of a part of a multipart upload.
+func (t *apiConfig) getMultipartMaxPartSize() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.multipartMaxPartSize <= 0 {
+		return globalMaxPartSize
+	}
+	return t.multipartMaxPartSize
+}
+
+// getMaxBuckets returns the maximum number of buckets,
+// zero means there is no limit.
+func (t *apiConfig) getMaxBuckets() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.maxBuckets
+}
+
+func (t *apiConfig) isContentSHA256Required() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.requireContentSHA256
+}
+
+// getBasePath returns the path prefix stripped from requests and
+// whether clients sign requests including it.
+func (t *apiConfig) getBasePath() (string, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.basePath, t.basePathSigned
+}
+
+func (t *apiConfig) shouldRejectCaseCollisions() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.rejectCaseCollisions
+}
+
 func (t *apiConfig) shouldGzipObjects() bool {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -218,6 +361,80 @@ func (t *apiConfig) getDeleteCleanupInterval() time.Duration {
 	return t.deleteCleanupInterval
 }
 
+func (t *apiConfig) getClockSkew() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.clockSkew == 0 {
+		return globalMaxSkewTime
+	}
+
+	return t.clockSkew
+}
+
+// getPresignedClockSkew returns how far in the future the date of
+// presigned requests may be, expiry is governed by X-Amz-Expires.
+func (t *apiConfig) getPresignedClockSkew() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.clockSkewPresigned == 0 {
+		return globalMaxSkewTime
+	}
+
+	return t.clockSkewPresigned
+}
+
+// getRequestsRate returns the allowed requests per second and burst
+// for each client, a zero rate means rate limiting is disabled.
+func (t *apiConfig) getRequestsRate() (float64, int) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	burst := t.requestsRateBurst
+	if burst == 0 {
+		burst = int(math.Ceil(t.requestsRate))
+	}
+
+	return t.requestsRate, burst
+}
+
+// isAuthTypeDisabled returns true if requests of the given
+// auth type are rejected by the operator configuration.
+func (t *apiConfig) isAuthTypeDisabled(aType authType) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	_, ok := t.disabledAuthTypes[aType]
+	return ok
+}
+
+// getIPFilter returns the CIDRs allowed and denied access to the server.
+func (t *apiConfig) getIPFilter() (allow, deny []*net.IPNet) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.ipAllow, t.ipDeny
+}
+
+// getBucketAccessMode returns if the bucket is configured to
+// reject all write requests or all read requests.
+func (t *apiConfig) getBucketAccessMode(bucket string) (readOnly, writeOnly bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	_, readOnly = t.readOnlyBuckets[bucket]
+	_, writeOnly = t.writeOnlyBuckets[bucket]
+	return readOnly, writeOnly
+}
+
+func (t *apiConfig) getTrustedProxies() []*net.IPNet {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.trustedProxies
+}
+
 func (t *apiConfig) getClusterDeadline() time.Duration {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -265,6 +482,19 @@ func maxClients(f http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
+		// Without a deadline requests are rejected
+		// right away when the pool is full.
+		if deadline <= 0 {
+			select {
+			case pool <- struct{}{}:
+				defer func() { <-pool }()
+				f.ServeHTTP(w, r)
+			default:
+				writeMaxClientsErrorResponse(w, r, deadline)
+			}
+			return
+		}
+
 		globalHTTPStats.addRequestsInQueue(1)
 
 		deadlineTimer := time.NewTimer(deadline)
@@ -276,11 +506,9 @@ func maxClients(f http.HandlerFunc) http.HandlerFunc {
 			globalHTTPStats.addRequestsInQueue(-1)
 			f.ServeHTTP(w, r)
 		case <-deadlineTimer.C:
-			// Send a http timeout message
-			writeErrorResponse(r.Context(), w,
-				errorCodes.ToAPIErr(ErrOperationMaxedOut),
-				r.URL)
 			globalHTTPStats.addRequestsInQueue(-1)
+			// Send a http timeout message
+			writeMaxClientsErrorResponse(w, r, deadline)
 			return
 		case <-r.Context().Done():
 			globalHTTPStats.addRequestsInQueue(-1)
@@ -289,6 +517,64 @@ func maxClients(f http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// writeMaxClientsErrorResponse rejects a request which could not be
+// processed within the deadline, asking the client to retry after it
+// or later if the server is more overloaded than that.
+func writeMaxClientsErrorResponse(w http.ResponseWriter, r *http.Request, deadline time.Duration) {
+	retryAfter := computeRetryAfter()
+	if deadline > retryAfter {
+		retryAfter = deadline
+	}
+	setRetryAfter(w, retryAfter)
+	writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrOperationMaxedOut), r.URL)
+	atomic.AddUint64(&globalHTTPStats.rejectedRequestsMax, 1)
+}
+
+// Bounds of the retry hint sent to overloaded clients.
+const (
+	minRetryAfter = time.Second
+	maxRetryAfter = 2 * time.Minute
+)
+
+// computeRetryAfter returns how long clients should back off based on
+// the current load, the hint doubles every time the requests waiting in
+// the queue grow by a quarter of the requests pool. Without a requests
+// pool the load is unknown and clients are asked to wait the longest.
+func computeRetryAfter() time.Duration {
+	pool, _ := globalAPIConfig.getRequestsPool()
+	if cap(pool) == 0 {
+		return maxRetryAfter
+	}
+
+	queued := int(globalHTTPStats.getRequestsInQueue())
+	if queued < 0 {
+		queued = 0
+	}
+	step := cap(pool) / 4
+	if step < 1 {
+		step = 1
+	}
+
+	retryAfter := minRetryAfter
+	for i := queued / step; i > 0 && retryAfter < maxRetryAfter; i-- {
+		retryAfter *= 2
+	}
+	if retryAfter > maxRetryAfter {
+		retryAfter = maxRetryAfter
+	}
+	return retryAfter
+}
+
+// setRetryAfter sets the Retry-After header in seconds, rounded up,
+// along with the millisecond precision hint honored by S3 SDKs.
+func setRetryAfter(w http.ResponseWriter, d time.Duration) {
+	if d <= 0 {
+		d = minRetryAfter
+	}
+	w.Header().Set(xhttp.RetryAfter, strconv.Itoa(int(math.Ceil(d.Seconds()))))
+	w.Header().Set(xhttp.AmzRetryAfterMs, strconv.FormatInt(d.Milliseconds(), 10))
+}
+
 func (t *apiConfig) getReplicationFailedWorkers() int {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -0,0 +1,161 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+func TestMaxClients(t *testing.T) {
+	defer func(pool chan struct{}, deadline time.Duration) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.requestsPool = pool
+		globalAPIConfig.requestsDeadline = deadline
+		globalAPIConfig.mu.Unlock()
+	}(globalAPIConfig.requestsPool, globalAPIConfig.requestsDeadline)
+
+	pool := make(chan struct{}, 1)
+	okHandler := maxClients(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	testCases := []struct {
+		deadline           time.Duration
+		full               bool
+		expectedRespStatus int
+		expectedRetryAfter string
+	}{
+		// Requests are rejected right away without a deadline.
+		{0, false, http.StatusOK, ""},
+		{0, true, http.StatusServiceUnavailable, "1"},
+		// Requests are rejected once the deadline is reached.
+		{50 * time.Millisecond, false, http.StatusOK, ""},
+		{50 * time.Millisecond, true, http.StatusServiceUnavailable, "1"},
+		// Clients are asked to retry after the deadline.
+		{1500 * time.Millisecond, true, http.StatusServiceUnavailable, "2"},
+	}
+
+	for i, testCase := range testCases {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.requestsPool = pool
+		globalAPIConfig.requestsDeadline = testCase.deadline
+		globalAPIConfig.mu.Unlock()
+
+		if testCase.full {
+			pool <- struct{}{}
+		}
+		rejected := atomic.LoadUint64(&globalHTTPStats.rejectedRequestsMax)
+
+		rec := httptest.NewRecorder()
+		okHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object", nil))
+
+		if testCase.full {
+			<-pool
+		}
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.expectedRespStatus, rec.Code)
+		}
+		if retryAfter := rec.Header().Get(xhttp.RetryAfter); retryAfter != testCase.expectedRetryAfter {
+			t.Errorf("Test %d: expected Retry-After %q, got %q", i+1, testCase.expectedRetryAfter, retryAfter)
+		}
+		expectedRejected := rejected
+		if testCase.expectedRespStatus != http.StatusOK {
+			expectedRejected++
+		}
+		if got := atomic.LoadUint64(&globalHTTPStats.rejectedRequestsMax); got != expectedRejected {
+			t.Errorf("Test %d: expected %d rejected requests, got %d", i+1, expectedRejected, got)
+		}
+		if len(pool) != 0 {
+			t.Fatalf("Test %d: expected the request to release its slot", i+1)
+		}
+	}
+}
+
+func TestComputeRetryAfter(t *testing.T) {
+	defer func(pool chan struct{}) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.requestsPool = pool
+		globalAPIConfig.mu.Unlock()
+	}(globalAPIConfig.requestsPool)
+
+	testCases := []struct {
+		poolSize           int
+		queued             int32
+		expectedRetryAfter time.Duration
+	}{
+		// Without a requests pool the load is unknown.
+		{0, 0, maxRetryAfter},
+		// The hint doubles for every quarter of the pool queued.
+		{8, 0, time.Second},
+		{8, 1, time.Second},
+		{8, 2, 2 * time.Second},
+		{8, 4, 4 * time.Second},
+		{8, 6, 8 * time.Second},
+		{8, 1000, maxRetryAfter},
+		// Small pools back off for every queued request.
+		{2, 3, 8 * time.Second},
+	}
+
+	for i, testCase := range testCases {
+		var pool chan struct{}
+		if testCase.poolSize > 0 {
+			pool = make(chan struct{}, testCase.poolSize)
+		}
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.requestsPool = pool
+		globalAPIConfig.mu.Unlock()
+
+		globalHTTPStats.addRequestsInQueue(testCase.queued)
+		retryAfter := computeRetryAfter()
+		globalHTTPStats.addRequestsInQueue(-testCase.queued)
+
+		if retryAfter != testCase.expectedRetryAfter {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expectedRetryAfter, retryAfter)
+		}
+	}
+}
+
+func TestSetRetryAfter(t *testing.T) {
+	testCases := []struct {
+		retryAfter           time.Duration
+		expectedRetryAfter   string
+		expectedRetryAfterMs string
+	}{
+		{1500 * time.Millisecond, "2", "1500"},
+		{200 * time.Millisecond, "1", "200"},
+		{0, "1", "1000"},
+		{maxRetryAfter, "120", "120000"},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		setRetryAfter(rec, testCase.retryAfter)
+		if got := rec.Header().Get(xhttp.RetryAfter); got != testCase.expectedRetryAfter {
+			t.Errorf("Test %d: expected Retry-After %q, got %q", i+1, testCase.expectedRetryAfter, got)
+		}
+		if got := rec.Header().Get(xhttp.AmzRetryAfterMs); got != testCase.expectedRetryAfterMs {
+			t.Errorf("Test %d: expected %s %q, got %q", i+1, xhttp.AmzRetryAfterMs, testCase.expectedRetryAfterMs, got)
+		}
+	}
+}
@@ -264,16 +264,23 @@ func extractRespElements(w http.ResponseWriter) map[string]string {
 // `aws-chunked,gzip` here we need to only save `gzip`.
 // For more refer http://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-streaming.html
 func trimAwsChunkedContentEncoding(contentEnc string) (trimmedContentEnc string) {
-	if contentEnc == "" {
+	if !strings.Contains(contentEnc, streamingContentEncoding) {
 		return contentEnc
 	}
+	// Clients may separate the encodings with spaces, for example
+	// "gzip, aws-chunked", keep the separator used by the client.
+	sep := ","
+	if strings.Contains(contentEnc, ", ") {
+		sep = ", "
+	}
 	var newEncs []string
 	for _, enc := range strings.Split(contentEnc, ",") {
-		if enc != streamingContentEncoding {
+		enc = strings.TrimSpace(enc)
+		if enc != "" && enc != streamingContentEncoding {
 			newEncs = append(newEncs, enc)
 		}
 	}
-	return strings.Join(newEncs, ",")
+	return strings.Join(newEncs, sep)
 }
 
 // Validate form field size for s3 specification requirement.
@@ -412,9 +419,26 @@ func methodNotAllowedHandler(api string) func(w http.ResponseWriter, r *http.Req
 	return errorResponseHandler
 }
 
+// optionsAllowedMethods returns the methods supported on the resource
+// of a plain OPTIONS request, CORS preflight requests never reach here.
+func optionsAllowedMethods(r *http.Request) string {
+	resource, err := getResource(r.URL.Path, r.Host, globalDomainNames)
+	if err != nil || strings.HasPrefix(resource, minioReservedBucketPath+SlashSeparator) {
+		return ""
+	}
+	if resource == SlashSeparator {
+		return "GET, OPTIONS"
+	}
+	return "GET, HEAD, PUT, POST, DELETE, OPTIONS"
+}
+
 // If none of the http routes match respond with appropriate errors
 func errorResponseHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method == http.MethodOptions {
+		if methods := optionsAllowedMethods(r); methods != "" {
+			w.Header().Set(xhttp.Allow, methods)
+		}
+		w.WriteHeader(http.StatusOK)
 		return
 	}
 	desc := "Do not upgrade one server at a time - please follow the recommended guidelines mentioned here https://github.com/minio/minio#upgrading-minio for your environment"
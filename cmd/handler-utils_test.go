@@ -212,6 +212,33 @@ func TestExtractMetadataHeaders(t *testing.T) {
 	}
 }
 
+// Test trimAwsChunkedContentEncoding()
+func TestTrimAwsChunkedContentEncoding(t *testing.T) {
+	testCases := []struct {
+		contentEncoding string
+		expected        string
+	}{
+		{"", ""},
+		{"aws-chunked", ""},
+		{"gzip", "gzip"},
+		{"gzip, br", "gzip, br"},
+		{"aws-chunked,gzip", "gzip"},
+		{"gzip,aws-chunked", "gzip"},
+		{"gzip, aws-chunked", "gzip"},
+		{"aws-chunked, gzip, br", "gzip, br"},
+		{"aws-chunked,gzip,br", "gzip,br"},
+		{"gzip, aws-chunked, br", "gzip, br"},
+		{" aws-chunked ", ""},
+	}
+
+	for i, testCase := range testCases {
+		got := trimAwsChunkedContentEncoding(testCase.contentEncoding)
+		if got != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
+
 // Test getResource()
 func TestGetResource(t *testing.T) {
 	testCases := []struct {
@@ -237,6 +237,65 @@ func (stats *HTTPAPIStats) Load() map[string]int {
 	return apiStats
 }
 
+// Outcomes of authenticating a request, recorded in httpAuthStats.
+const (
+	authOutcomeSuccess           = "success"
+	authOutcomeSignatureMismatch = "signature_mismatch"
+	authOutcomeAccessDenied      = "access_denied"
+	authOutcomeInternalError     = "internal_error"
+	authOutcomeOtherError        = "other_error"
+)
+
+// authOutcomeFromErrCode returns the auth outcome of a request
+// which failed with the given API error code, if any.
+func authOutcomeFromErrCode(code string) string {
+	switch code {
+	case "":
+		return authOutcomeSuccess
+	case "SignatureDoesNotMatch":
+		return authOutcomeSignatureMismatch
+	case "AccessDenied":
+		return authOutcomeAccessDenied
+	case "InternalError":
+		return authOutcomeInternalError
+	}
+	return authOutcomeOtherError
+}
+
+// httpAuthStats holds the number of requests per
+// auth type and outcome of the request.
+type httpAuthStats struct {
+	sync.Mutex
+	stats map[string]map[string]uint64
+}
+
+// Inc increments the counter of the auth type and outcome.
+func (s *httpAuthStats) Inc(aType, outcome string) {
+	s.Lock()
+	defer s.Unlock()
+	if s.stats == nil {
+		s.stats = make(map[string]map[string]uint64)
+	}
+	if s.stats[aType] == nil {
+		s.stats[aType] = make(map[string]uint64)
+	}
+	s.stats[aType][outcome]++
+}
+
+// Load returns the recorded stats.
+func (s *httpAuthStats) Load() map[string]map[string]uint64 {
+	s.Lock()
+	defer s.Unlock()
+	stats := make(map[string]map[string]uint64, len(s.stats))
+	for aType, outcomes := range s.stats {
+		stats[aType] = make(map[string]uint64, len(outcomes))
+		for outcome, v := range outcomes {
+			stats[aType][outcome] = v
+		}
+	}
+	return stats
+}
+
 // HTTPStats holds statistics information about
 // HTTP requests made by all clients
 type HTTPStats struct {
@@ -247,18 +306,25 @@ type HTTPStats struct {
 	rejectedRequestsTime    uint64
 	rejectedRequestsHeader  uint64
 	rejectedRequestsInvalid uint64
+	rejectedRequestsRate    uint64
+	rejectedRequestsMax     uint64
 	currentS3Requests       HTTPAPIStats
 	totalS3Requests         HTTPAPIStats
 	totalS3Errors           HTTPAPIStats
 	totalS34xxErrors        HTTPAPIStats
 	totalS35xxErrors        HTTPAPIStats
 	totalS3Canceled         HTTPAPIStats
+	totalS3AuthOutcomes     httpAuthStats
 }
 
 func (st *HTTPStats) addRequestsInQueue(i int32) {
 	atomic.AddInt32(&st.s3RequestsInQueue, i)
 }
 
+func (st *HTTPStats) getRequestsInQueue() int32 {
+	return atomic.LoadInt32(&st.s3RequestsInQueue)
+}
+
 func (st *HTTPStats) incS3RequestsIncoming() {
 	// Golang automatically resets to zero if this overflows
 	atomic.AddUint64(&st.s3RequestsIncoming, 1)
@@ -273,6 +339,8 @@ func (st *HTTPStats) toServerHTTPStats() ServerHTTPStats {
 	serverStats.TotalS3RejectedTime = atomic.LoadUint64(&st.rejectedRequestsTime)
 	serverStats.TotalS3RejectedHeader = atomic.LoadUint64(&st.rejectedRequestsHeader)
 	serverStats.TotalS3RejectedInvalid = atomic.LoadUint64(&st.rejectedRequestsInvalid)
+	serverStats.TotalS3RejectedRate = atomic.LoadUint64(&st.rejectedRequestsRate)
+	serverStats.TotalS3RejectedMax = atomic.LoadUint64(&st.rejectedRequestsMax)
 	serverStats.CurrentS3Requests = ServerHTTPAPIStats{
 		APIStats: st.currentS3Requests.Load(),
 	}
@@ -291,6 +359,7 @@ func (st *HTTPStats) toServerHTTPStats() ServerHTTPStats {
 	serverStats.TotalS3Canceled = ServerHTTPAPIStats{
 		APIStats: st.totalS3Canceled.Load(),
 	}
+	serverStats.TotalS3AuthOutcomes = st.totalS3AuthOutcomes.Load()
 	return serverStats
 }
 
@@ -20,12 +20,16 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 )
 
 const (
 	byteRangePrefix = "bytes="
+
+	// Maximum number of byte ranges served in a single response.
+	maxRangeSpecs = 100
 )
 
 // HTTPRangeSpec represents a range specification as supported by S3 GET
@@ -163,6 +167,75 @@ func parseRequestRangeSpec(rangeString string) (hrange *HTTPRangeSpec, err error
 	}
 }
 
+// Parse a HTTP range header value with one or more comma separated
+// byte ranges, e.g. "bytes=0-99,200-299", into a list of HTTPRangeSpec.
+func parseRequestRangeSpecs(rangeString string) (hranges []*HTTPRangeSpec, err error) {
+	// Return error if given range string doesn't start with byte range prefix.
+	if !strings.HasPrefix(rangeString, byteRangePrefix) {
+		return nil, fmt.Errorf("'%s' does not start with '%s'", rangeString, byteRangePrefix)
+	}
+
+	byteRangeStrings := strings.Split(strings.TrimPrefix(rangeString, byteRangePrefix), ",")
+	if len(byteRangeStrings) > maxRangeSpecs {
+		return nil, fmt.Errorf("'%s' has more than %d ranges", rangeString, maxRangeSpecs)
+	}
+	for _, byteRangeString := range byteRangeStrings {
+		hrange, err := parseRequestRangeSpec(byteRangePrefix + strings.TrimSpace(byteRangeString))
+		if err != nil {
+			return nil, err
+		}
+		hranges = append(hranges, hrange)
+	}
+	return hranges, nil
+}
+
+// satisfiableRangeSpecs returns the ranges which overlap with the
+// object as absolute offsets, sorted and with overlapping or adjacent
+// ranges merged. errInvalidRange is returned if there are none. No
+// ranges are returned when together they ask for more than the whole
+// object, the object is served as a whole instead.
+func satisfiableRangeSpecs(objInfo ObjectInfo, hranges []*HTTPRangeSpec) ([]*HTTPRangeSpec, error) {
+	resourceSize, err := objInfo.GetActualSize()
+	if err != nil {
+		return nil, err
+	}
+	var satisfiable []*HTTPRangeSpec
+	var totalLength int64
+	for _, hrange := range hranges {
+		start, length, err := hrange.GetOffsetLength(resourceSize)
+		if err != nil {
+			if err == errInvalidRange {
+				continue
+			}
+			return nil, err
+		}
+		totalLength += length
+		satisfiable = append(satisfiable, &HTTPRangeSpec{Start: start, End: start + length - 1})
+	}
+	if len(satisfiable) == 0 {
+		return nil, errInvalidRange
+	}
+	if totalLength > resourceSize {
+		return nil, nil
+	}
+
+	sort.Slice(satisfiable, func(i, j int) bool {
+		return satisfiable[i].Start < satisfiable[j].Start
+	})
+	merged := satisfiable[:1]
+	for _, hrange := range satisfiable[1:] {
+		last := merged[len(merged)-1]
+		if hrange.Start > last.End+1 {
+			merged = append(merged, hrange)
+			continue
+		}
+		if hrange.End > last.End {
+			last.End = hrange.End
+		}
+	}
+	return merged, nil
+}
+
 // String returns stringified representation of range for a particular resource size.
 func (h *HTTPRangeSpec) String(resourceSize int64) string {
 	if h == nil {
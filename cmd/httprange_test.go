@@ -18,6 +18,8 @@
 package cmd
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -102,6 +104,37 @@ func TestHTTPRequestRangeSpec(t *testing.T) {
 	}
 }
 
+func TestHTTPRequestRangeSpecs(t *testing.T) {
+	testCases := []struct {
+		spec        string
+		expSpecs    []*HTTPRangeSpec
+		expErr      bool
+		expRangeErr bool
+	}{
+		{spec: "bytes=0-9", expSpecs: []*HTTPRangeSpec{{false, 0, 9}}},
+		{spec: "bytes=0-9,20-", expSpecs: []*HTTPRangeSpec{{false, 0, 9}, {false, 20, -1}}},
+		{spec: "bytes=20-29, 0-9, -5", expSpecs: []*HTTPRangeSpec{{false, 20, 29}, {false, 0, 9}, {true, -5, -1}}},
+		{spec: "bytes=0-9,5-14", expSpecs: []*HTTPRangeSpec{{false, 0, 9}, {false, 5, 14}}},
+		{spec: "bytes=0-9,", expErr: true},
+		{spec: "bytes=0-9,aa", expErr: true},
+		{spec: "0-9,20-29", expErr: true},
+		{spec: "bytes=0-9,12-10", expErr: true, expRangeErr: true},
+		{spec: "bytes=0-0" + strings.Repeat(",0-0", maxRangeSpecs), expErr: true},
+	}
+	for i, testCase := range testCases {
+		specs, err := parseRequestRangeSpecs(testCase.spec)
+		if testCase.expErr != (err != nil) {
+			t.Fatalf("Case %d: expected error %v, got %v", i, testCase.expErr, err)
+		}
+		if testCase.expRangeErr != (err == errInvalidRange) {
+			t.Fatalf("Case %d: expected invalid range error %v, got %v", i, testCase.expRangeErr, err)
+		}
+		if !reflect.DeepEqual(specs, testCase.expSpecs) {
+			t.Errorf("Case %d: expected %v, got %v", i, testCase.expSpecs, specs)
+		}
+	}
+}
+
 func TestHTTPRequestRangeToHeader(t *testing.T) {
 	validRangeSpecs := []struct {
 		spec        string
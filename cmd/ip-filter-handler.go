@@ -0,0 +1,110 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+// ipInCIDRs returns true if ip is contained in any of the CIDRs.
+func ipInCIDRs(ip net.IP, cidrs []*net.IPNet) bool {
+	for _, cidr := range cidrs {
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// getPeerIP returns the IP address of the immediate peer of the connection.
+func getPeerIP(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return net.ParseIP(host)
+}
+
+// isTrustedProxyReq returns true if the request is received from
+// one of the configured trusted reverse proxies.
+func isTrustedProxyReq(r *http.Request) bool {
+	trustedProxies := globalAPIConfig.getTrustedProxies()
+	if len(trustedProxies) == 0 {
+		return false
+	}
+	ip := getPeerIP(r)
+	return ip != nil && ipInCIDRs(ip, trustedProxies)
+}
+
+// getTrustedSourceIP returns the IP address of the client. Forwarded
+// headers are only honored when the immediate peer is a trusted proxy,
+// in which case the right-most untrusted address of X-Forwarded-For is
+// used, since addresses to its left may be set by the client.
+func getTrustedSourceIP(r *http.Request, trustedProxies []*net.IPNet) net.IP {
+	ip := getPeerIP(r)
+	if ip == nil || !ipInCIDRs(ip, trustedProxies) {
+		return ip
+	}
+
+	var hops []string
+	for _, v := range r.Header.Values(xhttp.XForwardedFor) {
+		hops = append(hops, strings.Split(v, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			// Malformed entry, do not trust anything to its left.
+			break
+		}
+		ip = hop
+		if !ipInCIDRs(hop, trustedProxies) {
+			break
+		}
+	}
+	return ip
+}
+
+// setIPFilterHandler rejects requests from source IPs that are not
+// in the configured allow list, or are in the configured deny list.
+// Health checks are always served, load balancers probing the
+// server are usually not in the allow list.
+func setIPFilterHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allow, deny := globalAPIConfig.getIPFilter()
+		if (len(allow) == 0 && len(deny) == 0) || guessIsRPCReq(r) || guessIsHealthCheckReq(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		ip := getTrustedSourceIP(r, globalAPIConfig.getTrustedProxies())
+		if ip == nil || ipInCIDRs(ip, deny) || (len(allow) > 0 && !ipInCIDRs(ip, allow)) {
+			if tc, ok := r.Context().Value(contextTraceReqKey).(*traceCtxt); ok {
+				tc.funcName = "handler.IPFilter"
+				tc.responseRecorder.LogErrBody = true
+			}
+
+			writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+func mustParseCIDRs(t *testing.T, cidrs ...string) []*net.IPNet {
+	t.Helper()
+	var ipNets []*net.IPNet
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets
+}
+
+func TestGetTrustedSourceIP(t *testing.T) {
+	trustedProxies := mustParseCIDRs(t, "10.0.0.0/8", "fd00::/8")
+
+	testCases := []struct {
+		remoteAddr    string
+		xForwardedFor []string
+		expectedIP    string
+	}{
+		// Direct IPv4 client.
+		{"192.168.1.10:1234", nil, "192.168.1.10"},
+		// Direct IPv6 client.
+		{"[2001:db8::1]:1234", nil, "2001:db8::1"},
+		// Forwarded header from an untrusted peer is ignored.
+		{"192.168.1.10:1234", []string{"10.1.1.1"}, "192.168.1.10"},
+		// Forwarded header from a trusted proxy is honored.
+		{"10.0.0.1:1234", []string{"192.168.1.20"}, "192.168.1.20"},
+		// Forwarded header from a trusted IPv6 proxy.
+		{"[fd00::1]:1234", []string{"2001:db8::2"}, "2001:db8::2"},
+		// Client supplied entries left of the proxy hop are not trusted.
+		{"10.0.0.1:1234", []string{"172.16.0.1, 192.168.1.20"}, "192.168.1.20"},
+		// Chains of trusted proxies are skipped.
+		{"10.0.0.1:1234", []string{"192.168.1.20, 10.0.0.2"}, "192.168.1.20"},
+		// Multiple header values are combined.
+		{"10.0.0.1:1234", []string{"172.16.0.1", "192.168.1.20"}, "192.168.1.20"},
+		// Malformed entries stop the search.
+		{"10.0.0.1:1234", []string{"192.168.1.20, junk"}, "10.0.0.1"},
+	}
+
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:9000/bucket", nil)
+		r.RemoteAddr = testCase.remoteAddr
+		for _, v := range testCase.xForwardedFor {
+			r.Header.Add(xhttp.XForwardedFor, v)
+		}
+		ip := getTrustedSourceIP(r, trustedProxies)
+		if !ip.Equal(net.ParseIP(testCase.expectedIP)) {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expectedIP, ip)
+		}
+	}
+}
+
+func TestSetIPFilterHandler(t *testing.T) {
+	defer func(allow, deny, trusted []*net.IPNet) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.ipAllow = allow
+		globalAPIConfig.ipDeny = deny
+		globalAPIConfig.trustedProxies = trusted
+		globalAPIConfig.mu.Unlock()
+	}(globalAPIConfig.ipAllow, globalAPIConfig.ipDeny, globalAPIConfig.trustedProxies)
+
+	globalAPIConfig.mu.Lock()
+	globalAPIConfig.ipAllow = mustParseCIDRs(t, "192.168.0.0/16", "2001:db8::/32")
+	globalAPIConfig.ipDeny = mustParseCIDRs(t, "192.168.100.0/24")
+	globalAPIConfig.trustedProxies = mustParseCIDRs(t, "10.0.0.1/32")
+	globalAPIConfig.mu.Unlock()
+
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	testCases := []struct {
+		path           string
+		remoteAddr     string
+		xForwardedFor  string
+		expectedStatus int
+	}{
+		// Allowed IPv4 client.
+		{"/bucket", "192.168.1.10:1234", "", http.StatusOK},
+		// Allowed IPv6 client.
+		{"/bucket", "[2001:db8::1]:1234", "", http.StatusOK},
+		// Client outside of the allow list.
+		{"/bucket", "172.16.0.1:1234", "", http.StatusForbidden},
+		// IPv6 client outside of the allow list.
+		{"/bucket", "[2001:db9::1]:1234", "", http.StatusForbidden},
+		// Denied client inside of the allow list.
+		{"/bucket", "192.168.100.1:1234", "", http.StatusForbidden},
+		// Untrusted client spoofing an allowed address.
+		{"/bucket", "172.16.0.1:1234", "192.168.1.10", http.StatusForbidden},
+		// Allowed client behind a trusted proxy.
+		{"/bucket", "10.0.0.1:1234", "192.168.1.10", http.StatusOK},
+		// Denied client behind a trusted proxy.
+		{"/bucket", "10.0.0.1:1234", "192.168.100.1", http.StatusForbidden},
+		// Health checks from outside of the allow list.
+		{healthCheckPathPrefix + healthCheckLivenessPath, "172.16.0.1:1234", "", http.StatusOK},
+		{healthCheckPathPrefix + healthCheckReadinessPath, "192.168.100.1:1234", "", http.StatusOK},
+	}
+
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:9000"+testCase.path, nil)
+		r.RemoteAddr = testCase.remoteAddr
+		if testCase.xForwardedFor != "" {
+			r.Header.Set(xhttp.XForwardedFor, testCase.xForwardedFor)
+		}
+		rec := httptest.NewRecorder()
+		setIPFilterHandler(okHandler).ServeHTTP(rec, r)
+		if rec.Code != testCase.expectedStatus {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.expectedStatus, rec.Code)
+		}
+	}
+}
@@ -49,6 +49,7 @@ var (
 	errInvalidAccessKeyID = errors.New("The access key ID you provided does not exist in our records")
 	errAuthentication     = errors.New("Authentication failed, check your access credentials")
 	errNoAuthToken        = errors.New("JWT token missing")
+	errAuthTokenExpired   = errors.New("JWT token expired")
 )
 
 func authenticateJWTUsers(accessKey, secretKey string, expiry time.Duration) (string, error) {
@@ -131,6 +132,7 @@ func authenticateURL(accessKey, secretKey string) (string, error) {
 
 // Check if the request is authenticated.
 // Returns nil if the request is authenticated. errNoAuthToken if token missing.
+// Returns errAuthTokenExpired if the token is valid but has expired.
 // Returns errAuthentication for all other errors.
 func metricsRequestAuthenticate(req *http.Request) (*xjwt.MapClaims, []string, bool, error) {
 	token, err := jwtreq.AuthorizationHeaderExtractor.ExtractToken(req)
@@ -152,6 +154,10 @@ func metricsRequestAuthenticate(req *http.Request) (*xjwt.MapClaims, []string, b
 		cred := u.Credentials
 		return []byte(cred.SecretKey), nil
 	}); err != nil {
+		var vErr *jwtgo.ValidationError
+		if errors.As(err, &vErr) && vErr.Errors&jwtgo.ValidationErrorExpired != 0 {
+			return claims, nil, false, errAuthTokenExpired
+		}
 		return claims, nil, false, errAuthentication
 	}
 	owner := true
@@ -18,13 +18,16 @@
 package cmd
 
 import (
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
 
 	jwtgo "github.com/golang-jwt/jwt/v4"
 	"github.com/minio/minio/internal/auth"
+	xhttp "github.com/minio/minio/internal/http"
 	xjwt "github.com/minio/minio/internal/jwt"
 )
 
@@ -117,6 +120,10 @@ func TestWebRequestAuthenticate(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable get token %s", err)
 	}
+	expiredToken, err := getExpiredTokenString(creds.AccessKey, creds.SecretKey)
+	if err != nil {
+		t.Fatalf("unable get token %s", err)
+	}
 	testCases := []struct {
 		req         *http.Request
 		expectedErr error
@@ -146,6 +153,15 @@ func TestWebRequestAuthenticate(t *testing.T) {
 			},
 			expectedErr: errAuthentication,
 		},
+		// Expired authorization token.
+		{
+			req: &http.Request{
+				Header: http.Header{
+					"Authorization": []string{expiredToken},
+				},
+			},
+			expectedErr: errAuthTokenExpired,
+		},
 	}
 
 	for i, testCase := range testCases {
@@ -156,6 +172,70 @@ func TestWebRequestAuthenticate(t *testing.T) {
 	}
 }
 
+func getExpiredTokenString(accessKey, secretKey string) (string, error) {
+	claims := xjwt.NewMapClaims()
+	claims.SetExpiry(UTCNow().Add(-time.Minute))
+	claims.SetAccessKey(accessKey)
+	token := jwtgo.NewWithClaims(jwtgo.SigningMethodHS512, claims)
+	return token.SignedString([]byte(secretKey))
+}
+
+// Tests that metrics authentication failures are reported as 401
+// with a bearer challenge.
+func TestMetricsAuthMiddlewareUnauthorized(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	creds := globalActiveCred
+	expiredToken, err := getExpiredTokenString(creds.AccessKey, creds.SecretKey)
+	if err != nil {
+		t.Fatalf("unable get token %s", err)
+	}
+
+	handler := AuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	testCases := []struct {
+		authorization string
+		expectedCode  string
+		expectedAuth  string
+	}{
+		{"", "MissingToken", `Bearer realm="minio"`},
+		{"Bearer invalid-token", "InvalidToken", `Bearer realm="minio", error="invalid_token", error_description="The token is invalid"`},
+		{"Bearer " + expiredToken, "ExpiredToken", `Bearer realm="minio", error="invalid_token", error_description="The token has expired"`},
+	}
+
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:9000/minio/v2/metrics/cluster", nil)
+		if testCase.authorization != "" {
+			req.Header.Set(xhttp.Authorization, testCase.authorization)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, http.StatusUnauthorized, rec.Code)
+		}
+		if got := rec.Header().Get(xhttp.WWWAuthenticate); got != testCase.expectedAuth {
+			t.Errorf("Test %d: expected %s %q, got %q", i+1, xhttp.WWWAuthenticate, testCase.expectedAuth, got)
+		}
+		var errResp APIErrorResponse
+		if err := json.NewDecoder(rec.Body).Decode(&errResp); err != nil {
+			t.Fatalf("Test %d: unable to decode error response: %v", i+1, err)
+		}
+		if errResp.Code != testCase.expectedCode {
+			t.Errorf("Test %d: expected error code %s, got %s", i+1, testCase.expectedCode, errResp.Code)
+		}
+	}
+}
+
 func BenchmarkParseJWTStandardClaims(b *testing.B) {
 	obj, fsDir, err := prepareFS()
 	if err != nil {
@@ -78,6 +78,22 @@ func (o *listPathOptions) parseMarker() {
 	}
 }
 
+// forwardPastPrefix moves the marker past all object names rolled up in
+// a common prefix when the marker is one, i.e. ends with the delimiter.
+// Prefixes are only rolled up from recursive listings for delimiters
+// other than slash, directories are never descended into otherwise.
+// Object names are valid UTF-8 and never contain 0xff, so the marker
+// sorts after all names starting with the prefix and the listing
+// forwards past them without reading them.
+func (o *listPathOptions) forwardPastPrefix() {
+	if !o.Recursive || o.Separator == slashSeparator || o.Marker == "" {
+		return
+	}
+	if strings.HasSuffix(o.Marker, o.Separator) {
+		o.Marker += "\xff"
+	}
+}
+
 // encodeMarker will encode a uuid and return it as a marker.
 // uuid cannot contain '[', ':' or ','.
 func (o listPathOptions) encodeMarker(marker string) string {
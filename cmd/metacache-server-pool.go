@@ -97,6 +97,7 @@ func (z *erasureServerPools) listPath(ctx context.Context, o *listPathOptions) (
 
 	// Decode and get the optional list id from the marker.
 	o.parseMarker()
+	o.forwardPastPrefix()
 	o.BaseDir = baseDirFromPrefix(o.Prefix)
 	o.Transient = o.Transient || isReservedOrInvalidBucket(o.Bucket, false)
 	o.SetFilter()
@@ -305,6 +306,7 @@ func (es *erasureSingle) listPath(ctx context.Context, o *listPathOptions) (entr
 
 	// Decode and get the optional list id from the marker.
 	o.parseMarker()
+	o.forwardPastPrefix()
 	o.BaseDir = baseDirFromPrefix(o.Prefix)
 	o.Transient = o.Transient || isReservedOrInvalidBucket(o.Bucket, false)
 	o.SetFilter()
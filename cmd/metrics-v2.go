@@ -63,6 +63,7 @@ func init() {
 		getILMNodeMetrics(),
 		getScannerNodeMetrics(),
 		getIAMNodeMetrics(),
+		getMultipartNodeMetrics(),
 	}
 
 	allMetricsGroups := func() (allMetrics []*MetricsGroup) {
@@ -123,6 +124,7 @@ const (
 	ilmSubsystem              MetricSubsystem = "ilm"
 	scannerSubsystem          MetricSubsystem = "scanner"
 	iamSubsystem              MetricSubsystem = "iam"
+	multipartSubsystem        MetricSubsystem = "multipart"
 )
 
 // MetricName are the individual names for the metric.
@@ -138,6 +140,7 @@ const (
 	inflightTotal  MetricName = "inflight_total"
 	invalidTotal   MetricName = "invalid_total"
 	limitTotal     MetricName = "limit_total"
+	maxTotal       MetricName = "max_total"
 	missedTotal    MetricName = "missed_total"
 	waitingTotal   MetricName = "waiting_total"
 	incomingTotal  MetricName = "incoming_total"
@@ -145,6 +148,7 @@ const (
 	offlineTotal   MetricName = "offline_total"
 	onlineTotal    MetricName = "online_total"
 	openTotal      MetricName = "open_total"
+	rateTotal      MetricName = "rate_total"
 	readTotal      MetricName = "read_total"
 	timestampTotal MetricName = "timestamp_total"
 	writeTotal     MetricName = "write_total"
@@ -188,6 +192,8 @@ const (
 	transitionedBytes    MetricName = "transitioned_bytes"
 	transitionedObjects  MetricName = "transitioned_objects"
 	transitionedVersions MetricName = "transitioned_versions"
+
+	staleUploadsReaped MetricName = "stale_uploads_reaped"
 )
 
 const (
@@ -588,6 +594,16 @@ func getS3RequestsInQueueMD() MetricDescription {
 	}
 }
 
+func getS3RequestsAuthTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsSubsystem,
+		Name:      authTotal,
+		Help:      "Total number of S3 requests by authentication type and outcome",
+		Type:      counterMetric,
+	}
+}
+
 func getIncomingS3RequestsMD() MetricDescription {
 	return MetricDescription{
 		Namespace: s3MetricNamespace,
@@ -678,6 +694,26 @@ func getS3RejectedTimestampRequestsTotalMD() MetricDescription {
 	}
 }
 
+func getS3RejectedMaxRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsRejectedSubsystem,
+		Name:      maxTotal,
+		Help:      "Total number S3 requests rejected for exceeding the maximum number of concurrent requests",
+		Type:      counterMetric,
+	}
+}
+
+func getS3RejectedRateRequestsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: s3MetricNamespace,
+		Subsystem: requestsRejectedSubsystem,
+		Name:      rateTotal,
+		Help:      "Total number S3 requests rejected for exceeding the requests rate",
+		Type:      counterMetric,
+	}
+}
+
 func getS3RejectedInvalidRequestsTotalMD() MetricDescription {
 	return MetricDescription{
 		Namespace: s3MetricNamespace,
@@ -1211,6 +1247,29 @@ func getILMNodeMetrics() *MetricsGroup {
 	return mg
 }
 
+func getStaleUploadsReapedMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: multipartSubsystem,
+		Name:      staleUploadsReaped,
+		Help:      "Total number of stale multipart uploads removed since server start",
+		Type:      counterMetric,
+	}
+}
+
+func getMultipartNodeMetrics() *MetricsGroup {
+	mg := &MetricsGroup{}
+	mg.RegisterRead(func(_ context.Context) []Metric {
+		return []Metric{
+			{
+				Description: getStaleUploadsReapedMD(),
+				Value:       float64(atomic.LoadUint64(&globalStaleUploadsReaped)),
+			},
+		}
+	})
+	return mg
+}
+
 func getScannerNodeMetrics() *MetricsGroup {
 	mg := &MetricsGroup{}
 	mg.RegisterRead(func(_ context.Context) []Metric {
@@ -1537,6 +1596,14 @@ func getHTTPMetrics() *MetricsGroup {
 			Description: getS3RejectedInvalidRequestsTotalMD(),
 			Value:       float64(httpStats.TotalS3RejectedInvalid),
 		})
+		metrics = append(metrics, Metric{
+			Description: getS3RejectedRateRequestsTotalMD(),
+			Value:       float64(httpStats.TotalS3RejectedRate),
+		})
+		metrics = append(metrics, Metric{
+			Description: getS3RejectedMaxRequestsTotalMD(),
+			Value:       float64(httpStats.TotalS3RejectedMax),
+		})
 		metrics = append(metrics, Metric{
 			Description: getS3RequestsInQueueMD(),
 			Value:       float64(httpStats.S3RequestsInQueue),
@@ -1546,6 +1613,16 @@ func getHTTPMetrics() *MetricsGroup {
 			Value:       float64(httpStats.S3RequestsIncoming),
 		})
 
+		for aType, outcomes := range httpStats.TotalS3AuthOutcomes {
+			for outcome, value := range outcomes {
+				metrics = append(metrics, Metric{
+					Description:    getS3RequestsAuthTotalMD(),
+					Value:          float64(value),
+					VariableLabels: map[string]string{"type": aType, "outcome": outcome},
+				})
+			}
+		}
+
 		for api, value := range httpStats.CurrentS3Requests.APIStats {
 			metrics = append(metrics, Metric{
 				Description:    getS3RequestsInFlightMD(),
@@ -18,11 +18,14 @@
 package cmd
 
 import (
+	"context"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync/atomic"
 	"time"
 
+	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 	iampolicy "github.com/minio/pkg/iam/policy"
 	"github.com/prometheus/client_golang/prometheus"
@@ -688,6 +691,30 @@ func metricsHandler() http.Handler {
 	})
 }
 
+// writeMetricsAuthErrorResponse writes a 401 with a WWW-Authenticate
+// challenge as per RFC 6750, telling clients whether the bearer token
+// is missing, expired or otherwise invalid.
+func writeMetricsAuthErrorResponse(ctx context.Context, w http.ResponseWriter, authErr error, reqURL *url.URL) {
+	apiErr := APIError{
+		Code:           "InvalidToken",
+		Description:    "The provided bearer token is invalid.",
+		HTTPStatusCode: http.StatusUnauthorized,
+	}
+	challenge := `Bearer realm="minio", error="invalid_token", error_description="The token is invalid"`
+	switch authErr {
+	case errNoAuthToken:
+		apiErr.Code = "MissingToken"
+		apiErr.Description = "A bearer token is required to access this resource."
+		challenge = `Bearer realm="minio"`
+	case errAuthTokenExpired:
+		apiErr.Code = "ExpiredToken"
+		apiErr.Description = "The provided bearer token has expired."
+		challenge = `Bearer realm="minio", error="invalid_token", error_description="The token has expired"`
+	}
+	w.Header().Set(xhttp.WWWAuthenticate, challenge)
+	writeErrorResponseJSON(ctx, w, apiErr, reqURL)
+}
+
 // AuthMiddleware checks if the bearer token is valid and authorized.
 func AuthMiddleware(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -700,7 +727,7 @@ func AuthMiddleware(h http.Handler) http.Handler {
 				tc.responseRecorder.LogErrBody = true
 			}
 
-			writeErrorResponseJSON(r.Context(), w, toAdminAPIErr(r.Context(), errAuthentication), r.URL)
+			writeMetricsAuthErrorResponse(r.Context(), w, authErr, r.URL)
 			return
 		}
 		// For authenticated users apply IAM policy.
@@ -320,6 +320,13 @@ func (e ObjectExistsAsDirectory) Error() string {
 	return "Object exists on : " + e.Bucket + " as directory " + e.Object
 }
 
+// ObjectNameCaseCollision object name differs only in case from an existing object.
+type ObjectNameCaseCollision GenericError
+
+func (e ObjectNameCaseCollision) Error() string {
+	return "Object name collides with a differently cased object on : " + e.Bucket + " " + e.Object
+}
+
 // PrefixAccessDenied object access is denied.
 type PrefixAccessDenied GenericError
 
@@ -1736,6 +1736,7 @@ func testListObjectsContinuation(obj ObjectLayer, instanceType string, t1 TestEr
 		// This bucket is used for testing ListObject operations.
 		"test-bucket-list-object-continuation-1",
 		"test-bucket-list-object-continuation-2",
+		"test-bucket-list-object-continuation-3",
 	}
 	for _, bucket := range testBuckets {
 		err := obj.MakeBucketWithLocation(context.Background(), bucket, BucketOptions{})
@@ -1760,6 +1761,16 @@ func testListObjectsContinuation(obj ObjectLayer, instanceType string, t1 TestEr
 		{testBuckets[1], "azerty/1.txt", "contentstring", nil},
 		{testBuckets[1], "apache2-doc/1.txt", "contentstring", nil},
 		{testBuckets[1], "apache2/1.txt", "contentstring", nil},
+		{testBuckets[2], "logs/app-1/a.log", "contentstring", nil},
+		{testBuckets[2], "logs/app-1/b.log", "contentstring", nil},
+		{testBuckets[2], "logs/app-2/a.log", "contentstring", nil},
+		{testBuckets[2], "logs/db-1/a.log", "contentstring", nil},
+		{testBuckets[2], "logs/index", "contentstring", nil},
+		{testBuckets[2], "photos/2021/feb/1.jpg", "contentstring", nil},
+		{testBuckets[2], "photos/2021/jan/1.jpg", "contentstring", nil},
+		{testBuckets[2], "photos/2021/jan/2.jpg", "contentstring", nil},
+		{testBuckets[2], "photos/2022/mar/1.jpg", "contentstring", nil},
+		{testBuckets[2], "photos/index.html", "contentstring", nil},
 	}
 	for _, object := range testObjects {
 		md5Bytes := md5.Sum([]byte(object.content))
@@ -1794,6 +1805,31 @@ func testListObjectsContinuation(obj ObjectLayer, instanceType string, t1 TestEr
 		{
 			Prefixes: []string{"apache2-doc/", "apache2/", "azerty/"},
 		},
+		{
+			Objects:  []ObjectInfo{{Name: "photos/index.html"}},
+			Prefixes: []string{"photos/2021/", "photos/2022/"},
+		},
+		{
+			Prefixes: []string{"photos/2021/feb/", "photos/2021/jan/"},
+		},
+		{
+			Prefixes: []string{"photos/2021/", "photos/2022/"},
+		},
+		{
+			Objects: []ObjectInfo{
+				{Name: "photos/2021/feb/1.jpg"},
+				{Name: "photos/2022/mar/1.jpg"},
+				{Name: "photos/index.html"},
+			},
+			Prefixes: []string{"photos/2021/jan/"},
+		},
+		{
+			Objects:  []ObjectInfo{{Name: "logs/index"}},
+			Prefixes: []string{"logs/app-", "logs/db-"},
+		},
+		{
+			Prefixes: []string{"logs/app-1/", "logs/app-2/"},
+		},
 	}
 
 	testCases := []struct {
@@ -1809,6 +1845,14 @@ func testListObjectsContinuation(obj ObjectLayer, instanceType string, t1 TestEr
 		{testBuckets[0], "a", "", 1, resultCases[0]},
 		{testBuckets[1], "apache", "", 1, resultCases[1]},
 		{testBuckets[1], "", "/", 1, resultCases[2]},
+		{testBuckets[2], "photos/", "/", 1, resultCases[3]},
+		{testBuckets[2], "photos/2021/", "/", 1, resultCases[4]},
+		{testBuckets[2], "photos/20", "/", 1, resultCases[5]},
+		{testBuckets[2], "photos/", "jan/", 1, resultCases[6]},
+		{testBuckets[2], "photos/", "jan/", 1000, resultCases[6]},
+		{testBuckets[2], "logs/", "-", 1, resultCases[7]},
+		{testBuckets[2], "logs/", "-", 1000, resultCases[7]},
+		{testBuckets[2], "logs/app-", "/", 1, resultCases[8]},
 	}
 
 	for i, testCase := range testCases {
@@ -21,12 +21,15 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio/internal/hash"
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 // Wrapper for calling NewMultipartUpload tests for both Erasure multiple disks and single node setup.
@@ -1840,6 +1843,87 @@ func testObjectCompleteMultipartUpload(obj ObjectLayer, instanceType string, t T
 	}
 }
 
+// Test for validating conditional CompleteMultipartUpload.
+func TestObjectCompleteMultipartUploadIfNoneMatch(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectCompleteMultipartUploadIfNoneMatch)
+}
+
+// Tests that of two racing completions with If-None-Match: *
+// only one creates the object.
+func testObjectCompleteMultipartUploadIfNoneMatch(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket, object := "minio-bucket", "minio-object"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+
+	r, err := http.NewRequest(http.MethodPost, "/", nil)
+	if err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	r.Header.Set(xhttp.IfNoneMatch, "*")
+	opts := ObjectOptions{
+		CheckPrecondFn: func(oi ObjectInfo) bool {
+			return checkPreconditionsPUT(oi, r)
+		},
+	}
+
+	const uploads = 2
+	var completeParts [uploads][]CompletePart
+	var uploadIDs [uploads]string
+	for i := range uploadIDs {
+		uploadIDs[i], err = obj.NewMultipartUpload(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s : %s", instanceType, err)
+		}
+		data := []byte(fmt.Sprintf("upload-%d", i))
+		pInfo, err := obj.PutObjectPart(ctx, bucket, object, uploadIDs[i], 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s : %s", instanceType, err)
+		}
+		completeParts[i] = []CompletePart{{PartNumber: 1, ETag: pInfo.ETag}}
+	}
+
+	var wg sync.WaitGroup
+	var errs [uploads]error
+	for i := range uploadIDs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = obj.CompleteMultipartUpload(ctx, bucket, object, uploadIDs[i], completeParts[i], opts)
+		}(i)
+	}
+	wg.Wait()
+
+	var succeeded, failed int
+	for _, err := range errs {
+		switch err.(type) {
+		case nil:
+			succeeded++
+		case PreConditionFailed:
+			failed++
+		default:
+			t.Fatalf("%s : unexpected error %v", instanceType, err)
+		}
+	}
+	if succeeded != 1 || failed != 1 {
+		t.Fatalf("%s : expected one completion to succeed and one to fail, got %d and %d", instanceType, succeeded, failed)
+	}
+
+	// Completing without the condition still overwrites the object.
+	uploadID, err := obj.NewMultipartUpload(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	pInfo, err := obj.PutObjectPart(ctx, bucket, object, uploadID, 1, mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	if _, err = obj.CompleteMultipartUpload(ctx, bucket, object, uploadID, []CompletePart{{PartNumber: 1, ETag: pInfo.ETag}}, ObjectOptions{}); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+}
+
 // Benchmarks for ObjectLayer.PutObjectPart().
 // The intent is to benchmark PutObjectPart for various sizes ranging from few bytes to 100MB.
 // Also each of these Benchmarks are run both Erasure and FS backends.
@@ -241,6 +241,10 @@ func mustGetUUID() string {
 }
 
 // Create an s3 compatible MD5sum for complete multipart transaction.
+// The ETag of a multipart object is the hex encoded MD5 of the concatenated
+// binary MD5s of its parts, followed by '-' and the number of parts, e.g.
+// "9b2cf535f27731c974343645a3985328-3". Objects uploaded with a single PUT
+// have the hex encoded MD5 of their content as ETag instead.
 func getCompleteMultipartMD5(parts []CompletePart) string {
 	var finalMD5Bytes []byte
 	for _, part := range parts {
@@ -568,6 +572,28 @@ func NewGetObjectReaderFromReader(r io.Reader, oi ObjectInfo, opts ObjectOptions
 	}, nil
 }
 
+// checkPutPreconditions evaluates the pre-conditions in opts, if any,
+// against the current version of the object returned by getObjectInfo.
+// Callers must hold the write lock on the object. A missing object or a
+// delete marker is evaluated as an empty ObjectInfo.
+func checkPutPreconditions(ctx context.Context, bucket, object string, getObjectInfo GetObjectInfoFn, opts ObjectOptions) error {
+	if opts.CheckPrecondFn == nil {
+		return nil
+	}
+	oi, err := getObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		if !isErrObjectNotFound(err) {
+			return err
+		}
+		// Delete markers are returned along with the error.
+		oi = ObjectInfo{}
+	}
+	if opts.CheckPrecondFn(oi) {
+		return PreConditionFailed{}
+	}
+	return nil
+}
+
 // ObjReaderFn is a function type that takes a reader and returns
 // GetObjectReader and an error. Request headers are passed to provide
 // encryption parameters. cleanupFns allow cleanup funcs to be
@@ -53,6 +53,7 @@ func TestIsValidBucketName(t *testing.T) {
 		{"bucket1", true},
 		{"a.b", true},
 		{"ab.a.bc", true},
+		{"this-bucket-name-is-exactly-sixty-three-characters-long-0123456", true},
 		// cases for which test should fail.
 		// passing invalid bucket names.
 		{"------", false},
@@ -79,6 +80,9 @@ func TestIsValidBucketName(t *testing.T) {
 		{"dash.-may-not-appear-next-to-dot", false},
 		{"dash-.-may-not-appear-next-to-dot", false},
 		{"lalalallalallalalalallalallalala-thestring-size-is-greater-than-63", false},
+		{"contains_underscore", false},
+		{"10.0.0.1", false},
+		{"contains space", false},
 	}
 
 	for i, testCase := range testCases {
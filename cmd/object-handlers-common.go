@@ -19,10 +19,18 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/minio/minio/internal/event"
 	xhttp "github.com/minio/minio/internal/http"
@@ -72,9 +80,10 @@ func checkCopyObjectPreconditions(ctx context.Context, w http.ResponseWriter, r
 		}
 	}
 	// x-amz-copy-source-if-modified-since: Return the object only if it has been modified
-	// since the specified time otherwise return 412 (precondition failed).
+	// since the specified time otherwise return 412 (precondition failed). Like S3, it is
+	// ignored when x-amz-copy-source-if-none-match is set.
 	ifModifiedSinceHeader := r.Header.Get(xhttp.AmzCopySourceIfModifiedSince)
-	if ifModifiedSinceHeader != "" {
+	if ifModifiedSinceHeader != "" && r.Header.Get(xhttp.AmzCopySourceIfNoneMatch) == "" {
 		if givenTime, err := time.Parse(http.TimeFormat, ifModifiedSinceHeader); err == nil {
 			if !ifModifiedSince(objInfo.ModTime, givenTime) {
 				// If the object is not modified since the specified time.
@@ -87,8 +96,9 @@ func checkCopyObjectPreconditions(ctx context.Context, w http.ResponseWriter, r
 
 	// x-amz-copy-source-if-unmodified-since : Return the object only if it has not been
 	// modified since the specified time, otherwise return a 412 (precondition failed).
+	// Like S3, it is ignored when x-amz-copy-source-if-match is set.
 	ifUnmodifiedSinceHeader := r.Header.Get(xhttp.AmzCopySourceIfUnmodifiedSince)
-	if ifUnmodifiedSinceHeader != "" {
+	if ifUnmodifiedSinceHeader != "" && r.Header.Get(xhttp.AmzCopySourceIfMatch) == "" {
 		if givenTime, err := time.Parse(http.TimeFormat, ifUnmodifiedSinceHeader); err == nil {
 			if ifModifiedSince(objInfo.ModTime, givenTime) {
 				// If the object is modified since the specified time.
@@ -219,6 +229,36 @@ func checkPreconditions(ctx context.Context, w http.ResponseWriter, r *http.Requ
 	return false
 }
 
+// Validates the preconditions of a PutObject request against the
+// object being overwritten, objInfo is empty if there is none.
+// Returns true if any precondition fails.
+// Preconditions supported are:
+//  If-Match
+//  If-None-Match
+func checkPreconditionsPUT(objInfo ObjectInfo, r *http.Request) bool {
+	exists := objInfo.Name != ""
+
+	// If-Match : Write the object only if it exists and its entity tag (ETag)
+	// is the same as the one specified.
+	if ifMatchETagHeader := r.Header.Get(xhttp.IfMatch); ifMatchETagHeader != "" {
+		if !exists {
+			return true
+		}
+		if ifMatchETagHeader != "*" && !isETagEqual(objInfo.ETag, ifMatchETagHeader) {
+			return true
+		}
+	}
+
+	// If-None-Match : Write the object only if it does not exist, for "*",
+	// or if its entity tag (ETag) is different from the one specified.
+	if ifNoneMatchETagHeader := r.Header.Get(xhttp.IfNoneMatch); ifNoneMatchETagHeader != "" && exists {
+		if ifNoneMatchETagHeader == "*" || isETagEqual(objInfo.ETag, ifNoneMatchETagHeader) {
+			return true
+		}
+	}
+	return false
+}
+
 // returns true if object was modified after givenTime.
 func ifModifiedSince(objTime time.Time, givenTime time.Time) bool {
 	// The Date-Modified header truncates sub-second precision, so
@@ -265,6 +305,78 @@ func setPutObjHeaders(w http.ResponseWriter, objInfo ObjectInfo, delete bool) {
 	}
 }
 
+// caseCollisionLeadingChars is the number of leading characters of an
+// object name whose case variants are listed to detect case collisions.
+const caseCollisionLeadingChars = 3
+
+// caseFoldVariants returns all strings equal to s under simple case folding.
+func caseFoldVariants(s string) []string {
+	variants := []string{""}
+	for _, c := range s {
+		var next []string
+		for _, v := range variants {
+			for f := c; ; {
+				next = append(next, v+string(f))
+				if f = unicode.SimpleFold(f); f == c {
+					break
+				}
+			}
+		}
+		variants = next
+	}
+	return variants
+}
+
+// checkObjectNameCaseCollision returns ObjectNameCaseCollision when an
+// object under the same parent prefix differs from object only in case,
+// which would overwrite it on case-insensitive backends. Differently
+// cased parent prefixes are not detected. Only the names starting with
+// a case variant of the first characters of object are listed, at most
+// 2^caseCollisionLeadingChars listings for ASCII names.
+func checkObjectNameCaseCollision(ctx context.Context, o ObjectLayer, bucket, object string) error {
+	if !globalAPIConfig.shouldRejectCaseCollisions() {
+		return nil
+	}
+
+	name := strings.TrimSuffix(object, SlashSeparator)
+	var prefix string
+	if i := strings.LastIndex(name, SlashSeparator); i >= 0 {
+		prefix = name[:i+1]
+	}
+
+	leading := name[len(prefix):]
+	for i := range leading {
+		if utf8.RuneCountInString(leading[:i]) == caseCollisionLeadingChars {
+			leading = leading[:i]
+			break
+		}
+	}
+
+	for _, variant := range caseFoldVariants(leading) {
+		var marker string
+		for {
+			loi, err := o.ListObjects(ctx, bucket, prefix+variant, marker, SlashSeparator, maxObjectList)
+			if err != nil {
+				return err
+			}
+			names := loi.Prefixes
+			for _, oi := range loi.Objects {
+				names = append(names, oi.Name)
+			}
+			for _, name := range names {
+				if name != object && strings.EqualFold(name, object) {
+					return ObjectNameCaseCollision{Bucket: bucket, Object: object}
+				}
+			}
+			if !loi.IsTruncated {
+				break
+			}
+			marker = loi.NextMarker
+		}
+	}
+	return nil
+}
+
 func deleteObjectVersions(ctx context.Context, o ObjectLayer, bucket string, toDel []ObjectToDelete) {
 	for remaining := toDel; len(remaining) > 0; toDel = remaining {
 		if len(toDel) > maxDeleteList {
@@ -301,3 +413,86 @@ func deleteObjectVersions(ctx context.Context, o ObjectLayer, bucket string, toD
 		}
 	}
 }
+
+// writeMultiRangeResponse writes the given ranges of the object as a
+// multipart/byteranges response. Every part carries its own Content-Type
+// and Content-Range, the content of each range is read with getRange.
+func writeMultiRangeResponse(w http.ResponseWriter, objInfo ObjectInfo, rangeSpecs []*HTTPRangeSpec,
+	getRange func(rs *HTTPRangeSpec) (*GetObjectReader, error)) error {
+	totalObjectSize, err := objInfo.GetActualSize()
+	if err != nil {
+		return err
+	}
+
+	contentType := w.Header().Get(xhttp.ContentType)
+	partHeaders := make([]textproto.MIMEHeader, 0, len(rangeSpecs))
+	partLengths := make([]int64, 0, len(rangeSpecs))
+	for _, rs := range rangeSpecs {
+		start, length, err := rs.GetOffsetLength(totalObjectSize)
+		if err != nil {
+			return err
+		}
+		header := make(textproto.MIMEHeader)
+		if contentType != "" {
+			header.Set(xhttp.ContentType, contentType)
+		}
+		header.Set(xhttp.ContentRange, fmt.Sprintf("bytes %d-%d/%d", start, start+length-1, totalObjectSize))
+		partHeaders = append(partHeaders, header)
+		partLengths = append(partLengths, length)
+	}
+
+	// Compute the content length by writing the multipart
+	// framing without the part contents.
+	counter := &countingWriter{w: ioutil.Discard}
+	mw := multipart.NewWriter(counter)
+	for _, header := range partHeaders {
+		if _, err = mw.CreatePart(header); err != nil {
+			return err
+		}
+	}
+	if err = mw.Close(); err != nil {
+		return err
+	}
+	contentLength := counter.n
+	for _, length := range partLengths {
+		contentLength += length
+	}
+
+	w.Header().Del(xhttp.ContentRange)
+	w.Header().Set(xhttp.ContentType, "multipart/byteranges; boundary="+mw.Boundary())
+	w.Header().Set(xhttp.ContentLength, strconv.FormatInt(contentLength, 10))
+	w.WriteHeader(http.StatusPartialContent)
+
+	body := multipart.NewWriter(w)
+	if err = body.SetBoundary(mw.Boundary()); err != nil {
+		return err
+	}
+	for i, rs := range rangeSpecs {
+		part, err := body.CreatePart(partHeaders[i])
+		if err != nil {
+			return err
+		}
+		gr, err := getRange(rs)
+		if err != nil {
+			return err
+		}
+		_, err = io.CopyN(part, gr, partLengths[i])
+		gr.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return body.Close()
+}
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
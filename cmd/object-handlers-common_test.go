@@ -18,7 +18,15 @@
 package cmd
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
 	"testing"
+	"time"
+
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 // Tests - canonicalizeETag()
@@ -51,3 +59,111 @@ func TestCanonicalizeETag(t *testing.T) {
 		}
 	}
 }
+
+// Tests - caseFoldVariants()
+func TestCaseFoldVariants(t *testing.T) {
+	testCases := []struct {
+		s        string
+		variants []string
+	}{
+		{"", []string{""}},
+		{"1-", []string{"1-"}},
+		{"a", []string{"a", "A"}},
+		{"a1B", []string{"a1B", "a1b", "A1B", "A1b"}},
+		// The Kelvin sign folds to k as well.
+		{"k", []string{"k", "\u212a", "K"}},
+	}
+	for i, testCase := range testCases {
+		variants := caseFoldVariants(testCase.s)
+		sort.Strings(variants)
+		sort.Strings(testCase.variants)
+		if !reflect.DeepEqual(variants, testCase.variants) {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.variants, variants)
+		}
+	}
+}
+
+// Tests - checkPreconditionsPUT()
+func TestCheckPreconditionsPUT(t *testing.T) {
+	existing := ObjectInfo{Name: "object", ETag: "aa7b6b88ff0c8c4a8b3e0fb4b6e6c6a8"}
+	testCases := []struct {
+		objInfo     ObjectInfo
+		ifMatch     string
+		ifNoneMatch string
+		failed      bool
+	}{
+		{objInfo: existing, failed: false},
+		{objInfo: ObjectInfo{}, ifNoneMatch: "*", failed: false},
+		{objInfo: existing, ifNoneMatch: "*", failed: true},
+		{objInfo: existing, ifNoneMatch: `"` + existing.ETag + `"`, failed: true},
+		{objInfo: existing, ifNoneMatch: "deadbeef", failed: false},
+		{objInfo: ObjectInfo{}, ifMatch: existing.ETag, failed: true},
+		{objInfo: existing, ifMatch: `"` + existing.ETag + `"`, failed: false},
+		{objInfo: existing, ifMatch: "deadbeef", failed: true},
+		{objInfo: existing, ifMatch: "*", failed: false},
+		{objInfo: existing, ifMatch: existing.ETag, ifNoneMatch: "*", failed: true},
+	}
+	for i, test := range testCases {
+		r, err := http.NewRequest(http.MethodPut, "http://localhost:9000/bucket/object", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.ifMatch != "" {
+			r.Header.Set(xhttp.IfMatch, test.ifMatch)
+		}
+		if test.ifNoneMatch != "" {
+			r.Header.Set(xhttp.IfNoneMatch, test.ifNoneMatch)
+		}
+		if failed := checkPreconditionsPUT(test.objInfo, r); failed != test.failed {
+			t.Errorf("Test %d: expected %t, got %t", i+1, test.failed, failed)
+		}
+	}
+}
+
+func TestCheckCopyObjectPreconditions(t *testing.T) {
+	modTime := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	objInfo := ObjectInfo{Name: "object", ETag: "aa7b6b88ff0c8c4a8b3e0fb4b6e6c6a8", ModTime: modTime}
+	before := modTime.Add(-time.Hour).Format(http.TimeFormat)
+	after := modTime.Add(time.Hour).Format(http.TimeFormat)
+	testCases := []struct {
+		headers map[string]string
+		failed  bool
+	}{
+		{headers: map[string]string{}, failed: false},
+		{headers: map[string]string{xhttp.AmzCopySourceIfMatch: `"` + objInfo.ETag + `"`}, failed: false},
+		{headers: map[string]string{xhttp.AmzCopySourceIfMatch: "deadbeef"}, failed: true},
+		{headers: map[string]string{xhttp.AmzCopySourceIfNoneMatch: objInfo.ETag}, failed: true},
+		{headers: map[string]string{xhttp.AmzCopySourceIfNoneMatch: "deadbeef"}, failed: false},
+		{headers: map[string]string{xhttp.AmzCopySourceIfModifiedSince: before}, failed: false},
+		{headers: map[string]string{xhttp.AmzCopySourceIfModifiedSince: after}, failed: true},
+		{headers: map[string]string{xhttp.AmzCopySourceIfUnmodifiedSince: after}, failed: false},
+		{headers: map[string]string{xhttp.AmzCopySourceIfUnmodifiedSince: before}, failed: true},
+		// if-match takes precedence over if-unmodified-since.
+		{headers: map[string]string{
+			xhttp.AmzCopySourceIfMatch:           objInfo.ETag,
+			xhttp.AmzCopySourceIfUnmodifiedSince: before,
+		}, failed: false},
+		// if-none-match takes precedence over if-modified-since.
+		{headers: map[string]string{
+			xhttp.AmzCopySourceIfNoneMatch:     objInfo.ETag,
+			xhttp.AmzCopySourceIfModifiedSince: before,
+		}, failed: true},
+		{headers: map[string]string{
+			xhttp.AmzCopySourceIfNoneMatch:     "deadbeef",
+			xhttp.AmzCopySourceIfModifiedSince: after,
+		}, failed: false},
+	}
+	for i, test := range testCases {
+		r := httptest.NewRequest(http.MethodPut, "http://localhost:9000/bucket/object", nil)
+		for k, v := range test.headers {
+			r.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		if failed := checkCopyObjectPreconditions(context.Background(), w, r, objInfo); failed != test.failed {
+			t.Errorf("Test %d: expected %t, got %t", i+1, test.failed, failed)
+		}
+		if test.failed && w.Code != http.StatusPreconditionFailed {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, http.StatusPreconditionFailed, w.Code)
+		}
+	}
+}
@@ -19,12 +19,14 @@ package cmd
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/hex"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -384,6 +386,7 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 
 	// Get request range.
 	var rs *HTTPRangeSpec
+	var rangeSpecs []*HTTPRangeSpec
 	var rangeErr error
 	rangeHeader := r.Header.Get(xhttp.Range)
 	if rangeHeader != "" {
@@ -393,7 +396,7 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 			return
 		}
 
-		rs, rangeErr = parseRequestRangeSpec(rangeHeader)
+		rangeSpecs, rangeErr = parseRequestRangeSpecs(rangeHeader)
 		// Handle only errInvalidRange. Ignore other
 		// parse error and treat it as regular Get
 		// request like Amazon S3.
@@ -404,6 +407,11 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 		if rangeErr != nil {
 			logger.LogIf(ctx, rangeErr, logger.Application)
 		}
+		// Multiple ranges are served one by one after the
+		// whole object has passed the pre-conditions.
+		if len(rangeSpecs) == 1 {
+			rs = rangeSpecs[0]
+		}
 	}
 
 	// Validate pre-conditions if any.
@@ -515,6 +523,20 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 		}
 	}
 
+	multiRange := len(rangeSpecs) > 1
+	if multiRange {
+		// Drop the ranges which do not overlap with the object,
+		// if none is left the whole request is not satisfiable.
+		rangeSpecs, err = satisfiableRangeSpecs(objInfo, rangeSpecs)
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		// The ranges add up to more than the object, serve
+		// the whole object like a request without ranges.
+		multiRange = len(rangeSpecs) > 0
+	}
+
 	if err = setObjectHeaders(w, objInfo, rs, opts); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
@@ -527,6 +549,46 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 
 	setHeadGetRespHeaders(w, r.Form)
 
+	if multiRange {
+		// The whole object reader is not needed anymore,
+		// each range is read separately.
+		gr.Close()
+
+		// Read the ranges from the same version of the object, the
+		// pre-conditions have been validated already. An overwrite of
+		// an unversioned object changes its ETag, fail the remaining
+		// ranges instead of mixing two objects in one response.
+		ropts := opts
+		etag := objInfo.ETag
+		ropts.CheckPrecondFn = func(oi ObjectInfo) bool {
+			return oi.ETag != etag
+		}
+		if objInfo.VersionID != "" {
+			ropts.VersionID = objInfo.VersionID
+		}
+		getRange := func(rs *HTTPRangeSpec) (*GetObjectReader, error) {
+			return getObjectNInfo(ctx, bucket, object, rs, r.Header, readLock, ropts)
+		}
+		if err = writeMultiRangeResponse(w, objInfo, rangeSpecs, getRange); err != nil {
+			if !xnet.IsNetworkOrHostDown(err, true) && !isErrPreconditionFailed(err) { // do not need to log disconnected clients
+				logger.LogIf(ctx, fmt.Errorf("Unable to write all the data to client %w", err))
+			}
+			return
+		}
+
+		// Notify object accessed via a GET request.
+		sendEvent(eventArgs{
+			EventName:    event.ObjectAccessedGet,
+			BucketName:   bucket,
+			Object:       objInfo,
+			ReqParams:    extractReqParams(r),
+			RespElements: extractRespElements(w),
+			UserAgent:    r.UserAgent(),
+			Host:         handlers.GetSourceIP(r),
+		})
+		return
+	}
+
 	statusCodeWritten := false
 	httpWriter := xioutil.WriteOnClose(w)
 	if rs != nil || opts.PartNumber > 0 {
@@ -1145,6 +1207,10 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 			return
 		}
+		if err := checkObjectNameCaseCollision(ctx, objectAPI, dstBucket, dstObject); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
 	}
 
 	// Check if either the source is encrypted or the destination will be encrypted.
@@ -1623,7 +1689,9 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 			}
 		}
 	}
-	if size == -1 {
+	// Bodies sent with Transfer-Encoding: chunked have no
+	// Content-Length, these are read until EOF instead.
+	if size == -1 && !contains(r.TransferEncoding, "chunked") {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL)
 		return
 	}
@@ -1654,6 +1722,22 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		metadata[xhttp.AmzObjectTagging] = objTags
 	}
 
+	if acl := r.Header.Get(xhttp.AmzACL); acl != "" {
+		if !isValidCannedACL(acl) {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidCannedACL), r.URL)
+			return
+		}
+		if isPublicCannedACL(acl) {
+			// Public ACLs require the permission to change the object
+			// ACL, which is managed as the bucket policy.
+			if s3Err := isPutActionAllowed(ctx, rAuthType, bucket, "", r, iampolicy.PutBucketPolicyAction); s3Err != ErrNone {
+				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
+				return
+			}
+			metadata[cannedACLKey] = acl
+		}
+	}
+
 	var (
 		md5hex              = clientETag.String()
 		sha256hex           = ""
@@ -1693,10 +1777,26 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
+	if size == -1 {
+		// The body has no known length, enforce the maximum
+		// object size and the bucket hard quota while it is read.
+		reader = newMaxObjectSizeReader(reader)
+		quotaReader, err := newBucketQuotaHardReader(ctx, bucket, reader)
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		reader = quotaReader
+	}
+
 	if err := enforceBucketQuotaHard(ctx, bucket, size); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	if err := checkObjectNameCaseCollision(ctx, objectAPI, bucket, object); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
 	if r.Header.Get(xhttp.AmzBucketReplicationStatus) == replication.Replica.String() {
 		if s3Err = isPutActionAllowed(ctx, getRequestAuthType(r), bucket, object, r, iampolicy.ReplicateObjectAction); s3Err != ErrNone {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
@@ -1752,6 +1852,13 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Validate pre-conditions, if any, against the object being overwritten.
+	if r.Header.Get(xhttp.IfMatch) != "" || r.Header.Get(xhttp.IfNoneMatch) != "" {
+		opts.CheckPrecondFn = func(oi ObjectInfo) bool {
+			return checkPreconditionsPUT(oi, r)
+		}
+	}
+
 	if api.CacheAPI() != nil {
 		putObject = api.CacheAPI().PutObject
 	}
@@ -2055,6 +2162,10 @@ func (api objectAPIHandlers) PutObjectExtractHandler(w http.ResponseWriter, r *h
 	}
 
 	putObjectTar := func(reader io.Reader, info os.FileInfo, object string) error {
+		if err := checkObjectNameCaseCollision(ctx, objectAPI, bucket, object); err != nil {
+			return err
+		}
+
 		size := info.Size()
 		metadata := map[string]string{
 			xhttp.AmzStorageClass: sc,
@@ -2240,6 +2351,11 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if err := checkObjectNameCaseCollision(ctx, objectAPI, bucket, object); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
 	// Check if bucket encryption is enabled
 	sseConfig, _ := globalBucketSSEConfigSys.Get(bucket)
 	sseConfig.Apply(r.Header, sse.ApplyOptions{
@@ -2316,6 +2432,16 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
 	}
 
+	// Record when the upload is to be aborted if left incomplete.
+	var abortRuleID string
+	var abortDate time.Time
+	if lc, err := globalLifecycleSys.Get(bucket); err == nil {
+		abortRuleID, abortDate = lc.PredictAbortMultipartTime(object, UTCNow())
+		if !abortDate.IsZero() {
+			metadata[ReservedMetadataPrefixLower+AbortMultipartDate] = abortDate.Format(time.RFC3339)
+		}
+	}
+
 	opts, err := putOpts(ctx, r, bucket, object, metadata)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
@@ -2332,6 +2458,11 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if !abortDate.IsZero() {
+		w.Header()[xhttp.AmzAbortDate] = []string{abortDate.Format(http.TimeFormat)}
+		w.Header()[xhttp.AmzAbortRuleID] = []string{abortRuleID}
+	}
+
 	response := generateInitiateMultipartUploadResponse(bucket, object, uploadID)
 	encodedSuccessResponse := encodeResponse(response)
 
@@ -3171,8 +3302,22 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		return
 	}
 
+	// The max. XML contains 10000 parts (each well below 1024 bytes) + XML overhead
+	const maxBodySize = 2 * 10000 * 1024
+
+	// Read the whole body, the payload hash is only verified once the body is drained.
+	complMultipartUploadBytes, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	if len(complMultipartUploadBytes) > maxBodySize {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrEntityTooLarge), r.URL)
+		return
+	}
+
 	complMultipartUpload := &CompleteMultipartUpload{}
-	if err = xmlDecoder(r.Body, complMultipartUpload, r.ContentLength); err != nil {
+	if err = xmlDecoder(bytes.NewReader(complMultipartUploadBytes), complMultipartUpload, int64(len(complMultipartUploadBytes))); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
@@ -3184,6 +3329,12 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidPartOrder), r.URL)
 		return
 	}
+	// Parts are sorted, the last one has the highest part number.
+	if len(complMultipartUpload.Parts) > globalAPIConfig.getMultipartMaxParts() ||
+		isMaxPartID(complMultipartUpload.Parts[len(complMultipartUpload.Parts)-1].PartNumber) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidMaxParts), r.URL)
+		return
+	}
 
 	// Reject retention or governance headers if set, CompleteMultipartUpload spec
 	// does not use these headers, and should not be passed down to checkPutObjectLockAllowed
@@ -3197,6 +3348,11 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		return
 	}
 
+	if err = enforceBucketQuotaHardMultipart(ctx, objectAPI, bucket, object, uploadID, complMultipartUpload.Parts); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
 	completeMultiPartUpload := objectAPI.CompleteMultipartUpload
 	if api.CacheAPI() != nil {
 		completeMultiPartUpload = api.CacheAPI().CompleteMultipartUpload
@@ -3206,9 +3362,10 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 	writeErrorResponseWithoutXMLHeader := func(ctx context.Context, w http.ResponseWriter, err APIError, reqURL *url.URL) {
 		switch err.Code {
 		case "SlowDown", "XMinioServerNotInitialized", "XMinioReadQuorum", "XMinioWriteQuorum":
-			// Set retxry-after header to indicate user-agents to retry request after 120secs.
+			// Set retry-after header to indicate user-agents when to retry
+			// the request based on the current load.
 			// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Retry-After
-			w.Header().Set(xhttp.RetryAfter, "120")
+			setRetryAfter(w, computeRetryAfter())
 		}
 
 		// Generate error response.
@@ -3239,6 +3396,13 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		return
 	}
 
+	// Validate pre-conditions, if any, against the object being overwritten.
+	if r.Header.Get(xhttp.IfMatch) != "" || r.Header.Get(xhttp.IfNoneMatch) != "" {
+		opts.CheckPrecondFn = func(oi ObjectInfo) bool {
+			return checkPreconditionsPUT(oi, r)
+		}
+	}
+
 	// First, we compute the ETag of the multipart object.
 	// The ETag of a multi-part object is always:
 	//   ETag := MD5(ETag_p1, ETag_p2, ...)+"-N"   (N being the number of parts)
@@ -3394,6 +3558,15 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
+	// Deleting a specific version additionally requires s3:DeleteObjectVersion,
+	// replica deletes are authorized with s3:ReplicateDelete below instead.
+	if r.Form.Get(xhttp.VersionID) != "" && r.Header.Get(xhttp.AmzBucketReplicationStatus) != replication.Replica.String() {
+		if s3Error := checkRequestAuthType(ctx, r, policy.DeleteObjectVersionAction, bucket, object); s3Error != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+			return
+		}
+	}
+
 	if globalDNSConfig != nil {
 		_, err := globalDNSConfig.Get(bucket)
 		if err != nil && err != dns.ErrNotImplemented {
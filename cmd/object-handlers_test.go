@@ -23,24 +23,34 @@ import (
 	"crypto/md5"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"path"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/bucket/replication"
 	xhttp "github.com/minio/minio/internal/http"
 	ioutilx "github.com/minio/minio/internal/ioutil"
+	"github.com/minio/pkg/bucket/policy"
+	"github.com/minio/pkg/bucket/policy/condition"
 )
 
 // Type to capture different modifications to API request to simulate failure cases.
@@ -199,6 +209,167 @@ func testAPIHeadObjectHandler(obj ObjectLayer, instanceType, bucketName string,
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
 
+// Wrapper for calling the x-amz-expiration header tests for both Erasure multiple disks and FS single drive setup.
+func TestAPIObjectExpirationHeader(t *testing.T) {
+	ExecObjectLayerAPITest(t, testAPIObjectExpirationHeader, []string{"HeadObject", "GetObject"})
+}
+
+func testAPIObjectExpirationHeader(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T,
+) {
+	ctx := context.Background()
+	for _, objectName := range []string{"logs/object", "data/object"} {
+		data := []byte(objectName)
+		_, err := obj.PutObject(ctx, bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: Error uploading object: <ERROR> %v", instanceType, err)
+		}
+	}
+
+	lifecycleConfig := `<LifecycleConfiguration><Rule><ID>expire-logs</ID><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>2</Days></Expiration></Rule></LifecycleConfiguration>`
+	if _, err := globalBucketMetadataSys.Update(ctx, bucketName, bucketLifecycleConfig, []byte(lifecycleConfig)); err != nil {
+		t.Fatalf("%s: Error setting lifecycle configuration: <ERROR> %v", instanceType, err)
+	}
+
+	objInfo, err := obj.GetObjectInfo(ctx, bucketName, "logs/object", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: Error reading object info: <ERROR> %v", instanceType, err)
+	}
+	expectedExpiration := fmt.Sprintf(`expiry-date="%s", rule-id="expire-logs"`,
+		lifecycle.ExpectedExpiryTime(objInfo.ModTime, 2).Format(http.TimeFormat))
+
+	testCases := []struct {
+		method             string
+		objectName         string
+		expectedExpiration string
+	}{
+		// Objects matching the rule announce their expiry.
+		{http.MethodHead, "logs/object", expectedExpiration},
+		{http.MethodGet, "logs/object", expectedExpiration},
+		// Objects outside of the rule filter do not.
+		{http.MethodHead, "data/object", ""},
+		{http.MethodGet, "data/object", ""},
+	}
+
+	for i, testCase := range testCases {
+		req, err := newTestSignedRequestV4(testCase.method, getGetObjectURL("", bucketName, testCase.objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, http.StatusOK, rec.Code)
+		}
+		var got string
+		if values := rec.Header()[xhttp.AmzExpiration]; len(values) > 0 {
+			got = values[0]
+		}
+		if got != testCase.expectedExpiration {
+			t.Errorf("Test %d: %s: Expected %s header %q, got %q", i+1, instanceType, xhttp.AmzExpiration, testCase.expectedExpiration, got)
+		}
+	}
+}
+
+// Wrapper for calling the Content-Length tests for objects stored with a content encoding
+// for both Erasure multiple disks and FS single drive setup.
+func TestAPIContentLengthEncodedObject(t *testing.T) {
+	ExecObjectLayerAPITest(t, testAPIContentLengthEncodedObject, []string{"PutObject", "HeadObject", "GetObject"})
+}
+
+func testAPIContentLengthEncodedObject(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T,
+) {
+	data := generateBytesData(1 * humanize.MiByte)
+
+	testCases := []struct {
+		objectName              string
+		contentEncoding         string
+		expectedContentEncoding string
+	}{
+		{"gzip-object", "gzip", "gzip"},
+		{"chunked-gzip-object", "aws-chunked,gzip", "gzip"},
+		{"gzip-chunked-object", "gzip, aws-chunked", "gzip"},
+		{"multi-encoded-object", "gzip, br", "gzip, br"},
+	}
+
+	for i, testCase := range testCases {
+		req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, testCase.objectName),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey,
+			map[string]string{xhttp.ContentEncoding: testCase.contentEncoding})
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for PutObject: <ERROR> %v", i+1, instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, http.StatusOK, rec.Code)
+		}
+
+		req, err = newTestSignedRequestV4(http.MethodHead, getHeadObjectURL("", bucketName, testCase.objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for HeadObject: <ERROR> %v", i+1, instanceType, err)
+		}
+		rec = httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, http.StatusOK, rec.Code)
+		}
+		if got := rec.Header().Get(xhttp.ContentEncoding); got != testCase.expectedContentEncoding {
+			t.Errorf("Test %d: %s: Expected Content-Encoding %q, got %q", i+1, instanceType, testCase.expectedContentEncoding, got)
+		}
+		headLength := rec.Header().Get(xhttp.ContentLength)
+		if headLength != strconv.Itoa(len(data)) {
+			t.Errorf("Test %d: %s: Expected HEAD Content-Length %d, got %s", i+1, instanceType, len(data), headLength)
+		}
+
+		// A full GET must deliver exactly the bytes announced by HEAD.
+		req, err = newTestSignedRequestV4(http.MethodGet, getGetObjectURL("", bucketName, testCase.objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey, map[string]string{"Accept-Encoding": "gzip"})
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for GetObject: <ERROR> %v", i+1, instanceType, err)
+		}
+		rec = httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, http.StatusOK, rec.Code)
+		}
+		if got := rec.Header().Get(xhttp.ContentLength); got != headLength {
+			t.Errorf("Test %d: %s: Expected GET Content-Length %s, got %s", i+1, instanceType, headLength, got)
+		}
+		if strconv.Itoa(rec.Body.Len()) != headLength {
+			t.Errorf("Test %d: %s: Expected %s bytes from GET, got %d", i+1, instanceType, headLength, rec.Body.Len())
+		}
+		if !bytes.Equal(rec.Body.Bytes(), data) {
+			t.Errorf("Test %d: %s: Object content differs from the uploaded bytes", i+1, instanceType)
+		}
+
+		// Ranges are relative to the stored bytes.
+		req, err = newTestSignedRequestV4(http.MethodGet, getGetObjectURL("", bucketName, testCase.objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey, map[string]string{xhttp.Range: "bytes=10-99"})
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for GetObject: <ERROR> %v", i+1, instanceType, err)
+		}
+		rec = httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusPartialContent {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, http.StatusPartialContent, rec.Code)
+		}
+		if got := rec.Header().Get(xhttp.ContentLength); got != "90" {
+			t.Errorf("Test %d: %s: Expected range Content-Length 90, got %s", i+1, instanceType, got)
+		}
+		expectedRange := fmt.Sprintf("bytes 10-99/%d", len(data))
+		if got := rec.Header().Get(xhttp.ContentRange); got != expectedRange {
+			t.Errorf("Test %d: %s: Expected Content-Range %q, got %q", i+1, instanceType, expectedRange, got)
+		}
+		if !bytes.Equal(rec.Body.Bytes(), data[10:100]) {
+			t.Errorf("Test %d: %s: Range content differs from the uploaded bytes", i+1, instanceType)
+		}
+	}
+}
+
 func TestAPIHeadObjectHandlerWithEncryption(t *testing.T) {
 	globalPolicySys = NewPolicySys()
 	defer func() { globalPolicySys = nil }()
@@ -632,6 +803,216 @@ func testAPIGetObjectHandler(obj ObjectLayer, instanceType, bucketName string, a
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
 
+// Wrapper for calling GetObject API handler tests with multiple ranges.
+func TestAPIGetObjectMultiRangeHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIGetObjectMultiRangeHandler, []string{"GetObject"})
+}
+
+func testAPIGetObjectMultiRangeHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T,
+) {
+	objectName := "test-object"
+	data := generateBytesData(1 * humanize.KiByte)
+	_, err := obj.PutObject(context.Background(), bucketName, objectName,
+		mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: Error uploading object: <ERROR> %v", instanceType, err)
+	}
+
+	type byteRange struct {
+		contentRange string
+		content      []byte
+	}
+	testCases := []struct {
+		byteRange          string
+		expectedRespStatus int
+		expectedRanges     []byteRange
+	}{
+		// Test case - 1.
+		// Two disjoint ranges.
+		{
+			byteRange:          "bytes=0-99,200-299",
+			expectedRespStatus: http.StatusPartialContent,
+			expectedRanges: []byteRange{
+				{"bytes 0-99/1024", data[0:100]},
+				{"bytes 200-299/1024", data[200:300]},
+			},
+		},
+		// Test case - 2.
+		// Overlapping and adjacent ranges are merged.
+		{
+			byteRange:          "bytes=0-99, 50-149,150-199,300-399",
+			expectedRespStatus: http.StatusPartialContent,
+			expectedRanges: []byteRange{
+				{"bytes 0-199/1024", data[0:200]},
+				{"bytes 300-399/1024", data[300:400]},
+			},
+		},
+		// Test case - 3.
+		// Descending ranges are served in ascending order.
+		{
+			byteRange:          "bytes=-24,500-599,0-9",
+			expectedRespStatus: http.StatusPartialContent,
+			expectedRanges: []byteRange{
+				{"bytes 0-9/1024", data[0:10]},
+				{"bytes 500-599/1024", data[500:600]},
+				{"bytes 1000-1023/1024", data[1000:]},
+			},
+		},
+		// Test case - 4.
+		// Out of bounds ranges are skipped, ranges ending after the object are truncated.
+		{
+			byteRange:          "bytes=2000-2099,1000-2000",
+			expectedRespStatus: http.StatusPartialContent,
+			expectedRanges: []byteRange{
+				{"bytes 1000-1023/1024", data[1000:]},
+			},
+		},
+		// Test case - 5.
+		// No range overlaps with the object.
+		{
+			byteRange:          "bytes=1024-1100,2000-",
+			expectedRespStatus: http.StatusRequestedRangeNotSatisfiable,
+		},
+		// Test case - 6.
+		// Invalid range in the list.
+		{
+			byteRange:          "bytes=0-99,20-10",
+			expectedRespStatus: http.StatusRequestedRangeNotSatisfiable,
+		},
+		// Test case - 7.
+		// Ranges adding up to more than the object serve the whole object.
+		{
+			byteRange:          "bytes=0-,0-,0-",
+			expectedRespStatus: http.StatusOK,
+		},
+		// Test case - 8.
+		// Too many ranges serve the whole object.
+		{
+			byteRange:          "bytes=0-0" + strings.Repeat(",0-0", maxRangeSpecs),
+			expectedRespStatus: http.StatusOK,
+		},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodGet, getGetObjectURL("", bucketName, objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to create HTTP request for Get Object: <ERROR> %v", i+1, err)
+		}
+		req.Header.Set(xhttp.Range, testCase.byteRange)
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		if rec.Code == http.StatusOK && !bytes.Equal(rec.Body.Bytes(), data) {
+			t.Errorf("Test %d: %s: Object content differs from expected value", i+1, instanceType)
+		}
+		if rec.Code != http.StatusPartialContent {
+			continue
+		}
+
+		if contentLength := strconv.Itoa(rec.Body.Len()); rec.Header().Get(xhttp.ContentLength) != contentLength {
+			t.Errorf("Test %d: %s: Expected Content-Length %s, got %s", i+1, instanceType, contentLength, rec.Header().Get(xhttp.ContentLength))
+		}
+		mediaType, params, err := mime.ParseMediaType(rec.Header().Get(xhttp.ContentType))
+		if err != nil || mediaType != "multipart/byteranges" {
+			t.Fatalf("Test %d: %s: Unexpected Content-Type %s", i+1, instanceType, rec.Header().Get(xhttp.ContentType))
+		}
+		mr := multipart.NewReader(rec.Body, params["boundary"])
+		for j, expectedRange := range testCase.expectedRanges {
+			part, err := mr.NextPart()
+			if err != nil {
+				t.Fatalf("Test %d: %s: Part %d: Failed to read part: <ERROR> %v", i+1, instanceType, j+1, err)
+			}
+			if contentRange := part.Header.Get(xhttp.ContentRange); contentRange != expectedRange.contentRange {
+				t.Errorf("Test %d: %s: Part %d: Expected Content-Range %s, got %s", i+1, instanceType, j+1, expectedRange.contentRange, contentRange)
+			}
+			content, err := ioutil.ReadAll(part)
+			if err != nil {
+				t.Fatalf("Test %d: %s: Part %d: Failed to read part: <ERROR> %v", i+1, instanceType, j+1, err)
+			}
+			if !bytes.Equal(content, expectedRange.content) {
+				t.Errorf("Test %d: %s: Part %d: Object content differs from expected value", i+1, instanceType, j+1)
+			}
+		}
+		if _, err = mr.NextPart(); err != io.EOF {
+			t.Errorf("Test %d: %s: Expected no more parts, got %v", i+1, instanceType, err)
+		}
+	}
+}
+
+// TestAPIGetObjectResponseOverridesHandler - Tests that the response-*
+// query parameters of presigned GET requests override the stored headers.
+func TestAPIGetObjectResponseOverridesHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIGetObjectResponseOverridesHandler, []string{"GetObject"})
+}
+
+func testAPIGetObjectResponseOverridesHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T,
+) {
+	objectName := "test-object"
+	data := []byte("hello, world")
+	_, err := obj.PutObject(context.Background(), bucketName, objectName,
+		mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""),
+		ObjectOptions{UserDefined: map[string]string{"content-type": "application/octet-stream"}})
+	if err != nil {
+		t.Fatalf("%s: Error uploading object: <ERROR> %v", instanceType, err)
+	}
+
+	overrides := url.Values{}
+	overrides.Set("response-content-disposition", "attachment")
+	overrides.Set("response-content-type", "text/plain")
+	overrides.Set("response-cache-control", "no-cache")
+	expectedHeaders := map[string]string{
+		xhttp.ContentDisposition: "attachment",
+		xhttp.ContentType:        "text/plain",
+		xhttp.CacheControl:       "no-cache",
+	}
+
+	for i, preSign := range []func(*http.Request, string, string, int64) error{preSignV4, preSignV2} {
+		req, err := newTestRequest(http.MethodGet, getGetObjectURL("", bucketName, objectName)+"?"+overrides.Encode(), 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for GetObject: <ERROR> %v", i+1, instanceType, err)
+		}
+		req.Header = http.Header{}
+		if err = preSign(req, credentials.AccessKey, credentials.SecretKey, int64(10*60*60)); err != nil {
+			t.Fatalf("Test %d: %s: Failed to presign request: <ERROR> %v", i+1, instanceType, err)
+		}
+
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, http.StatusOK, rec.Code)
+		}
+		for header, value := range expectedHeaders {
+			if got := rec.Header().Get(header); got != value {
+				t.Errorf("Test %d: %s: Expected %s to be `%s`, but instead found `%s`", i+1, instanceType, header, value, got)
+			}
+		}
+		if !bytes.Equal(rec.Body.Bytes(), data) {
+			t.Errorf("Test %d: %s: Object content differs from expected value", i+1, instanceType)
+		}
+
+		// The overrides are part of the signed query, changing them
+		// after signing must invalidate the signature.
+		query := req.URL.Query()
+		query.Set("response-content-disposition", "inline")
+		req.URL.RawQuery = query.Encode()
+		req.RequestURI = req.URL.RequestURI()
+		req.Form = nil
+
+		rec = httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, http.StatusForbidden, rec.Code)
+		}
+	}
+}
+
 // Wrapper for calling GetObject API handler tests for both Erasure multiple disks and FS single drive setup.
 func TestAPIGetObjectWithMPHandler(t *testing.T) {
 	globalPolicySys = NewPolicySys()
@@ -957,6 +1338,51 @@ func testAPIGetObjectWithPartNumberHandler(obj ObjectLayer, instanceType, bucket
 			mkGetReqWithPartNumber(idx, oi, partNum)
 		}
 	}
+
+	// Fetch part 2 of the 3-part object "mp-2" and check the part headers,
+	// then ask for a part number beyond the part count.
+	mp2 := objectInputs[7]
+	for _, testCase := range []struct {
+		partNumber   int
+		expectedCode int
+		contentRange string
+	}{
+		{2, http.StatusPartialContent, "bytes 5487701-10975499/10975503"},
+		{4, http.StatusRequestedRangeNotSatisfiable, ""},
+	} {
+		queries := url.Values{}
+		queries.Add("partNumber", strconv.Itoa(testCase.partNumber))
+		req, err := newTestSignedRequestV4(http.MethodGet, makeTestTargetURL("", bucketName, mp2.objectName, queries),
+			0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for Get Object: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedCode {
+			t.Fatalf("%s: PartNumber %d: expected the response status to be `%d`, but instead found `%d`",
+				instanceType, testCase.partNumber, testCase.expectedCode, rec.Code)
+		}
+		if testCase.expectedCode != http.StatusPartialContent {
+			errResp := APIErrorResponse{}
+			if err = xml.Unmarshal(rec.Body.Bytes(), &errResp); err != nil {
+				t.Fatalf("%s: Failed to unmarshal error response: <ERROR> %v", instanceType, err)
+			}
+			if errResp.Code != "InvalidPartNumber" {
+				t.Fatalf("%s: PartNumber %d: expected InvalidPartNumber, got %s", instanceType, testCase.partNumber, errResp.Code)
+			}
+			continue
+		}
+		if got := rec.Header().Get(xhttp.ContentRange); got != testCase.contentRange {
+			t.Fatalf("%s: PartNumber %d: expected Content-Range %q, got %q", instanceType, testCase.partNumber, testCase.contentRange, got)
+		}
+		if got := rec.Header()[xhttp.AmzMpPartsCount]; len(got) != 1 || got[0] != "3" {
+			t.Fatalf("%s: PartNumber %d: expected %s to be 3, got %v", instanceType, testCase.partNumber, xhttp.AmzMpPartsCount, got)
+		}
+		if rec.Body.Len() != int(mp2.partLengths[1]) {
+			t.Fatalf("%s: PartNumber %d: expected %d bytes, got %d", instanceType, testCase.partNumber, mp2.partLengths[1], rec.Body.Len())
+		}
+	}
 }
 
 // Wrapper for calling PutObject API handler tests using streaming signature v4 for both Erasure multiple disks and FS single drive setup.
@@ -1524,14 +1950,259 @@ func testAPIPutObjectHandler(obj ObjectLayer, instanceType, bucketName string, a
 	nilBucket := "dummy-bucket"
 	nilObject := "dummy-object"
 
-	nilReq, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", nilBucket, nilObject),
-		0, nil, "", "", nil)
-	if err != nil {
-		t.Errorf("MinIO %s: Failed to create HTTP request for testing the response when object Layer is set to `nil`.", instanceType)
+	nilReq, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", nilBucket, nilObject),
+		0, nil, "", "", nil)
+	if err != nil {
+		t.Errorf("MinIO %s: Failed to create HTTP request for testing the response when object Layer is set to `nil`.", instanceType)
+	}
+	// execute the object layer set to `nil` test.
+	// `ExecObjectLayerAPINilTest` manages the operation.
+	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
+}
+
+// Wrapper for calling the object name case collision tests for both Erasure multiple disks and single node setup.
+func TestAPIPutObjectCaseCollisionHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIPutObjectCaseCollisionHandler, []string{"CopyObject", "PutObject", "NewMultipart"})
+}
+
+func testAPIPutObjectCaseCollisionHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T,
+) {
+	defer func(reject bool) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.rejectCaseCollisions = reject
+		globalAPIConfig.mu.Unlock()
+	}(globalAPIConfig.shouldRejectCaseCollisions())
+
+	existing := "dir/Foo.txt"
+	data := []byte("hello")
+	for _, object := range []string{existing, "dir/Sub/object"} {
+		_, err := obj.PutObject(context.Background(), bucketName, object,
+			mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("MinIO %s: %s", instanceType, err)
+		}
+	}
+
+	testCases := []struct {
+		method         string
+		url            string
+		headers        map[string]string
+		reject         bool
+		expectedStatus int
+	}{
+		// Test case - 1.
+		// Differently cased name is rejected.
+		{http.MethodPut, getPutObjectURL("", bucketName, "dir/FOO.txt"), nil, true, http.StatusBadRequest},
+		// Test case - 2.
+		// Overwriting the same name is allowed.
+		{http.MethodPut, getPutObjectURL("", bucketName, existing), nil, true, http.StatusOK},
+		// Test case - 3.
+		// Other names are not affected.
+		{http.MethodPut, getPutObjectURL("", bucketName, "dir/bar.txt"), nil, true, http.StatusOK},
+		// Test case - 4.
+		// Copying to a differently cased name is rejected.
+		{http.MethodPut, getCopyObjectURL("", bucketName, "dir/fOO.txt"),
+			map[string]string{"X-Amz-Copy-Source": url.QueryEscape(pathJoin(bucketName, existing))}, true, http.StatusBadRequest},
+		// Test case - 5.
+		// Multipart uploads to a differently cased name are rejected.
+		{http.MethodPost, getNewMultipartURL("", bucketName, "dir/FOO.TXT"), nil, true, http.StatusBadRequest},
+		// Test case - 6.
+		// Differently cased name of an existing prefix is rejected.
+		{http.MethodPut, getPutObjectURL("", bucketName, "dir/SUB/"), nil, true, http.StatusBadRequest},
+		// Test case - 7.
+		// Differently cased name is accepted by default.
+		{http.MethodPut, getPutObjectURL("", bucketName, "dir/foo.txt"), nil, false, http.StatusOK},
+	}
+
+	for i, testCase := range testCases {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.rejectCaseCollisions = testCase.reject
+		globalAPIConfig.mu.Unlock()
+
+		var body io.ReadSeeker
+		var length int64
+		if testCase.method == http.MethodPut && testCase.headers == nil {
+			body, length = bytes.NewReader(data), int64(len(data))
+		}
+		req, err := newTestSignedRequestV4(testCase.method, testCase.url, length, body, credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("MinIO %s: Test %d: Failed to create HTTP request: <ERROR> %v", instanceType, i+1, err)
+		}
+		for k, v := range testCase.headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedStatus {
+			t.Errorf("MinIO %s: Test %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedStatus, rec.Code)
+			continue
+		}
+		if rec.Code == http.StatusBadRequest {
+			var errXML APIErrorResponse
+			if err = xml.Unmarshal(rec.Body.Bytes(), &errXML); err != nil {
+				t.Fatalf("MinIO %s: Test %d: %v", instanceType, i+1, err)
+			}
+			if errXML.Code != "InvalidRequest" {
+				t.Errorf("MinIO %s: Test %d: Expected InvalidRequest, got %s", instanceType, i+1, errXML.Code)
+			}
+		}
+	}
+
+	// The rejected names must not have been written.
+	if _, err := obj.GetObjectInfo(context.Background(), bucketName, "dir/FOO.txt", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Errorf("MinIO %s: Expected the rejected object to not exist, got %v", instanceType, err)
+	}
+
+	// HTTP request for testing when `objectLayer` is set to `nil`.
+	nilBucket := "dummy-bucket"
+	nilObject := "dummy-object"
+	nilReq, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", nilBucket, nilObject),
+		0, nil, "", "", nil)
+	if err != nil {
+		t.Errorf("MinIO %s: Failed to create HTTP request for testing the response when object Layer is set to `nil`.", instanceType)
+	}
+	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
+}
+
+// Wrapper for calling PutObject API handler tests with chunked bodies of unknown length.
+func TestAPIPutObjectChunkedHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIPutObjectChunkedHandler, []string{"PutObject"})
+}
+
+func testAPIPutObjectChunkedHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T,
+) {
+	defer func(objectMaxSize int64) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.objectMaxSize = objectMaxSize
+		globalAPIConfig.mu.Unlock()
+	}(globalAPIConfig.objectMaxSize)
+
+	objectName := "test-object"
+	data := generateBytesData(6 * humanize.KiByte)
+
+	// Set a hard quota on the bucket and serve its usage from memory.
+	quota, err := json.Marshal(madmin.BucketQuota{Quota: 8 * humanize.KiByte, Type: madmin.HardQuota})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = globalBucketMetadataSys.Update(context.Background(), bucketName, bucketQuotaConfigFile, quota); err != nil {
+		t.Fatalf("%s: %s", instanceType, err)
+	}
+	var usage uint64
+	sys := NewBucketQuotaSys()
+	sys.bucketStorageCache.Once.Do(func() {
+		sys.bucketStorageCache.TTL = time.Nanosecond
+		sys.bucketStorageCache.Update = func() (interface{}, error) {
+			return DataUsageInfo{BucketsUsage: map[string]BucketUsageInfo{bucketName: {Size: usage}}}, nil
+		}
+	})
+	defer func(sys *BucketQuotaSys) { globalBucketQuotaSys = sys }(globalBucketQuotaSys)
+	globalBucketQuotaSys = sys
+
+	testCases := []struct {
+		headers map[string]string
+		chunked bool
+		// maximum object size, if set.
+		objectMaxSize int64
+		// bucket usage against the hard quota.
+		usage uint64
+		// expected Response.
+		expectedRespStatus int
+		expectedErrCode    string
+	}{
+		// Test case - 1.
+		// Chunked body verified against the signed payload hash.
+		{
+			chunked:            true,
+			expectedRespStatus: http.StatusOK,
+		},
+		// Test case - 2.
+		// Chunked body with an unsigned payload.
+		{
+			headers:            map[string]string{xhttp.AmzContentSha256: unsignedPayload},
+			chunked:            true,
+			expectedRespStatus: http.StatusOK,
+		},
+		// Test case - 3.
+		// Unknown length without chunked transfer encoding.
+		{
+			chunked:            false,
+			expectedRespStatus: http.StatusLengthRequired,
+			expectedErrCode:    "MissingContentLength",
+		},
+		// Test case - 4.
+		// Chunked body larger than the maximum object size.
+		{
+			headers:            map[string]string{xhttp.AmzContentSha256: unsignedPayload},
+			chunked:            true,
+			objectMaxSize:      4 * humanize.KiByte,
+			expectedRespStatus: http.StatusBadRequest,
+			expectedErrCode:    "EntityTooLarge",
+		},
+		// Test case - 5.
+		// Chunked body exceeding the space left within the bucket quota.
+		{
+			headers:            map[string]string{xhttp.AmzContentSha256: unsignedPayload},
+			chunked:            true,
+			usage:              4 * humanize.KiByte,
+			expectedRespStatus: http.StatusBadRequest,
+			expectedErrCode:    "XMinioAdminBucketQuotaExceeded",
+		},
+		// Test case - 6.
+		// Chunked body sent to a bucket already over its quota.
+		{
+			headers:            map[string]string{xhttp.AmzContentSha256: unsignedPayload},
+			chunked:            true,
+			usage:              10 * humanize.KiByte,
+			expectedRespStatus: http.StatusBadRequest,
+			expectedErrCode:    "XMinioAdminBucketQuotaExceeded",
+		},
+	}
+
+	for i, testCase := range testCases {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.objectMaxSize = testCase.objectMaxSize
+		globalAPIConfig.mu.Unlock()
+		usage = testCase.usage
+
+		req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, objectName),
+			-1, bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey, testCase.headers)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for PutObject: <ERROR> %v", i+1, instanceType, err)
+		}
+		if testCase.chunked {
+			req.TransferEncoding = []string{"chunked"}
+		}
+
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`: %s",
+				i+1, instanceType, testCase.expectedRespStatus, rec.Code, rec.Body.String())
+		}
+		if testCase.expectedErrCode != "" {
+			errorResponse := APIErrorResponse{}
+			if err = xml.Unmarshal(rec.Body.Bytes(), &errorResponse); err != nil {
+				t.Fatalf("Test %d: %s: Unable to unmarshal response body %s", i+1, instanceType, rec.Body.String())
+			}
+			if errorResponse.Code != testCase.expectedErrCode {
+				t.Errorf("Test %d: %s: Expected the error code to be `%s`, but instead found `%s`", i+1, instanceType, testCase.expectedErrCode, errorResponse.Code)
+			}
+			continue
+		}
+
+		var buffer bytes.Buffer
+		if err = GetObject(context.Background(), obj, bucketName, objectName, 0, int64(len(data)), &buffer, "", ObjectOptions{}); err != nil {
+			t.Fatalf("Test %d: %s: Failed to fetch the uploaded object: <ERROR> %v", i+1, instanceType, err)
+		}
+		if !bytes.Equal(buffer.Bytes(), data) {
+			t.Errorf("Test %d: %s: Object content differs from expected value", i+1, instanceType)
+		}
 	}
-	// execute the object layer set to `nil` test.
-	// `ExecObjectLayerAPINilTest` manages the operation.
-	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
 
 // Tests sanity of attempting to copying each parts at offsets from an existing
@@ -2443,6 +3114,270 @@ func testAPICopyObjectHandler(obj ObjectLayer, instanceType, bucketName string,
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
 
+// Wrapper for calling CopyObject HTTP handler tests across buckets.
+func TestAPICopyObjectCrossBucketHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPICopyObjectCrossBucketHandler, []string{"CopyObject", "PutBucketPolicy"})
+}
+
+func testAPICopyObjectCrossBucketHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T,
+) {
+	ctx := context.Background()
+	srcBucketName := getRandomBucketName()
+	if err := obj.MakeBucketWithLocation(ctx, srcBucketName, BucketOptions{}); err != nil {
+		t.Fatalf("%s: Failed to create bucket: <ERROR> %v", instanceType, err)
+	}
+	objectName := "test-object"
+	data := []byte("hello world")
+	objInfo, err := obj.PutObject(ctx, srcBucketName, objectName,
+		mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: Error uploading object: <ERROR> %v", instanceType, err)
+	}
+
+	putBucketPolicy := func(bucket string, action policy.Action) {
+		bucketPolicy := &policy.Policy{
+			Version: policy.DefaultVersion,
+			Statements: []policy.Statement{
+				policy.NewStatement(
+					"",
+					policy.Allow,
+					policy.NewPrincipal("*"),
+					policy.NewActionSet(action),
+					policy.NewResourceSet(policy.NewResource(bucket, "*")),
+					condition.NewFunctions(),
+				),
+			},
+		}
+		bucketPolicyBuf, err := json.Marshal(bucketPolicy)
+		if err != nil {
+			t.Fatalf("%s: Failed to marshal bucket policy: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getPutPolicyURL("", bucket),
+			int64(len(bucketPolicyBuf)), bytes.NewReader(bucketPolicyBuf), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for PutBucketPolicyHandler: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+		}
+	}
+	anonCopy := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		anonReq, err := newTestRequest(http.MethodPut, getCopyObjectURL("", bucketName, objectName), 0, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create an anonymous request: <ERROR> %v", instanceType, err)
+		}
+		anonReq.Header.Set(xhttp.AmzCopySource, url.QueryEscape(SlashSeparator+srcBucketName+SlashSeparator+objectName))
+		apiRouter.ServeHTTP(rec, anonReq)
+		return rec
+	}
+
+	// s3:PutObject on the destination is not enough.
+	putBucketPolicy(bucketName, policy.PutObjectAction)
+	rec := anonCopy()
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusForbidden, rec.Code)
+	}
+	apiErr := APIErrorResponse{}
+	if err = xml.Unmarshal(rec.Body.Bytes(), &apiErr); err != nil {
+		t.Fatalf("%s: Failed to parse error response: <ERROR> %v", instanceType, err)
+	}
+	if apiErr.Code != getAPIError(ErrAccessDenied).Code {
+		t.Errorf("%s: Expected error %s, got %s", instanceType, getAPIError(ErrAccessDenied).Code, apiErr.Code)
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucketName, objectName, ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("%s: Expected the object not to be copied, got <ERROR> %v", instanceType, err)
+	}
+
+	// s3:GetObject on the source allows the copy.
+	putBucketPolicy(srcBucketName, policy.GetObjectAction)
+	rec = anonCopy()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	copyResp := CopyObjectResponse{}
+	if err = xml.Unmarshal(rec.Body.Bytes(), &copyResp); err != nil {
+		t.Fatalf("%s: Failed to parse copy response: <ERROR> %v", instanceType, err)
+	}
+	if copyResp.ETag != "\""+objInfo.ETag+"\"" {
+		t.Errorf("%s: Expected ETag %s, got %s", instanceType, objInfo.ETag, copyResp.ETag)
+	}
+	if copyResp.LastModified == "" {
+		t.Errorf("%s: Expected LastModified to be set", instanceType)
+	}
+}
+
+// Wrapper for calling the multipart limits tests for both Erasure multiple disks and single node setup.
+func TestAPIMultipartLimits(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIMultipartLimits, []string{"NewMultipart", "PutObjectPart", "CompleteMultipart"})
+}
+
+func testAPIMultipartLimits(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T,
+) {
+	setLimits := func(maxParts int, minPartSize, maxPartSize int64) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.multipartMaxParts = maxParts
+		globalAPIConfig.multipartMinPartSize = minPartSize
+		globalAPIConfig.multipartMaxPartSize = maxPartSize
+		globalAPIConfig.mu.Unlock()
+	}
+	defer setLimits(globalAPIConfig.multipartMaxParts, globalAPIConfig.multipartMinPartSize, globalAPIConfig.multipartMaxPartSize)
+	setLimits(2, 512, humanize.KiByte)
+
+	objectName := "test-object-multipart-limits"
+	uploadID, err := obj.NewMultipartUpload(context.Background(), bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: Failed to create a new multipart upload: <ERROR> %v", instanceType, err)
+	}
+
+	expectError := func(rec *httptest.ResponseRecorder, code APIErrorCode, test string) {
+		apiErr := errorCodes.ToAPIErr(code)
+		if rec.Code != apiErr.HTTPStatusCode {
+			t.Fatalf("%s: %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, test, apiErr.HTTPStatusCode, rec.Code)
+		}
+		errResponse := &APIErrorResponse{}
+		if err := xml.NewDecoder(rec.Body).Decode(errResponse); err != nil {
+			t.Fatalf("%s: %s: Error decoding the recorded response Body", instanceType, test)
+		}
+		if errResponse.Code != apiErr.Code {
+			t.Fatalf("%s: %s: Expected error code %s, got %s", instanceType, test, apiErr.Code, errResponse.Code)
+		}
+	}
+
+	putPart := func(partNumber int, size int) *httptest.ResponseRecorder {
+		data := bytes.Repeat([]byte("a"), size)
+		req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectPartURL("", bucketName, objectName, uploadID, strconv.Itoa(partNumber)),
+			int64(size), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for PutObjectPart: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	completeUpload := func(parts []CompletePart) *httptest.ResponseRecorder {
+		completeBytes, err := xml.Marshal(&CompleteMultipartUpload{Parts: parts})
+		if err != nil {
+			t.Fatalf("%s: Error XML encoding of parts: <ERROR> %s", instanceType, err)
+		}
+		req, err := newTestSignedRequestV4(http.MethodPost, getCompleteMultipartUploadURL("", bucketName, objectName, uploadID),
+			int64(len(completeBytes)), bytes.NewReader(completeBytes), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for CompleteMultipartUpload: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Part numbers beyond the maximum number of parts are rejected.
+	expectError(putPart(3, 512), ErrInvalidMaxParts, "part number beyond max parts")
+
+	// Parts larger than the maximum part size are rejected.
+	expectError(putPart(1, humanize.KiByte+1), ErrEntityTooLarge, "part larger than max part size")
+
+	var parts []CompletePart
+	for partNumber, size := range map[int]int{1: 100, 2: 100} {
+		rec := putPart(partNumber, size)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+		}
+		parts = append(parts, CompletePart{PartNumber: partNumber, ETag: strings.Trim(rec.Header()[xhttp.ETag][0], "\"")})
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+
+	// Parts but the last one smaller than the minimum part size are rejected.
+	expectError(completeUpload(parts), ErrEntityTooSmall, "part smaller than min part size")
+
+	// Uploads with more parts than the maximum number of parts are rejected.
+	setLimits(1, 1, humanize.KiByte)
+	expectError(completeUpload(parts), ErrInvalidMaxParts, "more parts than max parts")
+
+	setLimits(2, 1, humanize.KiByte)
+	if rec := completeUpload(parts); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+}
+
+// Wrapper for calling the NewMultipartUpload abort date tests for both Erasure multiple disks and single node setup.
+func TestAPINewMultipartHandlerAbortDate(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPINewMultipartHandlerAbortDate, []string{"NewMultipart"})
+}
+
+func testAPINewMultipartHandlerAbortDate(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T,
+) {
+	lifecycleConfig := `<LifecycleConfiguration><Rule><ID>abort-uploads</ID><Filter><Prefix>uploads/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>3</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`
+	if _, err := globalBucketMetadataSys.Update(context.Background(), bucketName, bucketLifecycleConfig, []byte(lifecycleConfig)); err != nil {
+		t.Fatalf("%s: Error setting lifecycle configuration: <ERROR> %v", instanceType, err)
+	}
+
+	testCases := []struct {
+		objectName    string
+		expectedRule  string
+		expectedAbort bool
+	}{
+		{"uploads/object", "abort-uploads", true},
+		{"other/object", "", false},
+	}
+
+	for i, testCase := range testCases {
+		req, err := newTestSignedRequestV4(http.MethodPost, getNewMultipartURL("", bucketName, testCase.objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for NewMultipart Request: <ERROR> %v", i+1, instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		initiated := UTCNow()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, http.StatusOK, rec.Code)
+		}
+
+		multipartResponse := &InitiateMultipartUploadResponse{}
+		if err = xml.NewDecoder(rec.Body).Decode(multipartResponse); err != nil {
+			t.Fatalf("Test %d: %s: Error decoding the recorded response Body", i+1, instanceType)
+		}
+		mi, err := obj.GetMultipartInfo(context.Background(), bucketName, testCase.objectName, multipartResponse.UploadID, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Test %d: %s: Invalid UploadID: <ERROR> %s", i+1, instanceType, err)
+		}
+
+		var abortDate, abortRuleID string
+		if v := rec.Header()[xhttp.AmzAbortDate]; len(v) > 0 {
+			abortDate = v[0]
+		}
+		if v := rec.Header()[xhttp.AmzAbortRuleID]; len(v) > 0 {
+			abortRuleID = v[0]
+		}
+		if abortRuleID != testCase.expectedRule {
+			t.Errorf("Test %d: %s: Expected %s %q, got %q", i+1, instanceType, xhttp.AmzAbortRuleID, testCase.expectedRule, abortRuleID)
+		}
+		_, stored := mi.UserDefined[ReservedMetadataPrefixLower+AbortMultipartDate]
+		if !testCase.expectedAbort {
+			if abortDate != "" || stored {
+				t.Errorf("Test %d: %s: Expected no abort date, got %q", i+1, instanceType, abortDate)
+			}
+			continue
+		}
+		expectedDate := lifecycle.ExpectedExpiryTime(initiated, 3).Format(http.TimeFormat)
+		if abortDate != expectedDate {
+			t.Errorf("Test %d: %s: Expected %s %q, got %q", i+1, instanceType, xhttp.AmzAbortDate, expectedDate, abortDate)
+		}
+		if !stored {
+			t.Errorf("Test %d: %s: Expected the abort date to be stored with the upload", i+1, instanceType)
+		}
+	}
+}
+
 // Wrapper for calling NewMultipartUpload tests for both Erasure multiple disks and single node setup.
 // First register the HTTP handler for NewMutlipartUpload, then a HTTP request for NewMultipart upload is made.
 // The UploadID from the response body is parsed and its existence is asserted with an attempt to ListParts using it.
@@ -2977,6 +3912,68 @@ func testAPICompleteMultipartHandler(obj ObjectLayer, instanceType, bucketName s
 		}
 	}
 
+	// Testing for a request body which differs from the signed payload.
+	uploadID, err = obj.NewMultipartUpload(context.Background(), bucketName, objectName, opts)
+	if err != nil {
+		t.Fatalf("MinIO %s : <ERROR>  %s", instanceType, err)
+	}
+	for partID, data := range []string{string(validPart), string(validPart), "abcd"} {
+		_, err = obj.PutObjectPart(context.Background(), bucketName, objectName, uploadID, partID+1,
+			mustGetPutObjReader(t, strings.NewReader(data), int64(len(data)), getMD5Hash([]byte(data)), ""), opts)
+		if err != nil {
+			t.Fatalf("%s : %s", instanceType, err)
+		}
+	}
+	signedBytes, err := xml.Marshal(&CompleteMultipartUpload{Parts: []CompletePart{
+		{ETag: validPartMD5, PartNumber: 1},
+		{ETag: validPartMD5, PartNumber: 2},
+	}})
+	if err != nil {
+		t.Fatalf("Error XML encoding of parts: <ERROR> %s.", err)
+	}
+	tamperedBytes, err := xml.Marshal(&CompleteMultipartUpload{Parts: []CompletePart{
+		{ETag: validPartMD5, PartNumber: 2},
+		{ETag: getMD5Hash([]byte("abcd")), PartNumber: 3},
+	}})
+	if err != nil {
+		t.Fatalf("Error XML encoding of parts: <ERROR> %s.", err)
+	}
+	req, err := newTestSignedRequestV4(http.MethodPost, getCompleteMultipartUploadURL("", bucketName, objectName, uploadID),
+		int64(len(signedBytes)), bytes.NewReader(signedBytes), credentials.AccessKey, credentials.SecretKey, nil)
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request for CompleteMultipartUpload: <ERROR> %v", err)
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(tamperedBytes))
+	rec := httptest.NewRecorder()
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("MinIO %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	actualError := &APIErrorResponse{}
+	if err = xml.Unmarshal(rec.Body.Bytes(), actualError); err != nil {
+		t.Fatalf("MinIO %s: error response failed to parse error XML", instanceType)
+	}
+	if actualError.Code != getAPIError(ErrBadDigest).Code {
+		t.Errorf("MinIO %s: Expected error %s, got %s", instanceType, getAPIError(ErrBadDigest).Code, actualError.Code)
+	}
+
+	// Testing for a request body larger than any valid list of parts.
+	oversizedBytes := append(signedBytes, bytes.Repeat([]byte(" "), 2*10000*1024)...)
+	req, err = newTestSignedRequestV4(http.MethodPost, getCompleteMultipartUploadURL("", bucketName, objectName, uploadID),
+		int64(len(oversizedBytes)), bytes.NewReader(oversizedBytes), credentials.AccessKey, credentials.SecretKey, nil)
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request for CompleteMultipartUpload: <ERROR> %v", err)
+	}
+	rec = httptest.NewRecorder()
+	apiRouter.ServeHTTP(rec, req)
+	actualError = &APIErrorResponse{}
+	if err = xml.Unmarshal(rec.Body.Bytes(), actualError); err != nil {
+		t.Fatalf("MinIO %s: error response failed to parse error XML", instanceType)
+	}
+	if actualError.Code != getAPIError(ErrEntityTooLarge).Code {
+		t.Errorf("MinIO %s: Expected error %s, got %s", instanceType, getAPIError(ErrEntityTooLarge).Code, actualError.Code)
+	}
+
 	// Testing for anonymous API request.
 	var completeBytes []byte
 	// Complete multipart upload parts.
@@ -3341,6 +4338,114 @@ func testAPIDeleteObjectHandler(obj ObjectLayer, instanceType, bucketName string
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
 
+// Wrapper for calling DeleteObject HTTP handler tests on versioned buckets.
+func TestAPIDeleteObjectVersionHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIDeleteObjectVersionHandler, []string{"DeleteObject", "PutBucketPolicy"})
+}
+
+func testAPIDeleteObjectVersionHandler(obj ObjectLayer, instanceType, _ string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T,
+) {
+	ctx := context.Background()
+	bucketName := getRandomBucketName()
+	if err := obj.MakeBucketWithLocation(ctx, bucketName, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatalf("%s: Failed to make bucket: <ERROR> %v", instanceType, err)
+	}
+
+	objectName := "test-object"
+	data := []byte("hello")
+	objInfo, err := obj.PutObject(ctx, bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""),
+		ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatalf("%s: Failed to upload object: <ERROR> %v", instanceType, err)
+	}
+
+	// Deleting the latest version of an object in a versioned bucket, twice,
+	// creates a delete marker each time.
+	for i := 1; i <= 2; i++ {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodDelete, getDeleteObjectURL("", bucketName, objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for DeleteObject: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("%s: Case %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i, http.StatusNoContent, rec.Code)
+		}
+		// These headers are set as literal lower-case map keys.
+		if rec.Header()[xhttp.AmzDeleteMarker] == nil || rec.Header()[xhttp.AmzDeleteMarker][0] != "true" {
+			t.Errorf("%s: Case %d: Expected %s header to be set", instanceType, i, xhttp.AmzDeleteMarker)
+		}
+		if rec.Header()[xhttp.AmzVersionID] == nil || rec.Header()[xhttp.AmzVersionID][0] == "" {
+			t.Errorf("%s: Case %d: Expected %s header to be set", instanceType, i, xhttp.AmzVersionID)
+		}
+	}
+
+	// Allow anonymous s3:DeleteObject and s3:ReplicateDelete but not s3:DeleteObjectVersion.
+	bucketPolicy := &policy.Policy{
+		Version: policy.DefaultVersion,
+		Statements: []policy.Statement{
+			policy.NewStatement(
+				"",
+				policy.Allow,
+				policy.NewPrincipal("*"),
+				policy.NewActionSet(policy.DeleteObjectAction, policy.ReplicateDeleteAction),
+				policy.NewResourceSet(policy.NewResource(bucketName, "*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+	bucketPolicyBuf, err := json.Marshal(bucketPolicy)
+	if err != nil {
+		t.Fatalf("%s: Failed to marshal bucket policy: <ERROR> %v", instanceType, err)
+	}
+	rec := httptest.NewRecorder()
+	req, err := newTestSignedRequestV4(http.MethodPut, getPutPolicyURL("", bucketName),
+		int64(len(bucketPolicyBuf)), bytes.NewReader(bucketPolicyBuf), credentials.AccessKey, credentials.SecretKey, nil)
+	if err != nil {
+		t.Fatalf("%s: Failed to create HTTP request for PutBucketPolicyHandler: <ERROR> %v", instanceType, err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNoContent, rec.Code)
+	}
+
+	versionURL := makeTestTargetURL("", bucketName, objectName, url.Values{xhttp.VersionID: []string{objInfo.VersionID}})
+	testCases := []struct {
+		url                string
+		replica            bool
+		expectedRespStatus int
+	}{
+		// Deleting a specific version requires s3:DeleteObjectVersion.
+		{versionURL, false, http.StatusForbidden},
+		// Creating a delete marker only requires s3:DeleteObject.
+		{getDeleteObjectURL("", bucketName, objectName), false, http.StatusNoContent},
+		// Replica deletes are authorized with s3:ReplicateDelete.
+		{makeTestTargetURL("", bucketName, objectName, url.Values{xhttp.VersionID: []string{mustGetUUID()}}), true, http.StatusNoContent},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		anonReq, err := newTestRequest(http.MethodDelete, testCase.url, 0, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create an anonymous request: <ERROR> %v", instanceType, err)
+		}
+		if testCase.replica {
+			anonReq.Header.Set(xhttp.AmzBucketReplicationStatus, replication.Replica.String())
+		}
+		apiRouter.ServeHTTP(rec, anonReq)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("%s: Anonymous case %d: Expected the response status to be `%d`, but instead found `%d`", instanceType, i+1, testCase.expectedRespStatus, rec.Code)
+		}
+	}
+
+	// The version itself is still present.
+	if _, err = obj.GetObjectInfo(ctx, bucketName, objectName, ObjectOptions{VersionID: objInfo.VersionID}); err != nil {
+		t.Errorf("%s: Expected version %s to be retained, got <ERROR> %v", instanceType, objInfo.VersionID, err)
+	}
+}
+
 // TestAPIPutObjectPartHandlerStreaming - Tests validate the response of PutObjectPart HTTP handler
 // when the request signature type is `streaming signature`.
 func TestAPIPutObjectPartHandlerStreaming(t *testing.T) {
@@ -4045,3 +5150,114 @@ func testAPIListObjectPartsHandler(obj ObjectLayer, instanceType, bucketName str
 	// `ExecObjectLayerAPINilTest` sets the Object Layer to `nil` and calls the handler.
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
+
+// TestAPIListObjectPartsHandlerPagination - Tests validate the parts listed by
+// ListObjectParts HTTP handler and paging through them.
+func TestAPIListObjectPartsHandlerPagination(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIListObjectPartsHandlerPagination, []string{"ListObjectParts"})
+}
+
+func testAPIListObjectPartsHandlerPagination(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T,
+) {
+	testObject := "testobject"
+	uploadID, err := obj.NewMultipartUpload(context.Background(), bucketName, testObject, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("MinIO %s : <ERROR>  %s", instanceType, err)
+	}
+
+	var partsData [][]byte
+	for partID := 1; partID <= 3; partID++ {
+		data := bytes.Repeat([]byte{byte('a' + partID)}, partID*10)
+		_, err = obj.PutObjectPart(context.Background(), bucketName, testObject, uploadID, partID,
+			mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("MinIO %s : %s.", instanceType, err)
+		}
+		partsData = append(partsData, data)
+	}
+
+	listParts := func(maxParts, partNumberMarker string) ListPartsResponse {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodGet,
+			getListMultipartURLWithParams("", bucketName, testObject, uploadID, maxParts, partNumberMarker, ""),
+			0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Failed to create a V4 signed request to list object parts for %s/%s: <ERROR> %v.",
+				bucketName, testObject, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("MinIO %s: Expected to succeed with response HTTP status 200OK, but failed with HTTP status code %d.", instanceType, rec.Code)
+		}
+		var resp ListPartsResponse
+		if err = xml.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("MinIO %s: Failed to unmarshal list object parts response: <ERROR> %v", instanceType, err)
+		}
+		return resp
+	}
+
+	testCases := []struct {
+		maxParts         string
+		partNumberMarker string
+		expectedParts    []int
+		expectedNext     int
+		isTruncated      bool
+	}{
+		// Test case - 1.
+		// All the parts are listed by default.
+		{"", "", []int{1, 2, 3}, 3, false},
+		// Test case - 2.
+		// First page of two parts.
+		{"2", "", []int{1, 2}, 2, true},
+		// Test case - 3.
+		// Resuming from the next part number marker.
+		{"2", "2", []int{3}, 3, false},
+		// Test case - 4.
+		// Marker past the last part.
+		{"2", "3", nil, 0, false},
+	}
+
+	for i, testCase := range testCases {
+		resp := listParts(testCase.maxParts, testCase.partNumberMarker)
+		if resp.UploadID != uploadID || resp.Key != testObject {
+			t.Errorf("MinIO %s: Test %d: Unexpected upload %s/%s", instanceType, i+1, resp.Key, resp.UploadID)
+		}
+		if resp.IsTruncated != testCase.isTruncated {
+			t.Errorf("MinIO %s: Test %d: Expected truncated to be %v, got %v", instanceType, i+1, testCase.isTruncated, resp.IsTruncated)
+		}
+		if testCase.isTruncated && resp.NextPartNumberMarker != testCase.expectedNext {
+			t.Errorf("MinIO %s: Test %d: Expected next part number marker %d, got %d", instanceType, i+1, testCase.expectedNext, resp.NextPartNumberMarker)
+		}
+		if len(resp.Parts) != len(testCase.expectedParts) {
+			t.Fatalf("MinIO %s: Test %d: Expected %d parts, got %d", instanceType, i+1, len(testCase.expectedParts), len(resp.Parts))
+		}
+		for j, part := range resp.Parts {
+			data := partsData[testCase.expectedParts[j]-1]
+			if part.PartNumber != testCase.expectedParts[j] {
+				t.Errorf("MinIO %s: Test %d: Expected part number %d, got %d", instanceType, i+1, testCase.expectedParts[j], part.PartNumber)
+			}
+			if part.ETag != "\""+getMD5Hash(data)+"\"" {
+				t.Errorf("MinIO %s: Test %d: Unexpected ETag %s for part %d", instanceType, i+1, part.ETag, part.PartNumber)
+			}
+			if part.Size != int64(len(data)) {
+				t.Errorf("MinIO %s: Test %d: Expected size %d for part %d, got %d", instanceType, i+1, len(data), part.PartNumber, part.Size)
+			}
+			if part.LastModified == "" {
+				t.Errorf("MinIO %s: Test %d: Missing last modified for part %d", instanceType, i+1, part.PartNumber)
+			}
+		}
+	}
+
+	// HTTP request for testing when `objectLayer` is set to `nil`.
+	nilBucket := "dummy-bucket"
+	nilObject := "dummy-object"
+	nilReq, err := newTestSignedRequestV4(http.MethodGet,
+		getListMultipartURLWithParams("", nilBucket, nilObject, "dummy-uploadID", "0", "0", ""),
+		0, nil, "", "", nil)
+	if err != nil {
+		t.Errorf("MinIO %s:Failed to create http request for testing the response when object Layer is set to `nil`.", instanceType)
+	}
+	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
+}
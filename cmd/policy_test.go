@@ -18,7 +18,10 @@
 package cmd
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	miniogopolicy "github.com/minio/minio-go/v7/pkg/policy"
@@ -143,6 +146,79 @@ func TestPolicySysIsAllowed(t *testing.T) {
 	}
 }
 
+// Tests anonymous access through a public bucket policy with conditions
+// evaluated against values extracted from the incoming request.
+func TestPolicySysIsAllowedAnonymousConditions(t *testing.T) {
+	policyJSON := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": ["*"]},
+      "Action": ["s3:GetObject"],
+      "Resource": ["arn:aws:s3:::mybucket/*"],
+      "Condition": {"StringLike": {"aws:Referer": ["http://www.example.com/*"]}}
+    },
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": ["*"]},
+      "Action": ["s3:ListBucket"],
+      "Resource": ["arn:aws:s3:::mybucket"],
+      "Condition": {"StringEquals": {"s3:prefix": ["public/"]}}
+    },
+    {
+      "Effect": "Deny",
+      "Principal": {"AWS": ["*"]},
+      "Action": ["s3:GetObject"],
+      "Resource": ["arn:aws:s3:::mybucket/private/*"]
+    }
+  ]
+}`
+	p, err := policy.ParseConfig(strings.NewReader(policyJSON), "mybucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		action         policy.Action
+		urlStr         string
+		referer        string
+		objectName     string
+		expectedResult bool
+	}{
+		// Matching referer is allowed.
+		{policy.GetObjectAction, "http://127.0.0.1:9000/mybucket/index.html", "http://www.example.com/page.html", "index.html", true},
+		// Non matching referer is denied.
+		{policy.GetObjectAction, "http://127.0.0.1:9000/mybucket/index.html", "http://www.attacker.com/", "index.html", false},
+		// Missing referer is denied.
+		{policy.GetObjectAction, "http://127.0.0.1:9000/mybucket/index.html", "", "index.html", false},
+		// Explicit deny takes precedence over allow.
+		{policy.GetObjectAction, "http://127.0.0.1:9000/mybucket/private/secret", "http://www.example.com/page.html", "private/secret", false},
+		// Listing the allowed prefix.
+		{policy.ListBucketAction, "http://127.0.0.1:9000/mybucket?prefix=public/", "", "", true},
+		// Listing another prefix.
+		{policy.ListBucketAction, "http://127.0.0.1:9000/mybucket?prefix=private/", "", "", false},
+	}
+
+	for i, testCase := range testCases {
+		r := httptest.NewRequest(http.MethodGet, testCase.urlStr, nil)
+		if testCase.referer != "" {
+			r.Header.Set("Referer", testCase.referer)
+		}
+		r.Form = r.URL.Query()
+
+		result := p.IsAllowed(policy.Args{
+			Action:          testCase.action,
+			BucketName:      "mybucket",
+			ConditionValues: getConditionValues(r, "", "", nil),
+			ObjectName:      testCase.objectName,
+		})
+		if result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
+
 func getReadOnlyStatement(bucketName, prefix string) []miniogopolicy.Statement {
 	return []miniogopolicy.Statement{
 		{
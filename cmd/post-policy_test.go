@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/xml"
 	"fmt"
 	"io/ioutil"
 	"mime/multipart"
@@ -508,6 +509,93 @@ func testPostPolicyBucketHandlerRedirect(obj ObjectLayer, instanceType string, t
 	}
 }
 
+// Wrapper for calling TestPostPolicyBucketHandlerSuccessStatus tests for both Erasure multiple disks and single node setup.
+func TestPostPolicyBucketHandlerSuccessStatus(t *testing.T) {
+	ExecObjectLayerTest(t, testPostPolicyBucketHandlerSuccessStatus)
+}
+
+// testPostPolicyBucketHandlerSuccessStatus tests POST Object from an HTML form when success_action_status is specified
+func testPostPolicyBucketHandlerSuccessStatus(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	if err := newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatalf("Initializing config.json failed")
+	}
+
+	// get random bucket name.
+	bucketName := getRandomBucketName()
+
+	// Register the API end points with Erasure/FS object layer.
+	apiRouter := initTestAPIEndPoints(obj, []string{"PostPolicy"})
+
+	credentials := globalActiveCred
+
+	err := obj.MakeBucketWithLocation(context.Background(), bucketName, BucketOptions{})
+	if err != nil {
+		// Failed to create newbucket, abort.
+		t.Fatalf("%s : %s", instanceType, err.Error())
+	}
+
+	testCases := []struct {
+		successStatus      string
+		expectedRespStatus int
+	}{
+		{"201", http.StatusCreated},
+		{"200", http.StatusOK},
+		{"204", http.StatusNoContent},
+		{"", http.StatusNoContent},
+	}
+
+	region := "us-east-1"
+	for i, testCase := range testCases {
+		curTime := UTCNow()
+		keyName := fmt.Sprintf("test/status-%d", i)
+		objData := []byte("form upload " + testCase.successStatus)
+
+		dates := []interface{}{curTime.Add(time.Minute * 5).Format(iso8601TimeFormat), curTime.Format(iso8601DateFormat), curTime.Format(yyyymmdd)}
+		policy := `{"expiration": "%s","conditions":[["eq", "$bucket", "` + bucketName + `"], ["starts-with", "$success_action_status", ""], ["starts-with", "$key", "test/"], ["eq", "$x-amz-meta-uuid", "1234"], ["eq", "$x-amz-algorithm", "AWS4-HMAC-SHA256"], ["eq", "$x-amz-date", "%s"], ["eq", "$x-amz-credential", "` + credentials.AccessKey + `/%s/us-east-1/s3/aws4_request"]]}`
+		policy = fmt.Sprintf(policy, dates...)
+
+		var formData map[string]string
+		if testCase.successStatus != "" {
+			formData = map[string]string{"success_action_status": testCase.successStatus}
+		}
+		req, perr := newPostRequestV4Generic("", bucketName, keyName, objData,
+			credentials.AccessKey, credentials.SecretKey, region, curTime,
+			[]byte(policy), formData, false, false)
+		if perr != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for PostPolicyHandler: <ERROR> %v", i+1, instanceType, perr)
+		}
+
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`: %s",
+				i+1, instanceType, testCase.expectedRespStatus, rec.Code, rec.Body.String())
+		}
+
+		// The file field is stored under the key, with ${filename} substituted.
+		targetObj := keyName + "/upload.txt"
+		info, err := obj.GetObjectInfo(context.Background(), bucketName, targetObj, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Test %d: %s: Unexpected error: %v", i+1, instanceType, err)
+		}
+		if info.Size != int64(len(objData)) {
+			t.Fatalf("Test %d: %s: Expected object size %d, found %d", i+1, instanceType, len(objData), info.Size)
+		}
+
+		if testCase.expectedRespStatus == http.StatusCreated {
+			var resp PostResponse
+			if err = xml.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Test %d: %s: Failed to parse the response: %v", i+1, instanceType, err)
+			}
+			if resp.Bucket != bucketName || resp.Key != targetObj || resp.ETag != `"`+info.ETag+`"` {
+				t.Fatalf("Test %d: %s: Unexpected response %+v", i+1, instanceType, resp)
+			}
+		} else if rec.Body.Len() != 0 {
+			t.Fatalf("Test %d: %s: Expected an empty body, found %q", i+1, instanceType, rec.Body.String())
+		}
+	}
+}
+
 // postPresignSignatureV4 - presigned signature for PostPolicy requests.
 func postPresignSignatureV4(policyBase64 string, t time.Time, secretAccessKey, location string) string {
 	// Get signining key.
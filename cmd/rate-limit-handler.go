@@ -0,0 +1,150 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// Interval at which idle rate limiters are evicted.
+	rateLimiterEvictInterval = time.Minute
+
+	// Rate limiters not used for this long are evicted.
+	rateLimiterIdleTimeout = 5 * time.Minute
+)
+
+// rateLimiterEntry is the token bucket of a single client.
+type rateLimiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen int64 // Unix nanoseconds, accessed atomically.
+}
+
+// requestRateLimiter keeps a token bucket per client.
+type requestRateLimiter struct {
+	limiters  sync.Map // map[string]*rateLimiterEntry
+	evictOnce sync.Once
+}
+
+// allow reports if a request from client identified by key is allowed
+// with the given limits, otherwise returns the duration after which
+// the client may retry.
+func (l *requestRateLimiter) allow(key string, limit float64, burst int, now time.Time) (bool, time.Duration) {
+	l.evictOnce.Do(func() {
+		go l.evictIdle(rateLimiterEvictInterval, rateLimiterIdleTimeout)
+	})
+
+	v, ok := l.limiters.Load(key)
+	if !ok {
+		v, _ = l.limiters.LoadOrStore(key, &rateLimiterEntry{
+			limiter: rate.NewLimiter(rate.Limit(limit), burst),
+		})
+	}
+	entry := v.(*rateLimiterEntry)
+	atomic.StoreInt64(&entry.lastSeen, now.UnixNano())
+
+	// Pick up any configuration changes.
+	if entry.limiter.Limit() != rate.Limit(limit) {
+		entry.limiter.SetLimitAt(now, rate.Limit(limit))
+	}
+	if entry.limiter.Burst() != burst {
+		entry.limiter.SetBurstAt(now, burst)
+	}
+
+	reservation := entry.limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		return false, time.Second
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		// Do not consume the token, the request is rejected.
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// evictIdle periodically removes token buckets of clients that
+// have not sent any requests for the idle timeout.
+func (l *requestRateLimiter) evictIdle(interval, idleTimeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-GlobalContext.Done():
+			return
+		case <-ticker.C:
+			l.evictIdleAt(time.Now(), idleTimeout)
+		}
+	}
+}
+
+func (l *requestRateLimiter) evictIdleAt(now time.Time, idleTimeout time.Duration) {
+	l.limiters.Range(func(key, value interface{}) bool {
+		entry := value.(*rateLimiterEntry)
+		if now.Sub(time.Unix(0, atomic.LoadInt64(&entry.lastSeen))) > idleTimeout {
+			l.limiters.Delete(key)
+		}
+		return true
+	})
+}
+
+// globalRequestRateLimiter throttles S3 API requests per client.
+var globalRequestRateLimiter = &requestRateLimiter{}
+
+// getReqRateLimitKey returns the key used to rate limit the request.
+// Requests are throttled before their signature is verified, so the
+// access key they claim cannot be trusted, key them by source IP.
+// Forwarded headers are only honored from trusted proxies.
+func getReqRateLimitKey(r *http.Request) string {
+	if ip := getTrustedSourceIP(r, globalAPIConfig.getTrustedProxies()); ip != nil {
+		return "ip:" + ip.String()
+	}
+	return "ip:" + r.RemoteAddr
+}
+
+// setRateLimitHandler throttles the number of requests per second
+// allowed for each source IP.
+func setRateLimitHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, burst := globalAPIConfig.getRequestsRate()
+		if limit <= 0 || guessIsRPCReq(r) || guessIsHealthCheckReq(r) || guessIsMetricsReq(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		allowed, retryAfter := globalRequestRateLimiter.allow(getReqRateLimitKey(r), limit, burst, time.Now())
+		if !allowed {
+			if tc, ok := r.Context().Value(contextTraceReqKey).(*traceCtxt); ok {
+				tc.funcName = "handler.RateLimit"
+				tc.responseRecorder.LogErrBody = true
+			}
+
+			setRetryAfter(w, retryAfter)
+			writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrSlowDown), r.URL)
+			atomic.AddUint64(&globalHTTPStats.rejectedRequestsRate, 1)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+func TestRequestRateLimiterAllow(t *testing.T) {
+	l := &requestRateLimiter{}
+	now := time.Now()
+
+	// Burst of 2 at 1 request per second.
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.allow("client-a", 1, 2, now); !ok {
+			t.Fatalf("request %d: expected to be allowed within burst", i+1)
+		}
+	}
+	ok, retryAfter := l.allow("client-a", 1, 2, now)
+	if ok {
+		t.Fatal("expected request exceeding burst to be rejected")
+	}
+	if retryAfter <= 0 || retryAfter > time.Second {
+		t.Fatalf("expected retry after within (0, 1s], got %s", retryAfter)
+	}
+
+	// Other clients have their own bucket.
+	if ok, _ := l.allow("client-b", 1, 2, now); !ok {
+		t.Fatal("expected request from another client to be allowed")
+	}
+
+	// Tokens are refilled over time.
+	if ok, _ := l.allow("client-a", 1, 2, now.Add(time.Second)); !ok {
+		t.Fatal("expected request to be allowed after refill")
+	}
+}
+
+func TestRequestRateLimiterEvictIdle(t *testing.T) {
+	l := &requestRateLimiter{}
+	now := time.Now()
+
+	l.allow("idle", 1, 1, now)
+	l.allow("active", 1, 1, now.Add(time.Minute))
+
+	l.evictIdleAt(now.Add(2*time.Minute), 90*time.Second)
+
+	if _, ok := l.limiters.Load("idle"); ok {
+		t.Fatal("expected idle client to be evicted")
+	}
+	if _, ok := l.limiters.Load("active"); !ok {
+		t.Fatal("expected active client to be retained")
+	}
+}
+
+func TestSetRateLimitHandler(t *testing.T) {
+	defer func(rate float64, burst int, limiter *requestRateLimiter) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.requestsRate = rate
+		globalAPIConfig.requestsRateBurst = burst
+		globalAPIConfig.mu.Unlock()
+		globalRequestRateLimiter = limiter
+	}(globalAPIConfig.requestsRate, globalAPIConfig.requestsRateBurst, globalRequestRateLimiter)
+
+	globalAPIConfig.mu.Lock()
+	globalAPIConfig.requestsRate = 1
+	globalAPIConfig.requestsRateBurst = 1
+	globalAPIConfig.mu.Unlock()
+	globalRequestRateLimiter = &requestRateLimiter{}
+
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := setRateLimitHandler(okHandler)
+
+	newReq := func(remoteAddr string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object", nil)
+		req.RemoteAddr = remoteAddr
+		return req
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newReq("10.0.0.1:1234"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected first request to succeed, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newReq("10.0.0.1:1234"))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected second request to be throttled, got %d", rec.Code)
+	}
+	if rec.Header().Get(xhttp.RetryAfter) != "1" {
+		t.Fatalf("expected Retry-After of 1 second, got %q", rec.Header().Get(xhttp.RetryAfter))
+	}
+
+	// Requests claiming another access key are not exempt, the
+	// signature is not verified yet.
+	req := newReq("10.0.0.1:1234")
+	req.Header.Set(xhttp.Authorization, signV4Algorithm+" Credential=otheraccesskey/20220101/us-east-1/s3/aws4_request")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected request with another access key to be throttled, got %d", rec.Code)
+	}
+
+	// Forwarded headers from untrusted peers are ignored.
+	req = newReq("10.0.0.1:1234")
+	req.Header.Set(xhttp.XForwardedFor, "192.168.1.1")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected request with a forged X-Forwarded-For to be throttled, got %d", rec.Code)
+	}
+
+	// Requests from a different source IP are not affected.
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newReq("10.0.0.2:1234"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected request from another IP to succeed, got %d", rec.Code)
+	}
+}
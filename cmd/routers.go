@@ -43,12 +43,18 @@ var globalHandlers = []mux.MiddlewareFunc{
 	// The generic tracer needs to be the first handler
 	// to catch all requests returned early by any other handler
 	httpTracer,
+	// Rejects requests from source IPs outside the configured
+	// allow list or inside the configured deny list.
+	setIPFilterHandler,
 	// Auth handler verifies incoming authorization headers and
 	// routes them accordingly. Client receives a HTTP error for
 	// invalid/unsupported signatures.
 	//
 	// Validates all incoming requests to have a valid date header.
 	setAuthHandler,
+	// Throttles requests per source IP, when a requests
+	// rate is configured.
+	setRateLimitHandler,
 	// Redirect some pre-defined browser request paths to a static location prefix.
 	setBrowserRedirectHandler,
 	// Adds 'crossdomain.xml' policy handler to serve legacy flash clients.
@@ -61,6 +67,9 @@ var globalHandlers = []mux.MiddlewareFunc{
 	setRequestValidityHandler,
 	// set x-amz-request-id header.
 	addCustomHeaders,
+	// Rejects writes on read-only buckets and reads
+	// on write-only buckets.
+	setBucketAccessModeHandler,
 	// Add bucket forwarding handler
 	setBucketForwardingHandler,
 	// Add new handlers here.
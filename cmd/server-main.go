@@ -20,6 +20,7 @@ package cmd
 import (
 	"context"
 	"crypto/tls"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -40,6 +41,7 @@ import (
 	"github.com/minio/minio/internal/color"
 	"github.com/minio/minio/internal/config"
 	"github.com/minio/minio/internal/fips"
+	"github.com/minio/minio/internal/hash/sha256"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/minio/internal/rest"
@@ -87,6 +89,20 @@ var ServerFlags = []cli.Flag{
 		EnvVar: "MINIO_READ_HEADER_TIMEOUT",
 		Hidden: true,
 	},
+	cli.DurationFlag{
+		Name:   "read-body-timeout",
+		Value:  xhttp.DefaultReadBodyTimeout,
+		Usage:  "read body timeout is the amount of time allowed between reads of the request body, applies to all connections including internode, 0 disables it",
+		EnvVar: "MINIO_READ_BODY_TIMEOUT",
+		Hidden: true,
+	},
+	cli.DurationFlag{
+		Name:   "write-response-timeout",
+		Value:  xhttp.DefaultWriteResponseTimeout,
+		Usage:  "write response timeout is the amount of time allowed for each write of the response, applies to all connections including internode, 0 disables it",
+		EnvVar: "MINIO_WRITE_RESPONSE_TIMEOUT",
+		Hidden: true,
+	},
 }
 
 var serverCmd = cli.Command{
@@ -191,6 +207,8 @@ func serverHandleCmdArgs(ctx *cli.Context) {
 	logger.FatalIf(err, "Invalid command line arguments")
 
 	globalLocalNodeName = GetLocalPeer(globalEndpoints, globalMinioHost, globalMinioPort)
+	nodeNameSum := sha256.Sum256([]byte(globalLocalNodeName))
+	globalLocalNodeNameHex = hex.EncodeToString(nodeNameSum[:])
 
 	globalRemoteEndpoints = make(map[string]Endpoint)
 	for _, z := range globalEndpoints {
@@ -505,11 +523,13 @@ func serverMain(ctx *cli.Context) {
 	}
 
 	httpServer := xhttp.NewServer(addrs).
-		UseHandler(setCriticalErrorHandler(corsHandler(handler))).
+		UseHandler(setCriticalErrorHandler(setBasePathHandler(corsHandler(handler)))).
 		UseTLSConfig(newTLSConfig(getCert)).
 		UseShutdownTimeout(ctx.Duration("shutdown-timeout")).
 		UseIdleTimeout(ctx.Duration("idle-timeout")).
 		UseReadHeaderTimeout(ctx.Duration("read-header-timeout")).
+		UseReadBodyTimeout(ctx.Duration("read-body-timeout")).
+		UseWriteResponseTimeout(ctx.Duration("write-response-timeout")).
 		UseBaseContext(GlobalContext).
 		UseCustomLogger(log.New(ioutil.Discard, "", 0)) // Turn-off random logging by Go stdlib
 
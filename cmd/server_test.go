@@ -19,7 +19,10 @@ package cmd
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/md5"
+	"encoding/hex"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -28,13 +31,17 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/internal/bucket/versioning"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/pkg/bucket/policy"
 )
@@ -77,6 +84,7 @@ func verifyError(c *check, response *http.Response, code, description string, st
 func runAllTests(suite *TestSuiteCommon, c *check) {
 	suite.SetUpSuite(c)
 	suite.TestCors(c)
+	suite.TestOptions(c)
 	suite.TestObjectDir(c)
 	suite.TestBucketPolicy(c)
 	suite.TestDeleteBucket(c)
@@ -94,16 +102,21 @@ func runAllTests(suite *TestSuiteCommon, c *check) {
 	suite.TestPutObject(c)
 	suite.TestListBuckets(c)
 	suite.TestValidateSignature(c)
+	suite.TestExpectContinue(c)
 	suite.TestSHA256Mismatch(c)
 	suite.TestPutObjectLongName(c)
 	suite.TestNotBeAbleToCreateObjectInNonexistentBucket(c)
 	suite.TestHeadOnObjectLastModified(c)
+	suite.TestObjectConditionalRequests(c)
 	suite.TestHeadOnBucket(c)
 	suite.TestContentTypePersists(c)
 	suite.TestPartialContent(c)
 	suite.TestListObjectsHandler(c)
+	suite.TestListObjectsV2Pagination(c)
+	suite.TestListObjectsGzip(c)
 	suite.TestListObjectsHandlerErrors(c)
 	suite.TestPutBucketErrors(c)
+	suite.TestPutBucketLocationConstraint(c)
 	suite.TestGetObjectLarge10MiB(c)
 	suite.TestGetObjectLarge11MiB(c)
 	suite.TestGetPartialObjectMisAligned(c)
@@ -116,7 +129,16 @@ func runAllTests(suite *TestSuiteCommon, c *check) {
 	suite.TestValidateObjectMultipartUploadID(c)
 	suite.TestObjectMultipartListError(c)
 	suite.TestObjectValidMD5(c)
+	suite.TestObjectContentMD5Mismatch(c)
+	suite.TestObjectKeyEncoding(c)
+	suite.TestObjectStandardHeaders(c)
 	suite.TestObjectMultipart(c)
+	suite.TestObjectETagConsistency(c)
+	suite.TestBucketVersioning(c)
+	suite.TestListObjectVersions(c)
+	suite.TestObjectTagging(c)
+	suite.TestCannedACL(c)
+	suite.TestObjectACL(c)
 	suite.TearDownSuite(c)
 }
 
@@ -259,6 +281,47 @@ func (s *TestSuiteCommon) TestCors(c *check) {
 	}
 }
 
+func (s *TestSuiteCommon) TestOptions(c *check) {
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	testCases := []struct {
+		url          string
+		allowMethods string
+	}{
+		{s.endPoint + SlashSeparator, "GET, OPTIONS"},
+		{getMakeBucketURL(s.endPoint, bucketName), "GET, HEAD, PUT, POST, DELETE, OPTIONS"},
+		{getGetObjectURL(s.endPoint, bucketName, "object"), "GET, HEAD, PUT, POST, DELETE, OPTIONS"},
+	}
+	for _, testCase := range testCases {
+		// Plain OPTIONS requests are answered with the supported methods.
+		req, err := http.NewRequest(http.MethodOptions, testCase.url, nil)
+		c.Assert(err, nil)
+		res, err := s.client.Do(req)
+		c.Assert(err, nil)
+		c.Assert(res.StatusCode, http.StatusOK)
+		c.Assert(res.Header.Get(xhttp.Allow), testCase.allowMethods)
+		c.Assert(res.Header.Get("Access-Control-Allow-Origin"), "")
+
+		// Preflight requests are answered with the CORS headers.
+		req, err = http.NewRequest(http.MethodOptions, testCase.url, nil)
+		c.Assert(err, nil)
+		req.Header.Set("Origin", "http://foobar.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPut)
+		res, err = s.client.Do(req)
+		c.Assert(err, nil)
+		c.Assert(res.StatusCode, http.StatusOK)
+		c.Assert(res.Header.Get("Access-Control-Allow-Origin"), "http://foobar.com")
+		c.Assert(res.Header.Get("Access-Control-Allow-Methods"), http.MethodPut)
+		c.Assert(res.Header.Get(xhttp.Allow), "")
+	}
+}
+
 func (s *TestSuiteCommon) TestObjectDir(c *check) {
 	bucketName := getRandomBucketName()
 	// HTTP request to create the bucket.
@@ -1206,6 +1269,65 @@ func (s *TestSuiteCommon) TestValidateSignature(c *check) {
 	verifyError(c, response, "SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided. Check your key and signing method.", http.StatusForbidden)
 }
 
+// readTrackingReader records if the request body was read.
+type readTrackingReader struct {
+	io.Reader
+	read int32
+}
+
+func (r *readTrackingReader) Read(p []byte) (int, error) {
+	atomic.StoreInt32(&r.read, 1)
+	return r.Reader.Read(p)
+}
+
+// TestExpectContinue - Validates that the request body is not
+// uploaded by clients sending 'Expect: 100-continue' when the
+// request fails authentication.
+func (s *TestSuiteCommon) TestExpectContinue(c *check) {
+	// generate a random bucket name.
+	bucketName := getRandomBucketName()
+	// HTTP request to create the bucket.
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	// Execute the HTTP request to create bucket.
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// Wait for '100 Continue' long enough for the body to be
+	// only sent when the server asks for it.
+	transport := s.client.Transport.(*http.Transport).Clone()
+	transport.ExpectContinueTimeout = time.Minute
+	client := &http.Client{Transport: transport}
+
+	data := []byte("hello world")
+	for _, secretKey := range []string{s.secretKey + "a", s.secretKey} {
+		request, err = newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "test-object"),
+			int64(len(data)), bytes.NewReader(data), s.accessKey, secretKey, s.signer)
+		c.Assert(err, nil)
+		body := &readTrackingReader{Reader: bytes.NewReader(data)}
+		request.Body = ioutil.NopCloser(body)
+		request.Header.Set("Expect", "100-continue")
+
+		response, err = client.Do(request)
+		c.Assert(err, nil)
+		if secretKey != s.secretKey {
+			verifyError(c, response, "SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided. Check your key and signing method.", http.StatusForbidden)
+			if atomic.LoadInt32(&body.read) != 0 {
+				c.Fatalf("request body was uploaded for a request failing authentication")
+			}
+			continue
+		}
+		response.Body.Close()
+		c.Assert(response.StatusCode, http.StatusOK)
+		if atomic.LoadInt32(&body.read) == 0 {
+			c.Fatalf("request body was not uploaded for an authenticated request")
+		}
+	}
+}
+
 // This tests validate if PUT handler can successfully detect SHA256 mismatch.
 func (s *TestSuiteCommon) TestSHA256Mismatch(c *check) {
 	// generate a random bucket name.
@@ -1407,6 +1529,83 @@ func (s *TestSuiteCommon) TestHeadOnObjectLastModified(c *check) {
 	c.Assert(response.StatusCode, http.StatusOK)
 }
 
+// TestObjectConditionalRequests - Validates If-Match and If-None-Match
+// on GET and PUT requests for an object.
+func (s *TestSuiteCommon) TestObjectConditionalRequests(c *check) {
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	objectName := "test-object"
+	putObject := func(data string, headers map[string]string) *http.Response {
+		buffer := bytes.NewReader([]byte(data))
+		request, err := newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, objectName),
+			int64(buffer.Len()), buffer, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+		for k, v := range headers {
+			request.Header.Set(k, v)
+		}
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		return response
+	}
+	getObject := func(headers map[string]string) *http.Response {
+		request, err := newTestSignedRequest(http.MethodGet, getGetObjectURL(s.endPoint, bucketName, objectName),
+			0, nil, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+		for k, v := range headers {
+			request.Header.Set(k, v)
+		}
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		return response
+	}
+
+	// If-Match on an object which does not exist yet fails.
+	response = putObject("hello", map[string]string{xhttp.IfMatch: "*"})
+	c.Assert(response.StatusCode, http.StatusPreconditionFailed)
+
+	// If-None-Match: * creates the object only if it is absent.
+	response = putObject("hello", map[string]string{xhttp.IfNoneMatch: "*"})
+	c.Assert(response.StatusCode, http.StatusOK)
+	etag := response.Header.Get(xhttp.ETag)
+
+	response = putObject("world", map[string]string{xhttp.IfNoneMatch: "*"})
+	c.Assert(response.StatusCode, http.StatusPreconditionFailed)
+	verifyError(c, response, "PreconditionFailed", "At least one of the pre-conditions you specified did not hold", http.StatusPreconditionFailed)
+
+	// If-Match overwrites the object only if the ETag matches.
+	response = putObject("world", map[string]string{xhttp.IfMatch: `"deadbeef"`})
+	c.Assert(response.StatusCode, http.StatusPreconditionFailed)
+
+	response = getObject(nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	body, err := ioutil.ReadAll(response.Body)
+	c.Assert(err, nil)
+	c.Assert(string(body), "hello")
+
+	response = putObject("world", map[string]string{xhttp.IfMatch: etag})
+	c.Assert(response.StatusCode, http.StatusOK)
+	newETag := response.Header.Get(xhttp.ETag)
+
+	// If-Match and If-None-Match on GET.
+	response = getObject(map[string]string{xhttp.IfMatch: etag})
+	c.Assert(response.StatusCode, http.StatusPreconditionFailed)
+	response = getObject(map[string]string{xhttp.IfMatch: newETag})
+	c.Assert(response.StatusCode, http.StatusOK)
+	response = getObject(map[string]string{xhttp.IfNoneMatch: newETag})
+	c.Assert(response.StatusCode, http.StatusNotModified)
+	response = getObject(map[string]string{xhttp.IfNoneMatch: etag})
+	c.Assert(response.StatusCode, http.StatusOK)
+	body, err = ioutil.ReadAll(response.Body)
+	c.Assert(err, nil)
+	c.Assert(string(body), "world")
+}
+
 // TestHeadOnBucket - Validates response for HEAD on the bucket.
 // HEAD request on the bucket validates the existence of the bucket.
 func (s *TestSuiteCommon) TestHeadOnBucket(c *check) {
@@ -1565,6 +1764,40 @@ func (s *TestSuiteCommon) TestPartialContent(c *check) {
 	c.Assert(err, nil)
 
 	c.Assert(string(partialObject), "Wo")
+	c.Assert(response.Header.Get(xhttp.AcceptRanges), "bytes")
+	c.Assert(response.Header.Get(xhttp.ContentRange), "bytes 6-7/11")
+	c.Assert(response.ContentLength, int64(2))
+
+	// Suffix ranges are served from the end of the object.
+	request, err = newTestSignedRequest(http.MethodGet, getGetObjectURL(s.endPoint, bucketName, "bar"),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	request.Header.Set("Range", "bytes=-5")
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusPartialContent)
+	partialObject, err = ioutil.ReadAll(response.Body)
+	c.Assert(err, nil)
+	c.Assert(string(partialObject), "World")
+	c.Assert(response.Header.Get(xhttp.ContentRange), "bytes 6-10/11")
+	c.Assert(response.ContentLength, int64(5))
+
+	// Requests without a range get the whole object and still
+	// advertise range support.
+	for _, method := range []string{http.MethodGet, http.MethodHead} {
+		request, err = newTestSignedRequest(method, getGetObjectURL(s.endPoint, bucketName, "bar"),
+			0, nil, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err = s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+		c.Assert(response.Header.Get(xhttp.AcceptRanges), "bytes")
+		c.Assert(response.Header.Get(xhttp.ContentRange), "")
+		c.Assert(response.Header.Get(xhttp.ContentLength), "11")
+		response.Body.Close()
+	}
 }
 
 // TestListObjectsHandler - Setting valid parameters to List Objects
@@ -1636,6 +1869,174 @@ func (s *TestSuiteCommon) TestListObjectsHandler(c *check) {
 	}
 }
 
+// TestListObjectsV2Pagination - Pages through a bucket with ListObjectsV2
+// continuation tokens and validates the listing matches ListObjectsV1.
+func (s *TestSuiteCommon) TestListObjectsV2Pagination(c *check) {
+	// generate a random bucket name.
+	bucketName := getRandomBucketName()
+	// HTTP request to create the bucket.
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	// execute the HTTP request to create bucket.
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	for _, objectName := range []string{"a", "b", "dir/1", "dir/2", "e", "f"} {
+		buffer := bytes.NewReader([]byte("Hello World"))
+		request, err = newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, objectName),
+			int64(buffer.Len()), buffer, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err = s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+	}
+
+	// List the whole bucket with V1 as a reference.
+	queryValue := url.Values{}
+	queryValue.Set("delimiter", SlashSeparator)
+	request, err = newTestSignedRequest(http.MethodGet, makeTestTargetURL(s.endPoint, bucketName, "", queryValue),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	listV1 := ListObjectsResponse{}
+	c.Assert(xmlDecoder(response.Body, &listV1, response.ContentLength), nil)
+
+	var expectedKeys, expectedPrefixes []string
+	for _, object := range listV1.Contents {
+		expectedKeys = append(expectedKeys, object.Key)
+	}
+	for _, prefix := range listV1.CommonPrefixes {
+		expectedPrefixes = append(expectedPrefixes, prefix.Prefix)
+	}
+	c.Assert(expectedKeys, []string{"a", "b", "e", "f"})
+	c.Assert(expectedPrefixes, []string{"dir/"})
+
+	listV2 := func(token, startAfter string) ListObjectsV2Response {
+		queryValue := url.Values{}
+		queryValue.Set("list-type", "2")
+		queryValue.Set("max-keys", "2")
+		queryValue.Set("delimiter", SlashSeparator)
+		if token != "" {
+			queryValue.Set("continuation-token", token)
+		}
+		if startAfter != "" {
+			queryValue.Set("start-after", startAfter)
+		}
+		request, err := newTestSignedRequest(http.MethodGet, makeTestTargetURL(s.endPoint, bucketName, "", queryValue),
+			0, nil, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+		result := ListObjectsV2Response{}
+		c.Assert(xmlDecoder(response.Body, &result, response.ContentLength), nil)
+		return result
+	}
+
+	// Page through the bucket, two entries at a time.
+	var keys, prefixes []string
+	var token string
+	var pages int
+	for {
+		result := listV2(token, "")
+		pages++
+		c.Assert(result.ContinuationToken, token)
+		c.Assert(result.KeyCount, len(result.Contents)+len(result.CommonPrefixes))
+		for _, object := range result.Contents {
+			keys = append(keys, object.Key)
+		}
+		for _, prefix := range result.CommonPrefixes {
+			prefixes = append(prefixes, prefix.Prefix)
+		}
+		if !result.IsTruncated {
+			c.Assert(result.NextContinuationToken, "")
+			break
+		}
+		c.Assert(result.KeyCount, 2)
+		c.Assert(result.NextContinuationToken != "", true)
+		token = result.NextContinuationToken
+	}
+	c.Assert(pages, 3)
+	c.Assert(keys, expectedKeys)
+	c.Assert(prefixes, expectedPrefixes)
+
+	// Listing starts after the given key.
+	result := listV2("", "b")
+	c.Assert(result.StartAfter, "b")
+	c.Assert(len(result.CommonPrefixes), 1)
+	c.Assert(result.CommonPrefixes[0].Prefix, "dir/")
+	c.Assert(len(result.Contents), 1)
+	c.Assert(result.Contents[0].Key, "e")
+	c.Assert(result.IsTruncated, true)
+}
+
+// TestListObjectsGzip - Validates that listings are gzip compressed
+// for clients accepting it, while object data is not.
+func (s *TestSuiteCommon) TestListObjectsGzip(c *check) {
+	// generate a random bucket name.
+	bucketName := getRandomBucketName()
+	// HTTP request to create the bucket.
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	// execute the HTTP request to create bucket.
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// Upload enough objects for the listing to be worth compressing.
+	data := bytes.Repeat([]byte("a"), 2000)
+	for i := 0; i < 10; i++ {
+		objectName := fmt.Sprintf("prefix/object-%d.txt", i)
+		request, err = newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, objectName),
+			int64(len(data)), bytes.NewReader(data), s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err = s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+	}
+
+	request, err = newTestSignedRequest(http.MethodGet, getListObjectsV2URL(s.endPoint, bucketName, "", "1000", "", ""),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	// Setting the header disables transparent decompression by the client.
+	request.Header.Set("Accept-Encoding", "gzip")
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(response.Header.Get(xhttp.ContentEncoding), "gzip")
+	c.Assert(strings.Contains(strings.Join(response.Header.Values("Vary"), ","), "Accept-Encoding"), true)
+
+	gr, err := gzip.NewReader(response.Body)
+	c.Assert(err, nil)
+	listContent, err := ioutil.ReadAll(gr)
+	c.Assert(err, nil)
+	response.Body.Close()
+	c.Assert(strings.Contains(string(listContent), "<Key>prefix/object-9.txt</Key>"), true)
+
+	// Object data is not compressed unless configured with gzip_objects.
+	request, err = newTestSignedRequest(http.MethodGet, getGetObjectURL(s.endPoint, bucketName, "prefix/object-0.txt"),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	request.Header.Set("Accept-Encoding", "gzip")
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(response.Header.Get(xhttp.ContentEncoding), "")
+	objectContent, err := ioutil.ReadAll(response.Body)
+	c.Assert(err, nil)
+	response.Body.Close()
+	c.Assert(objectContent, data)
+}
+
 // TestListObjectsHandlerErrors - Setting invalid parameters to List Objects
 // and then asserting the error response with the expected one.
 func (s *TestSuiteCommon) TestListObjectsHandlerErrors(c *check) {
@@ -1687,6 +2088,18 @@ func (s *TestSuiteCommon) TestPutBucketErrors(c *check) {
 	c.Assert(err, nil)
 	// expected to fail with error message "InvalidBucketName".
 	verifyError(c, response, "InvalidBucketName", "The specified bucket is not valid.", http.StatusBadRequest)
+
+	// names violating the DNS naming rules are rejected as well.
+	for _, invalidName := range []string{"ab", "Uppercase-bucket", "under_score", "192.168.1.1", "consecutive..dots", strings.Repeat("a", 64)} {
+		request, err = newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, invalidName),
+			0, nil, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err = s.client.Do(request)
+		c.Assert(err, nil)
+		verifyError(c, response, "InvalidBucketName", "The specified bucket is not valid.", http.StatusBadRequest)
+	}
+
 	// HTTP request to create the bucket.
 	request, err = newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
 		0, nil, s.accessKey, s.secretKey, s.signer)
@@ -1708,86 +2121,635 @@ func (s *TestSuiteCommon) TestPutBucketErrors(c *check) {
 		http.StatusConflict)
 }
 
-func (s *TestSuiteCommon) TestGetObjectLarge10MiB(c *check) {
-	// generate a random bucket name.
+// TestPutBucketLocationConstraint - validates the location constraint
+// sent in the bucket creation body against the server region.
+func (s *TestSuiteCommon) TestPutBucketLocationConstraint(c *check) {
+	// locations are only validated when the server has a region configured.
+	defer func(region string) { globalSite.Region = region }(globalSite.Region)
+	globalSite.Region = "us-west-2"
+
+	createBucketConfig := func(location string) []byte {
+		return []byte(`<CreateBucketConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><LocationConstraint>` +
+			location + `</LocationConstraint></CreateBucketConfiguration>`)
+	}
+
+	// a location constraint matching the server region is accepted.
 	bucketName := getRandomBucketName()
-	// form HTTP request to create the bucket.
+	body := createBucketConfig(globalSite.Region)
 	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
-		0, nil, s.accessKey, s.secretKey, s.signer)
+		int64(len(body)), bytes.NewReader(body), s.accessKey, s.secretKey, s.signer)
 	c.Assert(err, nil)
 
-	// execute the HTTP request to create the bucket.
 	response, err := s.client.Do(request)
 	c.Assert(err, nil)
 	c.Assert(response.StatusCode, http.StatusOK)
 
-	var buffer bytes.Buffer
-	line := `1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
-	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
-	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
-	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
-	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
-	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
-	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
-	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
-	1234567890,1234567890,1234567890,1234567890,1234567890,123"`
-	// Create 10MiB content where each line contains 1024 characters.
-	for i := 0; i < 10*1024; i++ {
-		buffer.WriteString(fmt.Sprintf("[%05d] %s\n", i, line))
-	}
-	putContent := buffer.String()
-
-	buf := bytes.NewReader([]byte(putContent))
-
-	objectName := "test-big-object"
-	// create HTTP request for object upload.
-	request, err = newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, objectName),
-		int64(buf.Len()), buf, s.accessKey, s.secretKey, s.signer)
+	// a location constraint for any other region is rejected.
+	bucketName = getRandomBucketName()
+	body = createBucketConfig("eu-west-1")
+	request, err = newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		int64(len(body)), bytes.NewReader(body), s.accessKey, s.secretKey, s.signer)
 	c.Assert(err, nil)
 
-	// execute the HTTP request.
 	response, err = s.client.Do(request)
 	c.Assert(err, nil)
-	// Assert the status code to verify successful upload.
-	c.Assert(response.StatusCode, http.StatusOK)
+	verifyError(c, response, "InvalidLocationConstraint", "The specified location-constraint is not valid", http.StatusBadRequest)
 
-	// prepare HTTP requests to download the object.
-	request, err = newTestSignedRequest(http.MethodGet, getPutObjectURL(s.endPoint, bucketName, objectName),
-		0, nil, s.accessKey, s.secretKey, s.signer)
+	if s.signer != signerV4 {
+		return
+	}
+
+	// a body which does not match the signed payload is rejected.
+	body = createBucketConfig(globalSite.Region)
+	request, err = newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		int64(len(body)), bytes.NewReader(body), s.accessKey, s.secretKey, s.signer)
 	c.Assert(err, nil)
+	tampered := bytes.Replace(body, []byte(globalSite.Region), []byte(strings.Repeat("x", len(globalSite.Region))), 1)
+	request.Body = ioutil.NopCloser(bytes.NewReader(tampered))
 
-	// execute the HTTP request to download the object.
 	response, err = s.client.Do(request)
 	c.Assert(err, nil)
-	c.Assert(response.StatusCode, http.StatusOK)
-	// extract the content from response body.
-	getContent, err := ioutil.ReadAll(response.Body)
-	c.Assert(err, nil)
-
-	// Compare putContent and getContent.
-	c.Assert(string(getContent), putContent)
+	verifyError(c, response, "BadDigest", "The Content-Md5 you specified did not match what we received.", http.StatusBadRequest)
 }
 
-// TestGetObjectLarge11MiB - Tests validate fetching of an object of size 11MB.
-func (s *TestSuiteCommon) TestGetObjectLarge11MiB(c *check) {
-	// generate a random bucket name.
+// TestBucketVersioning - toggles bucket versioning and validates
+// that object writes are versioned once it is enabled.
+func (s *TestSuiteCommon) TestBucketVersioning(c *check) {
 	bucketName := getRandomBucketName()
-	// HTTP request to create the bucket.
 	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
 		0, nil, s.accessKey, s.secretKey, s.signer)
 	c.Assert(err, nil)
 
-	// execute the HTTP request.
 	response, err := s.client.Do(request)
 	c.Assert(err, nil)
 	c.Assert(response.StatusCode, http.StatusOK)
 
-	var buffer bytes.Buffer
-	line := `1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
-	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
-	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
-	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
-	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
+	getVersioning := func() versioning.Versioning {
+		request, err := newTestSignedRequest(http.MethodGet, getBucketVersioningURL(s.endPoint, bucketName),
+			0, nil, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+		defer response.Body.Close()
+
+		var v versioning.Versioning
+		c.Assert(xml.NewDecoder(response.Body).Decode(&v), nil)
+		return v
+	}
+	putVersioning := func(status string) {
+		body := []byte(`<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>` +
+			status + `</Status></VersioningConfiguration>`)
+		request, err := newTestSignedRequest(http.MethodPut, getBucketVersioningURL(s.endPoint, bucketName),
+			int64(len(body)), bytes.NewReader(body), s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+	}
+	putObject := func() string {
+		buffer := bytes.NewReader([]byte("hello world"))
+		request, err := newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "object"),
+			int64(buffer.Len()), buffer, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+		return response.Header.Get(xhttp.AmzVersionID)
+	}
+
+	// versioning is not configured on a new bucket.
+	c.Assert(getVersioning().Status, versioning.State(""))
+	c.Assert(putObject(), "")
+
+	// once enabled, every write is assigned a new version.
+	putVersioning("Enabled")
+	c.Assert(getVersioning().Status, versioning.Enabled)
+	versionID := putObject()
+	if versionID == "" || versionID == nullVersionID {
+		c.Fatalf("expected a version ID, got %q", versionID)
+	}
+	if nextVersionID := putObject(); nextVersionID == "" || nextVersionID == versionID {
+		c.Fatalf("expected a new version ID, got %q", nextVersionID)
+	}
+
+	// the versioning state survives a restart.
+	s.RestartTestServer(c)
+	c.Assert(getVersioning().Status, versioning.Enabled)
+
+	// once suspended, writes replace the null version.
+	putVersioning("Suspended")
+	c.Assert(getVersioning().Status, versioning.Suspended)
+	c.Assert(putObject(), nullVersionID)
+}
+
+// TestListObjectVersions - validates the listing of versions and delete
+// markers, ordered by key and then by recency, across paginated requests.
+func (s *TestSuiteCommon) TestListObjectVersions(c *check) {
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	body := []byte(`<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Enabled</Status></VersioningConfiguration>`)
+	request, err = newTestSignedRequest(http.MethodPut, getBucketVersioningURL(s.endPoint, bucketName),
+		int64(len(body)), bytes.NewReader(body), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	putObject := func(objectName string) string {
+		buffer := bytes.NewReader([]byte("hello world"))
+		request, err := newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, objectName),
+			int64(buffer.Len()), buffer, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+		return response.Header.Get(xhttp.AmzVersionID)
+	}
+
+	type listEntry struct {
+		XMLName   xml.Name
+		Key       string
+		VersionID string `xml:"VersionId"`
+		IsLatest  bool
+		Owner     Owner
+	}
+
+	// "a" ends up with two versions and a delete marker on top.
+	v1 := putObject("a")
+	v2 := putObject("a")
+	request, err = newTestSignedRequest(http.MethodDelete, getDeleteObjectURL(s.endPoint, bucketName, "a"),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+	c.Assert(response.Header.Get(xhttp.AmzDeleteMarker), "true")
+	dm := response.Header.Get(xhttp.AmzVersionID)
+	v3 := putObject("b")
+
+	expected := []listEntry{
+		{XMLName: xml.Name{Local: "DeleteMarker"}, Key: "a", VersionID: dm, IsLatest: true},
+		{XMLName: xml.Name{Local: "Version"}, Key: "a", VersionID: v2},
+		{XMLName: xml.Name{Local: "Version"}, Key: "a", VersionID: v1},
+		{XMLName: xml.Name{Local: "Version"}, Key: "b", VersionID: v3, IsLatest: true},
+	}
+
+	// page through the versions two at a time.
+	var entries []listEntry
+	var keyMarker, versionIDMarker string
+	for {
+		request, err = newTestSignedRequest(http.MethodGet,
+			getListObjectVersionsURL(s.endPoint, bucketName, "2", keyMarker, versionIDMarker),
+			0, nil, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err = s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+
+		var result struct {
+			XMLName             xml.Name `xml:"ListVersionsResult"`
+			Name                string
+			Prefix              string
+			Delimiter           string
+			KeyMarker           string
+			VersionIDMarker     string `xml:"VersionIdMarker"`
+			NextKeyMarker       string
+			NextVersionIDMarker string `xml:"NextVersionIdMarker"`
+			MaxKeys             int
+			IsTruncated         bool
+			Entries             []listEntry `xml:",any"`
+		}
+		c.Assert(xml.NewDecoder(response.Body).Decode(&result), nil)
+		response.Body.Close()
+
+		if len(result.Entries) > 2 {
+			c.Fatalf("expected at most 2 entries, got %d", len(result.Entries))
+		}
+		entries = append(entries, result.Entries...)
+		if !result.IsTruncated {
+			break
+		}
+		keyMarker, versionIDMarker = result.NextKeyMarker, result.NextVersionIDMarker
+	}
+
+	if len(entries) != len(expected) {
+		c.Fatalf("expected %d entries, got %d", len(expected), len(entries))
+	}
+	for i, entry := range entries {
+		c.Assert(entry.XMLName.Local, expected[i].XMLName.Local)
+		c.Assert(entry.Key, expected[i].Key)
+		c.Assert(entry.VersionID, expected[i].VersionID)
+		c.Assert(entry.IsLatest, expected[i].IsLatest)
+		c.Assert(entry.Owner.ID, globalMinioDefaultOwnerID)
+	}
+}
+
+// TestObjectTagging - validates setting, fetching and removing object
+// tags through the tagging sub-resource and the x-amz-tagging header.
+func (s *TestSuiteCommon) TestObjectTagging(c *check) {
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// tags can be set at upload time with the x-amz-tagging header.
+	objectName := "object"
+	buffer := bytes.NewReader([]byte("hello world"))
+	headers := map[string]string{xhttp.AmzObjectTagging: "project=minio&env=test"}
+	if s.signer == signerV2 {
+		request, err = newTestSignedRequestV2(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, objectName),
+			int64(buffer.Len()), buffer, s.accessKey, s.secretKey, headers)
+	} else {
+		request, err = newTestSignedRequestV4(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, objectName),
+			int64(buffer.Len()), buffer, s.accessKey, s.secretKey, headers)
+	}
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	getTags := func() map[string]string {
+		request, err := newTestSignedRequest(http.MethodGet, getObjectTaggingURL(s.endPoint, bucketName, objectName),
+			0, nil, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+		defer response.Body.Close()
+
+		t, err := tags.ParseObjectXML(response.Body)
+		c.Assert(err, nil)
+		return t.ToMap()
+	}
+	putTags := func(tagSet string) *http.Response {
+		body := []byte(`<Tagging xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><TagSet>` + tagSet + `</TagSet></Tagging>`)
+		request, err := newTestSignedRequest(http.MethodPut, getObjectTaggingURL(s.endPoint, bucketName, objectName),
+			int64(len(body)), bytes.NewReader(body), s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		return response
+	}
+	tag := func(key, value string) string {
+		return "<Tag><Key>" + key + "</Key><Value>" + value + "</Value></Tag>"
+	}
+
+	c.Assert(getTags(), map[string]string{"project": "minio", "env": "test"})
+
+	// the tagging sub-resource replaces the whole tag set.
+	response = putTags(tag("owner", "alice"))
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(getTags(), map[string]string{"owner": "alice"})
+
+	// an object accepts at most 10 tags.
+	var tagSet strings.Builder
+	for i := 0; i < 11; i++ {
+		tagSet.WriteString(tag(fmt.Sprintf("key%d", i), "value"))
+	}
+	response = putTags(tagSet.String())
+	verifyError(c, response, "BadRequest", "Tags cannot be more than 10", http.StatusBadRequest)
+
+	// tag keys are limited to 128 characters and values to 256 characters.
+	response = putTags(tag(strings.Repeat("k", 129), "value"))
+	verifyError(c, response, "InvalidTag", "The TagKey you have provided is invalid", http.StatusBadRequest)
+	response = putTags(tag("key", strings.Repeat("v", 257)))
+	verifyError(c, response, "InvalidTag", "The TagValue you have provided is invalid", http.StatusBadRequest)
+	c.Assert(getTags(), map[string]string{"owner": "alice"})
+
+	// anonymous requests are not allowed to read or change tags.
+	request, err = newTestRequest(http.MethodGet, getObjectTaggingURL(s.endPoint, bucketName, objectName), 0, nil)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	verifyError(c, response, "AccessDenied", "Access Denied.", http.StatusForbidden)
+
+	// removing the tags leaves an empty tag set.
+	request, err = newTestSignedRequest(http.MethodDelete, getObjectTaggingURL(s.endPoint, bucketName, objectName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+	c.Assert(getTags(), map[string]string{})
+}
+
+// TestCannedACL - validates that x-amz-acl canned ACLs grant
+// anonymous access to buckets and objects.
+func (s *TestSuiteCommon) TestCannedACL(c *check) {
+	do := func(method, urlStr string, data []byte, acl string) *http.Response {
+		headers := map[string]string{}
+		if acl != "" {
+			headers[xhttp.AmzACL] = acl
+		}
+		var request *http.Request
+		var err error
+		if s.signer == signerV2 {
+			request, err = newTestSignedRequestV2(method, urlStr, int64(len(data)), bytes.NewReader(data), s.accessKey, s.secretKey, headers)
+		} else {
+			request, err = newTestSignedRequestV4(method, urlStr, int64(len(data)), bytes.NewReader(data), s.accessKey, s.secretKey, headers)
+		}
+		c.Assert(err, nil)
+
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		return response
+	}
+	doAnonymous := func(method, urlStr string, data []byte) *http.Response {
+		request, err := newTestRequest(method, urlStr, int64(len(data)), bytes.NewReader(data))
+		c.Assert(err, nil)
+
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		return response
+	}
+	data := []byte("hello world")
+
+	// unknown canned ACLs are rejected.
+	bucketName := getRandomBucketName()
+	response := do(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName), nil, "public")
+	verifyError(c, response, "InvalidArgument", "The canned ACL specified is not valid.", http.StatusBadRequest)
+
+	response = do(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName), nil, "private")
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	response = do(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "object"), data, "public")
+	verifyError(c, response, "InvalidArgument", "The canned ACL specified is not valid.", http.StatusBadRequest)
+
+	// objects are private unless uploaded with a public canned ACL.
+	response = do(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "private"), data, "")
+	c.Assert(response.StatusCode, http.StatusOK)
+	response = do(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "owner"), data, "bucket-owner-full-control")
+	c.Assert(response.StatusCode, http.StatusOK)
+	response = do(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "public"), data, "public-read")
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	response = doAnonymous(http.MethodGet, getGetObjectURL(s.endPoint, bucketName, "private"), nil)
+	c.Assert(response.StatusCode, http.StatusForbidden)
+	response = doAnonymous(http.MethodGet, getGetObjectURL(s.endPoint, bucketName, "owner"), nil)
+	c.Assert(response.StatusCode, http.StatusForbidden)
+	response = doAnonymous(http.MethodGet, getGetObjectURL(s.endPoint, bucketName, "public"), nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	responseBody, err := ioutil.ReadAll(response.Body)
+	c.Assert(err, nil)
+	c.Assert(responseBody, data)
+
+	// a public-read object is not writable anonymously.
+	response = doAnonymous(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "public"), data)
+	c.Assert(response.StatusCode, http.StatusForbidden)
+
+	// a public-read bucket allows anonymous reads of all objects.
+	bucketName = getRandomBucketName()
+	response = do(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName), nil, "public-read")
+	c.Assert(response.StatusCode, http.StatusOK)
+	response = do(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "object"), data, "")
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	response = doAnonymous(http.MethodGet, getGetObjectURL(s.endPoint, bucketName, "object"), nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	response = doAnonymous(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "object"), data)
+	c.Assert(response.StatusCode, http.StatusForbidden)
+
+	// a public-read-write bucket also allows anonymous writes.
+	bucketName = getRandomBucketName()
+	response = do(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName), nil, "public-read-write")
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	response = doAnonymous(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "object"), data)
+	c.Assert(response.StatusCode, http.StatusOK)
+	response = doAnonymous(http.MethodGet, getGetObjectURL(s.endPoint, bucketName, "object"), nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+}
+
+// TestObjectACL - validates setting and fetching object ACL grant
+// documents and that the grants are honored for anonymous requests.
+func (s *TestSuiteCommon) TestObjectACL(c *check) {
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	objectName := "object"
+	buffer := bytes.NewReader([]byte("hello world"))
+	request, err = newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, objectName),
+		int64(buffer.Len()), buffer, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	getACL := func() []grant {
+		request, err := newTestSignedRequest(http.MethodGet, getObjectACLURL(s.endPoint, bucketName, objectName),
+			0, nil, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+		defer response.Body.Close()
+
+		var acl accessControlPolicy
+		c.Assert(xml.NewDecoder(response.Body).Decode(&acl), nil)
+		c.Assert(acl.Owner.ID, globalMinioDefaultOwnerID)
+		return acl.AccessControlList.Grants
+	}
+	putACL := func(grantee, permission string) *http.Response {
+		body := []byte(`<AccessControlPolicy xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><AccessControlList><Grant>` +
+			grantee + `<Permission>` + permission + `</Permission></Grant></AccessControlList></AccessControlPolicy>`)
+		request, err := newTestSignedRequest(http.MethodPut, getObjectACLURL(s.endPoint, bucketName, objectName),
+			int64(len(body)), bytes.NewReader(body), s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		return response
+	}
+	anonymousGet := func(urlStr string) int {
+		request, err := newTestRequest(http.MethodGet, urlStr, 0, nil)
+		c.Assert(err, nil)
+
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		response.Body.Close()
+		return response.StatusCode
+	}
+	groupGrantee := func(uri string) string {
+		return `<Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="Group"><URI>` + uri + `</URI></Grantee>`
+	}
+
+	// only the owner has access to a new object.
+	grants := getACL()
+	c.Assert(len(grants), 1)
+	c.Assert(grants[0].Grantee.XMLXSI, "CanonicalUser")
+	c.Assert(grants[0].Grantee.ID, globalMinioDefaultOwnerID)
+	c.Assert(grants[0].Permission, "FULL_CONTROL")
+	c.Assert(anonymousGet(getGetObjectURL(s.endPoint, bucketName, objectName)), http.StatusForbidden)
+
+	// granting READ to all users allows anonymous reads of the object, not of its ACL.
+	response = putACL(groupGrantee("http://acs.amazonaws.com/groups/global/AllUsers"), "READ")
+	c.Assert(response.StatusCode, http.StatusOK)
+	grants = getACL()
+	c.Assert(len(grants), 2)
+	c.Assert(grants[1].Grantee.XMLXSI, "Group")
+	c.Assert(grants[1].Grantee.URI, "http://acs.amazonaws.com/groups/global/AllUsers")
+	c.Assert(grants[1].Permission, "READ")
+	c.Assert(anonymousGet(getGetObjectURL(s.endPoint, bucketName, objectName)), http.StatusOK)
+	c.Assert(anonymousGet(getObjectACLURL(s.endPoint, bucketName, objectName)), http.StatusForbidden)
+
+	// an explicit deny in the bucket policy overrides the grant.
+	denyPolicy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"]}]}`, bucketName)
+	request, err = newTestSignedRequest(http.MethodPut, getPutPolicyURL(s.endPoint, bucketName),
+		int64(len(denyPolicy)), bytes.NewReader([]byte(denyPolicy)), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+	c.Assert(anonymousGet(getGetObjectURL(s.endPoint, bucketName, objectName)), http.StatusForbidden)
+
+	request, err = newTestSignedRequest(http.MethodDelete, getDeletePolicyURL(s.endPoint, bucketName), 0, nil,
+		s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+	c.Assert(anonymousGet(getGetObjectURL(s.endPoint, bucketName, objectName)), http.StatusOK)
+
+	// invalid grants are rejected and leave the ACL untouched.
+	response = putACL(groupGrantee("http://acs.amazonaws.com/groups/global/AllUsers"), "EVERYTHING")
+	verifyError(c, response, "MalformedACLError", "The XML you provided was not well-formed or did not validate against our published schema.", http.StatusBadRequest)
+	response = putACL(groupGrantee("http://acs.amazonaws.com/groups/s3/LogDelivery"), "READ")
+	verifyError(c, response, "MalformedACLError", "The XML you provided was not well-formed or did not validate against our published schema.", http.StatusBadRequest)
+	response = putACL(`<Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"></Grantee>`, "READ")
+	verifyError(c, response, "MalformedACLError", "The XML you provided was not well-formed or did not validate against our published schema.", http.StatusBadRequest)
+	response = putACL(`<Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="AmazonCustomerByEmail"><EmailAddress>user@example.com</EmailAddress></Grantee>`, "READ")
+	verifyError(c, response, "NotImplemented", "A header you provided implies functionality that is not implemented", http.StatusNotImplemented)
+	c.Assert(len(getACL()), 2)
+
+	// a canned ACL replaces the grants.
+	request, err = newTestRequest(http.MethodPut, getObjectACLURL(s.endPoint, bucketName, objectName), 0, nil)
+	c.Assert(err, nil)
+	request.Header.Set(xhttp.AmzACL, "private")
+	if s.signer == signerV2 {
+		c.Assert(signRequestV2(request, s.accessKey, s.secretKey), nil)
+	} else {
+		c.Assert(signRequestV4(request, s.accessKey, s.secretKey), nil)
+	}
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(len(getACL()), 1)
+	c.Assert(anonymousGet(getGetObjectURL(s.endPoint, bucketName, objectName)), http.StatusForbidden)
+}
+
+func (s *TestSuiteCommon) TestGetObjectLarge10MiB(c *check) {
+	// generate a random bucket name.
+	bucketName := getRandomBucketName()
+	// form HTTP request to create the bucket.
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	// execute the HTTP request to create the bucket.
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	var buffer bytes.Buffer
+	line := `1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
+	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
+	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
+	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
+	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
+	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
+	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
+	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
+	1234567890,1234567890,1234567890,1234567890,1234567890,123"`
+	// Create 10MiB content where each line contains 1024 characters.
+	for i := 0; i < 10*1024; i++ {
+		buffer.WriteString(fmt.Sprintf("[%05d] %s\n", i, line))
+	}
+	putContent := buffer.String()
+
+	buf := bytes.NewReader([]byte(putContent))
+
+	objectName := "test-big-object"
+	// create HTTP request for object upload.
+	request, err = newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, objectName),
+		int64(buf.Len()), buf, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	// execute the HTTP request.
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	// Assert the status code to verify successful upload.
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// prepare HTTP requests to download the object.
+	request, err = newTestSignedRequest(http.MethodGet, getPutObjectURL(s.endPoint, bucketName, objectName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	// execute the HTTP request to download the object.
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	// extract the content from response body.
+	getContent, err := ioutil.ReadAll(response.Body)
+	c.Assert(err, nil)
+
+	// Compare putContent and getContent.
+	c.Assert(string(getContent), putContent)
+}
+
+// TestGetObjectLarge11MiB - Tests validate fetching of an object of size 11MB.
+func (s *TestSuiteCommon) TestGetObjectLarge11MiB(c *check) {
+	// generate a random bucket name.
+	bucketName := getRandomBucketName()
+	// HTTP request to create the bucket.
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	// execute the HTTP request.
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	var buffer bytes.Buffer
+	line := `1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
+	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
+	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
+	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
+	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
 	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
 	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
 	1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,1234567890,
@@ -2500,6 +3462,161 @@ func (s *TestSuiteCommon) TestObjectValidMD5(c *check) {
 	verifyError(c, response, "SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided. Check your key and signing method.", http.StatusForbidden)
 }
 
+// TestObjectContentMD5Mismatch - validates that uploads with a correctly signed
+// Content-Md5 header not matching the body are rejected with BadDigest.
+func (s *TestSuiteCommon) TestObjectContentMD5Mismatch(c *check) {
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	objectName := "test-object"
+	request, err = newTestSignedRequest(http.MethodPost, getNewMultipartURL(s.endPoint, bucketName, objectName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	newResponse := &InitiateMultipartUploadResponse{}
+	c.Assert(xml.NewDecoder(response.Body).Decode(newResponse), nil)
+
+	data := bytes.Repeat([]byte("0123456789abcdef"), 5*humanize.MiByte/16)
+	upload := func(urlStr, contentMD5 string) *http.Response {
+		headers := map[string]string{"Content-Md5": contentMD5}
+		var request *http.Request
+		var err error
+		if s.signer == signerV2 {
+			request, err = newTestSignedRequestV2(http.MethodPut, urlStr, int64(len(data)), bytes.NewReader(data), s.accessKey, s.secretKey, headers)
+		} else {
+			request, err = newTestSignedRequestV4(http.MethodPut, urlStr, int64(len(data)), bytes.NewReader(data), s.accessKey, s.secretKey, headers)
+		}
+		c.Assert(err, nil)
+
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		return response
+	}
+
+	for _, urlStr := range []string{
+		getPutObjectURL(s.endPoint, bucketName, objectName),
+		getPartUploadURL(s.endPoint, bucketName, objectName, newResponse.UploadID, "1"),
+	} {
+		// the MD5 of different content is rejected.
+		response = upload(urlStr, getMD5HashBase64([]byte("hello world")))
+		verifyError(c, response, "BadDigest", "The Content-Md5 you specified did not match what we received.", http.StatusBadRequest)
+
+		// a value which is not a base64 encoded MD5 is rejected.
+		response = upload(urlStr, "not-an-md5")
+		verifyError(c, response, "InvalidDigest", "The Content-Md5 you specified is not valid.", http.StatusBadRequest)
+
+		// the MD5 of the body is accepted.
+		response = upload(urlStr, getMD5HashBase64(data))
+		c.Assert(response.StatusCode, http.StatusOK)
+	}
+}
+
+// TestObjectETagConsistency - Validates that single PUT objects get the
+// hex MD5 of their content as ETag, multipart objects the MD5 of the part
+// MD5s suffixed by the number of parts, and that HEAD and GET return the
+// same ETag as the upload.
+func (s *TestSuiteCommon) TestObjectETagConsistency(c *check) {
+	// generate a random bucket name.
+	bucketName := getRandomBucketName()
+	// HTTP request to create the bucket.
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	// execute the HTTP request to create bucket.
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// verifyETag checks HEAD and GET return the expected ETag.
+	verifyETag := func(objectName, expectedETag string) {
+		for _, method := range []string{http.MethodHead, http.MethodGet} {
+			request, err := newTestSignedRequest(method, getGetObjectURL(s.endPoint, bucketName, objectName),
+				0, nil, s.accessKey, s.secretKey, s.signer)
+			c.Assert(err, nil)
+			response, err := s.client.Do(request)
+			c.Assert(err, nil)
+			c.Assert(response.StatusCode, http.StatusOK)
+			c.Assert(response.Header.Get(xhttp.ETag), expectedETag)
+			response.Body.Close()
+		}
+	}
+
+	// Single PUT objects return the quoted hex MD5 of their content.
+	data := []byte("hello world")
+	md5Sum := md5.Sum(data)
+	expectedETag := "\"" + hex.EncodeToString(md5Sum[:]) + "\""
+	request, err = newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, "single-part"),
+		int64(len(data)), bytes.NewReader(data), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(response.Header.Get(xhttp.ETag), expectedETag)
+	verifyETag("single-part", expectedETag)
+
+	// Multipart objects return the quoted MD5 of the concatenated
+	// part MD5s, followed by '-' and the number of parts.
+	objectName := "multi-part"
+	request, err = newTestSignedRequest(http.MethodPost, getNewMultipartURL(s.endPoint, bucketName, objectName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	newResponse := &InitiateMultipartUploadResponse{}
+	c.Assert(xml.NewDecoder(response.Body).Decode(newResponse), nil)
+	uploadID := newResponse.UploadID
+
+	parts := [][]byte{
+		bytes.Repeat([]byte("a"), 5*humanize.MiByte),
+		bytes.Repeat([]byte("b"), 5*humanize.MiByte),
+		[]byte("c"),
+	}
+	var partMD5s []byte
+	completeUploads := &CompleteMultipartUpload{}
+	for i, part := range parts {
+		partMD5 := md5.Sum(part)
+		partMD5s = append(partMD5s, partMD5[:]...)
+
+		request, err = newTestSignedRequest(http.MethodPut, getPartUploadURL(s.endPoint, bucketName, objectName, uploadID, strconv.Itoa(i+1)),
+			int64(len(part)), bytes.NewReader(part), s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+		response, err = s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+		c.Assert(response.Header.Get(xhttp.ETag), "\""+hex.EncodeToString(partMD5[:])+"\"")
+		completeUploads.Parts = append(completeUploads.Parts, CompletePart{
+			PartNumber: i + 1,
+			ETag:       response.Header.Get(xhttp.ETag),
+		})
+	}
+	multipartMD5 := md5.Sum(partMD5s)
+	expectedETag = "\"" + hex.EncodeToString(multipartMD5[:]) + "-3\""
+
+	completeBytes, err := xml.Marshal(completeUploads)
+	c.Assert(err, nil)
+	request, err = newTestSignedRequest(http.MethodPost, getCompleteMultipartUploadURL(s.endPoint, bucketName, objectName, uploadID),
+		int64(len(completeBytes)), bytes.NewReader(completeBytes), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	completeResponse := &CompleteMultipartUploadResponse{}
+	c.Assert(xml.NewDecoder(response.Body).Decode(completeResponse), nil)
+	c.Assert(completeResponse.ETag, expectedETag)
+	verifyETag(objectName, expectedETag)
+}
+
 // TestObjectMultipart - Initiates a NewMultipart upload, uploads 2 parts,
 // completes the multipart upload and validates the status of the operation.
 func (s *TestSuiteCommon) TestObjectMultipart(c *check) {
@@ -2516,9 +3633,20 @@ func (s *TestSuiteCommon) TestObjectMultipart(c *check) {
 	c.Assert(response.StatusCode, 200)
 
 	objectName := "test-multipart-object"
+	// user metadata and content type set at initiate are applied to the completed object.
+	metadata := map[string]string{
+		"Content-Type":      "application/x-multipart-test",
+		"X-Amz-Meta-Origin": "initiate",
+		"X-Amz-Meta-Parts":  "2",
+	}
 	// construct HTTP request to initiate a NewMultipart upload.
-	request, err = newTestSignedRequest(http.MethodPost, getNewMultipartURL(s.endPoint, bucketName, objectName),
-		0, nil, s.accessKey, s.secretKey, s.signer)
+	if s.signer == signerV2 {
+		request, err = newTestSignedRequestV2(http.MethodPost, getNewMultipartURL(s.endPoint, bucketName, objectName),
+			0, nil, s.accessKey, s.secretKey, metadata)
+	} else {
+		request, err = newTestSignedRequestV4(http.MethodPost, getNewMultipartURL(s.endPoint, bucketName, objectName),
+			0, nil, s.accessKey, s.secretKey, metadata)
+	}
 	c.Assert(err, nil)
 
 	// execute the HTTP request initiating the new multipart upload.
@@ -2607,4 +3735,154 @@ func (s *TestSuiteCommon) TestObjectMultipart(c *check) {
 	}
 	etag := getCompleteMultipartMD5(parts)
 	c.Assert(canonicalizeETag(response.Header.Get(xhttp.ETag)), etag)
+
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		request, err = newTestSignedRequest(method, getGetObjectURL(s.endPoint, bucketName, objectName),
+			0, nil, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+		response, err = s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+		for k, v := range metadata {
+			c.Assert(response.Header.Get(k), v)
+		}
+		c.Assert(response.ContentLength, int64(5*humanize.MiByte+1))
+		_, err = io.Copy(ioutil.Discard, response.Body)
+		c.Assert(err, nil)
+		response.Body.Close()
+	}
+}
+
+// TestObjectKeyEncoding - validates that URL encoded object keys are
+// decoded once for storage while the encoded path is signed.
+func (s *TestSuiteCommon) TestObjectKeyEncoding(c *check) {
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err := s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	getObject := func(objectName string) string {
+		request, err := newTestSignedRequest(http.MethodGet, getGetObjectURL(s.endPoint, bucketName, objectName),
+			0, nil, s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+		data, err := ioutil.ReadAll(response.Body)
+		c.Assert(err, nil)
+		return string(data)
+	}
+
+	objectNames := []string{"object with spaces", "object#with#hash", "dir with space/sub#dir/object 1#"}
+	for _, objectName := range objectNames {
+		request, err = newTestSignedRequest(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, objectName),
+			int64(len(objectName)), bytes.NewReader([]byte(objectName)), s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+
+		response, err = s.client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+
+		c.Assert(getObject(objectName), objectName)
+	}
+
+	// An encoded slash is signed as sent and decoded
+	// into a slash of the object key.
+	data := []byte("encoded slash")
+	urlStr := s.endPoint + SlashSeparator + bucketName + SlashSeparator + "folder%2Fobject"
+	request, err = newTestSignedRequestV4(http.MethodPut, urlStr, int64(len(data)), bytes.NewReader(data),
+		s.accessKey, s.secretKey, nil)
+	c.Assert(err, nil)
+	c.Assert(request.URL.EscapedPath(), SlashSeparator+bucketName+SlashSeparator+"folder%2Fobject")
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(getObject("folder/object"), string(data))
+
+	request, err = newTestSignedRequestV4(http.MethodGet, urlStr, 0, nil, s.accessKey, s.secretKey, nil)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	request, err = newTestSignedRequest(http.MethodGet, getListObjectsV1URL(s.endPoint, bucketName, "", "1000", ""),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	response, err = s.client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	listV1 := ListObjectsResponse{}
+	c.Assert(xml.NewDecoder(response.Body).Decode(&listV1), nil)
+	var listed []string
+	for _, object := range listV1.Contents {
+		listed = append(listed, object.Key)
+	}
+	c.Assert(listed, []string{"dir with space/sub#dir/object 1#", "folder/object", "object with spaces", "object#with#hash"})
+}
+
+// TestObjectStandardHeaders - validates that the standard object headers
+// are stored with the object and returned verbatim, a gzip encoded body
+// is returned as stored.
+func (s *TestSuiteCommon) TestObjectStandardHeaders(c *check) {
+	do := func(method, urlStr string, data []byte, headers map[string]string) *http.Response {
+		var request *http.Request
+		var err error
+		if s.signer == signerV2 {
+			request, err = newTestSignedRequestV2(method, urlStr, int64(len(data)), bytes.NewReader(data), s.accessKey, s.secretKey, headers)
+		} else {
+			request, err = newTestSignedRequestV4(method, urlStr, int64(len(data)), bytes.NewReader(data), s.accessKey, s.secretKey, headers)
+		}
+		c.Assert(err, nil)
+
+		response, err := s.client.Do(request)
+		c.Assert(err, nil)
+		return response
+	}
+
+	bucketName := getRandomBucketName()
+	response := do(http.MethodPut, getMakeBucketURL(s.endPoint, bucketName), nil, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write([]byte("hello world, hello world"))
+	c.Assert(err, nil)
+	c.Assert(gw.Close(), nil)
+	data := buf.Bytes()
+
+	headers := map[string]string{
+		"Content-Encoding":    "gzip",
+		"Content-Language":    "de-DE",
+		"Cache-Control":       "max-age=3600, must-revalidate",
+		"Content-Disposition": `attachment; filename="hello.txt.gz"`,
+		"Expires":             "Wed, 01 Dec 2094 16:00:00 GMT",
+	}
+	objectName := "gzipped-object"
+	response = do(http.MethodPut, getPutObjectURL(s.endPoint, bucketName, objectName), data, headers)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// Ask for the identity encoding so the client does not decode the body.
+	for _, method := range []string{http.MethodGet, http.MethodHead} {
+		response = do(method, getGetObjectURL(s.endPoint, bucketName, objectName), nil,
+			map[string]string{"Accept-Encoding": "identity"})
+		c.Assert(response.StatusCode, http.StatusOK)
+		for k, v := range headers {
+			c.Assert(response.Header.Get(k), v)
+		}
+		body, err := ioutil.ReadAll(response.Body)
+		c.Assert(err, nil)
+		response.Body.Close()
+		if method == http.MethodGet {
+			c.Assert(body, data)
+		}
+	}
 }
@@ -112,7 +112,7 @@ func unescapeQueries(encodedQuery string) (unescapedQueries []string, err error)
 func doesPresignV2SignatureMatch(r *http.Request) APIErrorCode {
 	// r.RequestURI will have raw encoded URI as sent by the client.
 	tokens := strings.SplitN(r.RequestURI, "?", 2)
-	encodedResource := tokens[0]
+	encodedResource := signedRequestPath(r, tokens[0])
 	encodedQuery := ""
 	if len(tokens) == 2 {
 		encodedQuery = tokens[1]
@@ -180,13 +180,19 @@ func doesPresignV2SignatureMatch(r *http.Request) APIErrorCode {
 	if !compareSignatureV2(gotSignature, expectedSignature) {
 		return ErrSignatureDoesNotMatch
 	}
+	setReqAuthInfo(r, credentialHeader{accessKey: cred.AccessKey})
 
 	r.Form.Del(xhttp.Expires)
 
 	return ErrNone
 }
 
+// getReqAccessKeyV2 - returns the credentials of the access key in the
+// request, see getReqAccessKeyV4.
 func getReqAccessKeyV2(r *http.Request) (auth.Credentials, bool, APIErrorCode) {
+	if info, ok := getReqAuthInfo(r); ok {
+		return checkKeyValid(r, info.accessKey)
+	}
 	if accessKey := r.Form.Get(xhttp.AmzAccessKeyID); accessKey != "" {
 		return checkKeyValid(r, accessKey)
 	}
@@ -256,7 +262,7 @@ func doesSignV2Match(r *http.Request) APIErrorCode {
 
 	// r.RequestURI will have raw encoded URI as sent by the client.
 	tokens := strings.SplitN(r.RequestURI, "?", 2)
-	encodedResource := tokens[0]
+	encodedResource := signedRequestPath(r, tokens[0])
 	encodedQuery := ""
 	if len(tokens) == 2 {
 		encodedQuery = tokens[1]
@@ -281,6 +287,7 @@ func doesSignV2Match(r *http.Request) APIErrorCode {
 	if !compareSignatureV2(v2Auth, expectedAuth) {
 		return ErrSignatureDoesNotMatch
 	}
+	setReqAuthInfo(r, credentialHeader{accessKey: cred.AccessKey})
 	return ErrNone
 }
 
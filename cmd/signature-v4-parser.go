@@ -49,7 +49,13 @@ func (c credentialHeader) getScope() string {
 	}, SlashSeparator)
 }
 
+// getReqAccessKeyV4 - returns the credentials of the access key in the
+// request, once the signature is verified the recorded access key is
+// used instead of parsing the request again.
 func getReqAccessKeyV4(r *http.Request, region string, stype serviceType) (auth.Credentials, bool, APIErrorCode) {
+	if info, ok := getReqAuthInfo(r); ok {
+		return checkKeyValid(r, info.accessKey)
+	}
 	ch, s3Err := parseCredentialHeader("Credential="+r.Form.Get(xhttp.AmzCredential), region, stype)
 	if s3Err != ErrNone {
 		// Strip off the Algorithm prefix.
@@ -100,8 +106,9 @@ func parseCredentialHeader(credElement string, region string, stype serviceType)
 	// Region is set to be empty, we use whatever was sent by the
 	// request and proceed further. This is a work-around to address
 	// an important problem for ListBuckets() getting signed with
-	// different regions.
-	if region == "" {
+	// different regions. The same applies when the operator
+	// allows signatures for any region.
+	if region == "" || globalAPIConfig.isRegionCheckDisabled() {
 		region = sRegion
 	}
 	// Should validate region, only if region is set.
@@ -249,6 +249,40 @@ func TestParseCredentialHeader(t *testing.T) {
 	}
 }
 
+// TestParseCredentialHeaderRegionCheck - validates that the signing region is
+// only enforced when the region check is enabled.
+func TestParseCredentialHeaderRegionCheck(t *testing.T) {
+	defer func(disabled bool) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.disableRegionCheck = disabled
+		globalAPIConfig.mu.Unlock()
+	}(globalAPIConfig.disableRegionCheck)
+
+	credStr := generateCredentialStr("Z7IXGOO6BZ0REAN1Q26I", UTCNow().Format(yyyymmdd), "eu-west-1", "s3", "aws4_request")
+
+	testCases := []struct {
+		disableRegionCheck bool
+		expectedErrCode    APIErrorCode
+	}{
+		{false, ErrAuthorizationHeaderMalformed},
+		{true, ErrNone},
+	}
+
+	for i, testCase := range testCases {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.disableRegionCheck = testCase.disableRegionCheck
+		globalAPIConfig.mu.Unlock()
+
+		ch, errCode := parseCredentialHeader(credStr, "us-west-1", "s3")
+		if errCode != testCase.expectedErrCode {
+			t.Fatalf("Test %d: Expected the APIErrCode to be %s, got %s", i+1, errorCodes[testCase.expectedErrCode].Code, errorCodes[errCode].Code)
+		}
+		if errCode == ErrNone && ch.scope.region != "eu-west-1" {
+			t.Fatalf("Test %d: Expected the signing region to be eu-west-1, got %s", i+1, ch.scope.region)
+		}
+	}
+}
+
 // TestParseSignature - validates the logic for extracting the signature string.
 func TestParseSignature(t *testing.T) {
 	testCases := []struct {
@@ -224,7 +224,7 @@ func extractSignedHeaders(signedHeaders []string, r *http.Request) (http.Header,
 			extractedSignedHeaders.Set(header, "100-continue")
 		case "host":
 			// Go http server removes "host" from Request.Header
-			extractedSignedHeaders.Set(header, r.Host)
+			extractedSignedHeaders.Set(header, getSignedHost(r))
 		case "transfer-encoding":
 			// Go http server removes "host" from Request.Header
 			extractedSignedHeaders[http.CanonicalHeaderKey(header)] = r.TransferEncoding
@@ -240,6 +240,30 @@ func extractSignedHeaders(signedHeaders []string, r *http.Request) (http.Header,
 	return extractedSignedHeaders, ErrNone
 }
 
+// getSignedHost returns the host used by the client to sign the request.
+// When the request is received from a trusted reverse proxy which has
+// rewritten the Host header, the original host is taken from the
+// X-Forwarded-Host header.
+func getSignedHost(r *http.Request) string {
+	fwdHost := r.Header.Get(xhttp.XForwardedHost)
+	if fwdHost == "" || !isTrustedProxyReq(r) {
+		return r.Host
+	}
+
+	// Only the first host is relevant when forwarded by multiple proxies.
+	host := strings.TrimSpace(strings.Split(fwdHost, ",")[0])
+
+	// Clients do not sign the default port of the scheme used.
+	proto := strings.TrimSpace(strings.Split(r.Header.Get(xhttp.XForwardedProto), ",")[0])
+	switch strings.ToLower(proto) {
+	case "https":
+		host = strings.TrimSuffix(host, ":443")
+	case "http":
+		host = strings.TrimSuffix(host, ":80")
+	}
+	return host
+}
+
 // Trim leading and trailing spaces and replace sequential spaces with one space, following Trimall()
 // in http://docs.aws.amazon.com/general/latest/gr/sigv4-create-canonical-request.html
 func signV4TrimAll(input string) string {
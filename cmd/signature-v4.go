@@ -36,6 +36,7 @@ import (
 	"strings"
 	"time"
 
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/minio/minio-go/v7/pkg/s3utils"
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio/internal/auth"
@@ -102,9 +103,9 @@ func getSignedHeaders(signedHeaders http.Header) string {
 //  <SignedHeaders>\n
 //  <HashedPayload>
 //
-func getCanonicalRequest(extractedSignedHeaders http.Header, payload, queryStr, urlPath, method string) string {
+func getCanonicalRequest(extractedSignedHeaders http.Header, payload, queryStr, escapedPath, method string) string {
 	rawQuery := strings.ReplaceAll(queryStr, "+", "%20")
-	encodedPath := s3utils.EncodePath(urlPath)
+	encodedPath := getCanonicalURI(escapedPath)
 	canonicalRequest := strings.Join([]string{
 		method,
 		encodedPath,
@@ -116,6 +117,22 @@ func getCanonicalRequest(extractedSignedHeaders http.Header, payload, queryStr,
 	return canonicalRequest
 }
 
+// getCanonicalURI returns the canonical URI for the request path as
+// sent by the client. Each path segment is decoded and URI encoded
+// again, so slashes encoded in an object key (%2F) stay encoded as
+// the client signed them while the key itself is decoded only once
+// by the API handlers.
+func getCanonicalURI(escapedPath string) string {
+	segments := strings.Split(escapedPath, SlashSeparator)
+	for i, segment := range segments {
+		if s, err := url.PathUnescape(segment); err == nil {
+			segment = s
+		}
+		segments[i] = strings.ReplaceAll(s3utils.EncodePath(segment), SlashSeparator, "%2F")
+	}
+	return strings.Join(segments, SlashSeparator)
+}
+
 // getScope generate a string of a specific date, an AWS region, and a service.
 func getScope(t time.Time, region string) string {
 	scope := strings.Join([]string{
@@ -145,6 +162,47 @@ func getSigningKey(secretKey string, t time.Time, region string, stype serviceTy
 	return signingKey
 }
 
+// signingKeyCacheSize is the number of derived signing keys kept in memory.
+const signingKeyCacheSize = 1024
+
+// signingKeyCache holds recently derived signing keys, it is safe for
+// concurrent use. The scope date is part of the key so a signing key
+// is never used past its scope date.
+var signingKeyCache, _ = lru.New(signingKeyCacheSize)
+
+type signingKeyCacheKey struct {
+	accessKey string
+	date      string
+	region    string
+	stype     serviceType
+}
+
+type signingKeyCacheEntry struct {
+	secretKey  string
+	signingKey []byte
+}
+
+// getCachedSigningKey returns the signing key of cred for the scope,
+// avoiding the HMAC derivation for scopes verified recently. Entries of
+// a previous secret key are replaced, so changing the secret takes
+// effect at once.
+func getCachedSigningKey(cred auth.Credentials, t time.Time, region string, stype serviceType) []byte {
+	k := signingKeyCacheKey{
+		accessKey: cred.AccessKey,
+		date:      t.Format(yyyymmdd),
+		region:    region,
+		stype:     stype,
+	}
+	if v, ok := signingKeyCache.Get(k); ok {
+		if e := v.(signingKeyCacheEntry); e.secretKey == cred.SecretKey {
+			return e.signingKey
+		}
+	}
+	signingKey := getSigningKey(cred.SecretKey, t, region, stype)
+	signingKeyCache.Add(k, signingKeyCacheEntry{secretKey: cred.SecretKey, signingKey: signingKey})
+	return signingKey
+}
+
 // getSignature final signature in hexadecimal form.
 func getSignature(signingKey []byte, stringToSign string) string {
 	return hex.EncodeToString(sumHMAC(signingKey, []byte(stringToSign)))
@@ -188,7 +246,7 @@ func doesPolicySignatureV4Match(formValues http.Header) (auth.Credentials, APIEr
 	}
 
 	// Get signing key.
-	signingKey := getSigningKey(cred.SecretKey, credHeader.scope.date, credHeader.scope.region, serviceS3)
+	signingKey := getCachedSigningKey(cred, credHeader.scope.date, credHeader.scope.region, serviceS3)
 
 	// Get signature.
 	newSignature := getSignature(signingKey, formValues.Get("Policy"))
@@ -226,9 +284,10 @@ func doesPresignedSignatureMatch(hashedPayload string, r *http.Request, region s
 		return errCode
 	}
 
-	// If the host which signed the request is slightly ahead in time (by less than globalMaxSkewTime) the
-	// request should still be allowed.
-	if pSignValues.Date.After(UTCNow().Add(globalMaxSkewTime)) {
+	// If the host which signed the request is slightly ahead in time (by less than the configured
+	// presigned clock skew) the request should still be allowed. The skew does not extend the
+	// validity of the request, X-Amz-Expires always takes precedence.
+	if pSignValues.Date.After(UTCNow().Add(globalAPIConfig.getPresignedClockSkew())) {
 		return ErrRequestNotReadyYet
 	}
 
@@ -309,13 +368,13 @@ func doesPresignedSignatureMatch(hashedPayload string, r *http.Request, region s
 	// Verify finally if signature is same.
 
 	// Get canonical request.
-	presignedCanonicalReq := getCanonicalRequest(extractedSignedHeaders, hashedPayload, encodedQuery, req.URL.Path, req.Method)
+	presignedCanonicalReq := getCanonicalRequest(extractedSignedHeaders, hashedPayload, encodedQuery, signedRequestPath(&req, req.URL.EscapedPath()), req.Method)
 
 	// Get string to sign from canonical request.
 	presignedStringToSign := getStringToSign(presignedCanonicalReq, t, pSignValues.Credential.getScope())
 
 	// Get hmac presigned signing key.
-	presignedSigningKey := getSigningKey(cred.SecretKey, pSignValues.Credential.scope.date,
+	presignedSigningKey := getCachedSigningKey(cred, pSignValues.Credential.scope.date,
 		pSignValues.Credential.scope.region, stype)
 
 	// Get new signature.
@@ -325,13 +384,23 @@ func doesPresignedSignatureMatch(hashedPayload string, r *http.Request, region s
 	if !compareSignatureV4(req.Form.Get(xhttp.AmzSignature), newSignature) {
 		return ErrSignatureDoesNotMatch
 	}
+	setReqAuthInfo(r, pSignValues.Credential)
 	return ErrNone
 }
 
-// doesSignatureMatch - Verify authorization header with calculated header in accordance with
-//     - http://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
-// returns ErrNone if signature matches.
-func doesSignatureMatch(hashedPayload string, r *http.Request, region string, stype serviceType) APIErrorCode {
+// signatureV4Computation - the intermediate values derived by the server
+// while computing the signature of a signature V4 request.
+type signatureV4Computation struct {
+	CanonicalRequest string `json:"canonicalRequest"`
+	StringToSign     string `json:"stringToSign"`
+	Signature        string `json:"signature,omitempty"`
+}
+
+// computeSignatureV4 - computes the canonical request, string to sign and
+// signature of the request along with the parsed authorization header.
+func computeSignatureV4(hashedPayload string, r *http.Request, region string, stype serviceType) (signValues, signatureV4Computation, APIErrorCode) {
+	var computed signatureV4Computation
+
 	// Copy request.
 	req := *r
 
@@ -341,54 +410,67 @@ func doesSignatureMatch(hashedPayload string, r *http.Request, region string, st
 	// Parse signature version '4' header.
 	signV4Values, err := parseSignV4(v4Auth, region, stype)
 	if err != ErrNone {
-		return err
+		return signV4Values, computed, err
 	}
 
 	// Extract all the signed headers along with its values.
 	extractedSignedHeaders, errCode := extractSignedHeaders(signV4Values.SignedHeaders, r)
 	if errCode != ErrNone {
-		return errCode
+		return signV4Values, computed, errCode
 	}
 
 	cred, _, s3Err := checkKeyValid(r, signV4Values.Credential.accessKey)
 	if s3Err != ErrNone {
-		return s3Err
+		return signV4Values, computed, s3Err
 	}
 
 	// Extract date, if not present throw error.
 	var date string
 	if date = req.Header.Get(xhttp.AmzDate); date == "" {
 		if date = r.Header.Get(xhttp.Date); date == "" {
-			return ErrMissingDateHeader
+			return signV4Values, computed, ErrMissingDateHeader
 		}
 	}
 
 	// Parse date header.
 	t, e := time.Parse(iso8601Format, date)
 	if e != nil {
-		return ErrMalformedDate
+		return signV4Values, computed, ErrMalformedDate
 	}
 
 	// Query string.
 	queryStr := req.Form.Encode()
 
 	// Get canonical request.
-	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, hashedPayload, queryStr, req.URL.Path, req.Method)
+	computed.CanonicalRequest = getCanonicalRequest(extractedSignedHeaders, hashedPayload, queryStr, signedRequestPath(&req, req.URL.EscapedPath()), req.Method)
 
 	// Get string to sign from canonical request.
-	stringToSign := getStringToSign(canonicalRequest, t, signV4Values.Credential.getScope())
+	computed.StringToSign = getStringToSign(computed.CanonicalRequest, t, signV4Values.Credential.getScope())
 
 	// Get hmac signing key.
-	signingKey := getSigningKey(cred.SecretKey, signV4Values.Credential.scope.date,
+	signingKey := getCachedSigningKey(cred, signV4Values.Credential.scope.date,
 		signV4Values.Credential.scope.region, stype)
 
 	// Calculate signature.
-	newSignature := getSignature(signingKey, stringToSign)
+	computed.Signature = getSignature(signingKey, computed.StringToSign)
+
+	return signV4Values, computed, ErrNone
+}
+
+// doesSignatureMatch - Verify authorization header with calculated header in accordance with
+//     - http://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
+// returns ErrNone if signature matches.
+func doesSignatureMatch(hashedPayload string, r *http.Request, region string, stype serviceType) APIErrorCode {
+	signV4Values, computed, s3Err := computeSignatureV4(hashedPayload, r, region, stype)
+	if s3Err != ErrNone {
+		return s3Err
+	}
 
 	// Verify if signature match.
-	if !compareSignatureV4(newSignature, signV4Values.Signature) {
+	if !compareSignatureV4(computed.Signature, signV4Values.Signature) {
 		return ErrSignatureDoesNotMatch
 	}
+	setReqAuthInfo(r, signV4Values.Credential)
 
 	// Return error none.
 	return ErrNone
@@ -18,12 +18,21 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/minio/minio-go/v7/pkg/signer"
+	"github.com/minio/minio/internal/auth"
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 func niceError(code APIErrorCode) string {
@@ -303,3 +312,437 @@ func TestDoesPresignedSignatureMatch(t *testing.T) {
 		}
 	}
 }
+
+func TestDoesPresignedSignatureMatchSignedHeaders(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		method   string
+		tamper   func(req *http.Request)
+		expected APIErrorCode
+	}{
+		// (0) Presigned GET with signed content-type.
+		{
+			method:   http.MethodGet,
+			expected: ErrNone,
+		},
+		// (1) Presigned PUT with signed content-type.
+		{
+			method:   http.MethodPut,
+			expected: ErrNone,
+		},
+		// (2) Signed content-type modified by the client.
+		{
+			method: http.MethodPut,
+			tamper: func(req *http.Request) {
+				req.Header.Set("Content-Type", "text/plain")
+			},
+			expected: ErrSignatureDoesNotMatch,
+		},
+		// (3) Signed content-type missing from the request.
+		{
+			method: http.MethodPut,
+			tamper: func(req *http.Request) {
+				req.Header.Del("Content-Type")
+			},
+			expected: ErrUnsignedHeaders,
+		},
+	}
+
+	for i, testCase := range testCases {
+		req, e := http.NewRequest(testCase.method, "http://host/bucket/object", nil)
+		if e != nil {
+			t.Fatalf("(%d) failed to create http.Request, got %v", i, e)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		req = signer.PreSignV4(*req, globalActiveCred.AccessKey, globalActiveCred.SecretKey, "", globalSite.Region, 60)
+		if signedHeaders := req.URL.Query().Get(xhttp.AmzSignedHeaders); signedHeaders != "content-type;host" {
+			t.Fatalf("(%d) expected content-type and host to be signed, got %s", i, signedHeaders)
+		}
+		if testCase.tamper != nil {
+			testCase.tamper(req)
+		}
+		req.ParseForm()
+
+		err := doesPresignedSignatureMatch(unsignedPayload, req, globalSite.Region, serviceS3)
+		if err != testCase.expected {
+			t.Errorf("(%d) expected to get %s, instead got %s", i, niceError(testCase.expected), niceError(err))
+		}
+	}
+}
+
+// Tests that the canonical request URI encodes every byte except the
+// unreserved set, and '/' only in the query string.
+func TestGetCanonicalRequestURIEncoding(t *testing.T) {
+	query := url.Values{
+		"prefix": []string{"a b/c+d=e*~"},
+	}
+	canonicalRequest := getCanonicalRequest(http.Header{}, unsignedPayload, query.Encode(),
+		"/bucket/a b+c=d/日本語~-_.", http.MethodGet)
+
+	lines := strings.Split(canonicalRequest, "\n")
+	if expected := "/bucket/a%20b%2Bc%3Dd/%E6%97%A5%E6%9C%AC%E8%AA%9E~-_."; lines[1] != expected {
+		t.Errorf("expected canonical URI %s, got %s", expected, lines[1])
+	}
+	if expected := "prefix=a%20b%2Fc%2Bd%3De%2A~"; lines[2] != expected {
+		t.Errorf("expected canonical query %s, got %s", expected, lines[2])
+	}
+}
+
+// Tests presigned URLs for object keys and query values which
+// need to be URI encoded in the canonical request.
+func TestDoesPresignedSignatureMatchSpecialCharacters(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		object string
+		query  url.Values
+	}{
+		{object: "object with spaces"},
+		{object: "object+with+plus"},
+		{object: "key=value"},
+		{object: "prefix/sub prefix/object"},
+		{object: "unreserved-_.~ and reserved !'()*&$@,;:"},
+		{object: "日本語/ファイル.txt"},
+		{object: "emoji-😀"},
+		{
+			object: "object",
+			query: url.Values{
+				"response-content-disposition": []string{`attachment; filename="a b+c/d=e.txt"`},
+				"response-content-type":        []string{"text/plain; charset=utf-8"},
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		req, e := http.NewRequest(http.MethodGet, "http://host/bucket/", nil)
+		if e != nil {
+			t.Fatalf("(%d) failed to create http.Request, got %v", i, e)
+		}
+		req.URL.Path = "/bucket/" + testCase.object
+		req.URL.RawQuery = testCase.query.Encode()
+
+		req = signer.PreSignV4(*req, globalActiveCred.AccessKey, globalActiveCred.SecretKey, "", globalSite.Region, 60)
+
+		// Parse the presigned URL as the server would receive it.
+		req, e = http.NewRequest(http.MethodGet, req.URL.String(), nil)
+		if e != nil {
+			t.Fatalf("(%d) failed to parse presigned URL, got %v", i, e)
+		}
+		if req.URL.Path != "/bucket/"+testCase.object {
+			t.Fatalf("(%d) expected path %q, got %q", i, "/bucket/"+testCase.object, req.URL.Path)
+		}
+		req.ParseForm()
+
+		if err := doesPresignedSignatureMatch(unsignedPayload, req, globalSite.Region, serviceS3); err != ErrNone {
+			t.Errorf("(%d) %q: expected to get success, instead got %s", i, testCase.object, niceError(err))
+		}
+	}
+}
+
+// Tests requests signed for path-style and virtual-hosted-style
+// addressing when a domain is configured with MINIO_DOMAIN.
+func TestVirtualHostStyleSignature(t *testing.T) {
+	defer func(domains []string) { globalDomainNames = domains }(globalDomainNames)
+	globalDomainNames = []string{"minio.example.com"}
+
+	// Register the full API router, which routes virtual-hosted-style
+	// requests for the configured domains.
+	ExecObjectLayerAPITest(t, testVirtualHostStyleSignature, nil)
+}
+
+func testVirtualHostStyleSignature(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T,
+) {
+	globalObjLayerMutex.Lock()
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+
+	signV4 := func(req *http.Request) *http.Request {
+		req.Header.Set(xhttp.AmzContentSha256, unsignedPayload)
+		return signer.SignV4(*req, credentials.AccessKey, credentials.SecretKey, "", globalSite.Region)
+	}
+	signV2 := func(virtualHost bool) func(req *http.Request) *http.Request {
+		return func(req *http.Request) *http.Request {
+			return signer.SignV2(*req, credentials.AccessKey, credentials.SecretKey, virtualHost)
+		}
+	}
+
+	pathStyle := "http://minio.example.com/" + bucketName + "/object"
+	virtualHostStyle := "http://" + bucketName + ".minio.example.com/object"
+
+	testCases := []struct {
+		method             string
+		url                string
+		sign               func(*http.Request) *http.Request
+		tamper             func(*http.Request)
+		expectedRespStatus int
+	}{
+		{http.MethodPut, virtualHostStyle, signV4, nil, http.StatusOK},
+		{http.MethodGet, virtualHostStyle, signV4, nil, http.StatusOK},
+		{http.MethodGet, pathStyle, signV4, nil, http.StatusOK},
+		{http.MethodGet, "http://" + bucketName + ".minio.example.com/?list-type=2", signV4, nil, http.StatusOK},
+		{http.MethodGet, virtualHostStyle, signV2(true), nil, http.StatusOK},
+		{http.MethodGet, pathStyle, signV2(false), nil, http.StatusOK},
+		// Signed for another bucket through the Host header.
+		{http.MethodGet, virtualHostStyle, signV4, func(req *http.Request) {
+			req.Host = "otherbucket.minio.example.com"
+		}, http.StatusForbidden},
+		{http.MethodGet, virtualHostStyle, signV2(true), func(req *http.Request) {
+			req.Host = "otherbucket.minio.example.com"
+		}, http.StatusForbidden},
+	}
+
+	for i, testCase := range testCases {
+		var body io.ReadSeeker
+		var size int64
+		if testCase.method == http.MethodPut {
+			body, size = bytes.NewReader([]byte("hello")), 5
+		}
+		req, err := newTestRequest(testCase.method, testCase.url, size, body)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		req = testCase.sign(req)
+		if testCase.tamper != nil {
+			testCase.tamper(req)
+		}
+
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Test %d: %s: %s %s: Expected the response status to be `%d`, but instead found `%d`: %s",
+				i+1, instanceType, testCase.method, testCase.url, testCase.expectedRespStatus, rec.Code, rec.Body.String())
+		}
+		if testCase.method == http.MethodGet && testCase.expectedRespStatus == http.StatusOK &&
+			!strings.Contains(testCase.url, "list-type") && rec.Body.String() != "hello" {
+			t.Errorf("Test %d: %s: Expected object content `hello`, got `%s`", i+1, instanceType, rec.Body.String())
+		}
+	}
+}
+
+func TestCompareSignatureV4(t *testing.T) {
+	signature := "f8c3f9f26a2bde3ba5b38d1b3a4bd53c4c3b25e5bb30f24a33bdf1e0b4e5d0a1"
+
+	if !compareSignatureV4(signature, signature) {
+		t.Fatal("expected identical signatures to match")
+	}
+	if compareSignatureV4(signature, "") {
+		t.Fatal("expected empty signature to not match")
+	}
+	if compareSignatureV4(signature, signature[:len(signature)-1]) {
+		t.Fatal("expected truncated signature to not match")
+	}
+	// Signatures differing in a single byte must not match,
+	// wherever the differing byte is.
+	for i := 0; i < len(signature); i++ {
+		nearMatch := []byte(signature)
+		nearMatch[i] ^= 0x01
+		if compareSignatureV4(signature, string(nearMatch)) {
+			t.Fatalf("expected signature differing at byte %d to not match", i)
+		}
+	}
+}
+
+func BenchmarkCompareSignatureV4(b *testing.B) {
+	signature := "f8c3f9f26a2bde3ba5b38d1b3a4bd53c4c3b25e5bb30f24a33bdf1e0b4e5d0a1"
+	for _, pos := range []int{0, len(signature) / 2, len(signature) - 1} {
+		buf := []byte(signature)
+		buf[pos] ^= 0x01
+		nearMatch := string(buf)
+		b.Run(fmt.Sprintf("differ-at-%d", pos), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				compareSignatureV4(signature, nearMatch)
+			}
+		})
+	}
+}
+
+func TestGetCachedSigningKey(t *testing.T) {
+	cred := auth.Credentials{AccessKey: "cachedsigningkey", SecretKey: "secret-1"}
+	now := UTCNow()
+
+	testCases := []struct {
+		cred   auth.Credentials
+		t      time.Time
+		region string
+		stype  serviceType
+	}{
+		{cred, now, "us-east-1", serviceS3},
+		// Served from the cache.
+		{cred, now, "us-east-1", serviceS3},
+		// Each part of the scope selects its own signing key.
+		{cred, now.Add(-24 * time.Hour), "us-east-1", serviceS3},
+		{cred, now, "eu-west-1", serviceS3},
+		{cred, now, "us-east-1", serviceSTS},
+		// A changed secret key replaces the cached signing key.
+		{auth.Credentials{AccessKey: cred.AccessKey, SecretKey: "secret-2"}, now, "us-east-1", serviceS3},
+	}
+
+	for i, testCase := range testCases {
+		expected := getSigningKey(testCase.cred.SecretKey, testCase.t, testCase.region, testCase.stype)
+		if got := getCachedSigningKey(testCase.cred, testCase.t, testCase.region, testCase.stype); !bytes.Equal(got, expected) {
+			t.Errorf("Test %d: expected signing key %x, got %x", i+1, expected, got)
+		}
+	}
+}
+
+func BenchmarkGetSigningKey(b *testing.B) {
+	cred := auth.Credentials{AccessKey: "benchsigningkey", SecretKey: "benchsecret"}
+	now := UTCNow()
+
+	b.Run("derived", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			getSigningKey(cred.SecretKey, now, "us-east-1", serviceS3)
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			getCachedSigningKey(cred, now, "us-east-1", serviceS3)
+		}
+	})
+}
+
+func TestDoesSignatureMatchForwardedHost(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(trusted []*net.IPNet) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.trustedProxies = trusted
+		globalAPIConfig.mu.Unlock()
+	}(globalAPIConfig.trustedProxies)
+
+	_, trustedProxy, err := net.ParseCIDR("10.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	globalAPIConfig.mu.Lock()
+	globalAPIConfig.trustedProxies = []*net.IPNet{trustedProxy}
+	globalAPIConfig.mu.Unlock()
+
+	testCases := []struct {
+		remoteAddr      string
+		forwardedHost   string
+		forwardedProto  string
+		expectedErrCode APIErrorCode
+	}{
+		// (0) Forwarded host from a trusted proxy.
+		{"10.0.0.1:1234", "minio.example.com", "https", ErrNone},
+		// (1) Default port of the forwarded scheme is ignored.
+		{"10.0.0.1:1234", "minio.example.com:443", "https", ErrNone},
+		// (2) Forwarded host from an untrusted peer is ignored.
+		{"192.168.1.10:1234", "minio.example.com", "https", ErrSignatureDoesNotMatch},
+		// (3) Trusted proxy which did not forward the host.
+		{"10.0.0.1:1234", "", "", ErrSignatureDoesNotMatch},
+		// (4) Forwarded host different from the signed host.
+		{"10.0.0.1:1234", "other.example.com", "https", ErrSignatureDoesNotMatch},
+	}
+
+	for i, testCase := range testCases {
+		// The client signs the request for the public host name.
+		req := mustNewSignedRequest(http.MethodGet, "https://minio.example.com/bucket/object", 0, nil, t)
+
+		// The proxy rewrites the Host header.
+		req.Host = "127.0.0.1:9000"
+		req.RemoteAddr = testCase.remoteAddr
+		if testCase.forwardedHost != "" {
+			req.Header.Set(xhttp.XForwardedHost, testCase.forwardedHost)
+			req.Header.Set(xhttp.XForwardedProto, testCase.forwardedProto)
+		}
+
+		errCode := doesSignatureMatch(getContentSha256Cksum(req, serviceS3), req, globalSite.Region, serviceS3)
+		if errCode != testCase.expectedErrCode {
+			t.Errorf("(%d) expected to get %s, instead got %s", i, niceError(testCase.expectedErrCode), niceError(errCode))
+		}
+	}
+}
+
+func TestDoesPresignedSignatureMatchClockSkew(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(skew, presignedSkew time.Duration) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.clockSkew = skew
+		globalAPIConfig.clockSkewPresigned = presignedSkew
+		globalAPIConfig.mu.Unlock()
+	}(globalAPIConfig.clockSkew, globalAPIConfig.clockSkewPresigned)
+
+	now := UTCNow()
+	region := globalSite.Region
+	accessKeyID := globalActiveCred.AccessKey
+
+	testCases := []struct {
+		clockSkew          time.Duration
+		presignedClockSkew time.Duration
+		dateOffset         time.Duration
+		expected           APIErrorCode
+	}{
+		// (0) Date within the default presigned skew.
+		{0, 0, 10 * time.Minute, ErrSignatureDoesNotMatch},
+		// (1) Date beyond the default presigned skew.
+		{0, 0, 20 * time.Minute, ErrRequestNotReadyYet},
+		// (2) Strict presigned skew, not relaxed by the signed request skew.
+		{time.Hour, 5 * time.Minute, 10 * time.Minute, ErrRequestNotReadyYet},
+		// (3) Loose presigned skew.
+		{time.Minute, time.Hour, 30 * time.Minute, ErrSignatureDoesNotMatch},
+	}
+
+	for i, testCase := range testCases {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.clockSkew = testCase.clockSkew
+		globalAPIConfig.clockSkewPresigned = testCase.presignedClockSkew
+		globalAPIConfig.mu.Unlock()
+
+		date := now.Add(testCase.dateOffset)
+		query := url.Values{}
+		query.Set("X-Amz-Algorithm", signV4Algorithm)
+		query.Set("X-Amz-Date", date.Format(iso8601Format))
+		query.Set("X-Amz-Expires", "60")
+		query.Set("X-Amz-Signature", "badsignature")
+		query.Set("X-Amz-SignedHeaders", "host")
+		query.Set("X-Amz-Credential", fmt.Sprintf("%s/%s/%s/s3/aws4_request", accessKeyID, date.Format(yyyymmdd), region))
+
+		req, e := http.NewRequest(http.MethodGet, "http://host/a/b?"+query.Encode(), nil)
+		if e != nil {
+			t.Fatalf("(%d) failed to create http.Request, got %v", i, e)
+		}
+		req.ParseForm()
+
+		err := doesPresignedSignatureMatch(unsignedPayload, req, region, serviceS3)
+		if err != testCase.expected {
+			t.Errorf("(%d) expected to get %s, instead got %s", i, niceError(testCase.expected), niceError(err))
+		}
+	}
+}
@@ -54,7 +54,7 @@ func getChunkSignature(cred auth.Credentials, seedSignature string, region strin
 		hashedChunk
 
 	// Get hmac signing key.
-	signingKey := getSigningKey(cred.SecretKey, date, region, serviceS3)
+	signingKey := getCachedSigningKey(cred, date, region, serviceS3)
 
 	// Calculate signature.
 	newSignature := getSignature(signingKey, stringToSign)
@@ -120,13 +120,13 @@ func calculateSeedSignature(r *http.Request) (cred auth.Credentials, signature s
 	queryStr := req.Form.Encode()
 
 	// Get canonical request.
-	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, payload, queryStr, req.URL.Path, req.Method)
+	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, payload, queryStr, signedRequestPath(&req, req.URL.EscapedPath()), req.Method)
 
 	// Get string to sign from canonical request.
 	stringToSign := getStringToSign(canonicalRequest, date, signV4Values.Credential.getScope())
 
 	// Get hmac signing key.
-	signingKey := getSigningKey(cred.SecretKey, signV4Values.Credential.scope.date, region, serviceS3)
+	signingKey := getCachedSigningKey(cred, signV4Values.Credential.scope.date, region, serviceS3)
 
 	// Calculate signature.
 	newSignature := getSignature(signingKey, stringToSign)
@@ -135,6 +135,7 @@ func calculateSeedSignature(r *http.Request) (cred auth.Credentials, signature s
 	if !compareSignatureV4(newSignature, signV4Values.Signature) {
 		return cred, "", "", time.Time{}, ErrSignatureDoesNotMatch
 	}
+	setReqAuthInfo(r, signV4Values.Credential)
 
 	// Return caculated signature.
 	return cred, newSignature, region, date, ErrNone
@@ -160,6 +161,7 @@ var errChunkTooBig = errors.New("chunk too big: choose chunk size <= 16MiB")
 func newSignV4ChunkedReader(req *http.Request) (io.ReadCloser, APIErrorCode) {
 	cred, seedSignature, region, seedDate, errCode := calculateSeedSignature(req)
 	if errCode != ErrNone {
+		setAuthOutcome(req.Context(), errCode)
 		return nil, errCode
 	}
 
@@ -649,7 +649,7 @@ func signStreamingRequest(req *http.Request, accessKey, secretKey string, currTi
 	req.URL.RawQuery = strings.ReplaceAll(req.URL.Query().Encode(), "+", "%20")
 
 	// Get canonical URI.
-	canonicalURI := s3utils.EncodePath(req.URL.Path)
+	canonicalURI := getCanonicalURI(req.URL.EscapedPath())
 
 	// Get canonical request.
 	// canonicalRequest =
@@ -865,7 +865,7 @@ func preSignV4(req *http.Request, accessKeyID, secretAccessKey string, expires i
 	extractedSignedHeaders.Set("host", req.Host)
 
 	queryStr := strings.ReplaceAll(query.Encode(), "+", "%20")
-	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, unsignedPayload, queryStr, req.URL.Path, req.Method)
+	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, unsignedPayload, queryStr, req.URL.EscapedPath(), req.Method)
 	stringToSign := getStringToSign(canonicalRequest, date, scope)
 	signingKey := getSigningKey(secretAccessKey, date, region, serviceS3)
 	signature := getSignature(signingKey, stringToSign)
@@ -1004,7 +1004,7 @@ func signRequestV4(req *http.Request, accessKey, secretKey string) error {
 	req.URL.RawQuery = strings.ReplaceAll(req.URL.Query().Encode(), "+", "%20")
 
 	// Get canonical URI.
-	canonicalURI := s3utils.EncodePath(req.URL.Path)
+	canonicalURI := getCanonicalURI(req.URL.EscapedPath())
 
 	// Get canonical request.
 	// canonicalRequest =
@@ -1292,6 +1292,27 @@ func getPutNotificationURL(endPoint, bucketName string) string {
 	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
 }
 
+// return URL for bucket versioning configuration.
+func getBucketVersioningURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("versioning", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for object tagging.
+func getObjectTaggingURL(endPoint, bucketName, objectName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("tagging", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
+// return URL for object ACL.
+func getObjectACLURL(endPoint, bucketName, objectName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("acl", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
 // return URL for inserting bucket policy.
 func getPutPolicyURL(endPoint, bucketName string) string {
 	queryValue := url.Values{}
@@ -1366,6 +1387,22 @@ func getListObjectsV1URL(endPoint, bucketName, prefix, maxKeys, encodingType str
 	return makeTestTargetURL(endPoint, bucketName, prefix, queryValue)
 }
 
+// return URL for listing object versions in the bucket.
+func getListObjectVersionsURL(endPoint, bucketName, maxKeys, keyMarker, versionIDMarker string) string {
+	queryValue := url.Values{}
+	queryValue.Set("versions", "")
+	if maxKeys != "" {
+		queryValue.Set("max-keys", maxKeys)
+	}
+	if keyMarker != "" {
+		queryValue.Set("key-marker", keyMarker)
+	}
+	if versionIDMarker != "" {
+		queryValue.Set("version-id-marker", versionIDMarker)
+	}
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
 // return URL for listing objects in the bucket with V2 API.
 func getListObjectsV2URL(endPoint, bucketName, prefix, maxKeys, fetchOwner, encodingType string) string {
 	queryValue := url.Values{}
@@ -1960,6 +1997,9 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "HeadBucket":
 			// Register HeadBucket handler.
 			bucket.Methods(http.MethodHead).HandlerFunc(api.HeadBucketHandler)
+		case "PutBucket":
+			// Register PutBucket handler.
+			bucket.Methods(http.MethodPut).HandlerFunc(api.PutBucketHandler)
 		case "DeleteMultipleObjects":
 			// Register DeleteMultipleObjects handler.
 			bucket.Methods(http.MethodPost).HandlerFunc(api.DeleteMultipleObjectsHandler).Queries("delete", "")
@@ -191,22 +191,43 @@ const (
 
 // isMaxObjectSize - verify if max object size
 func isMaxObjectSize(size int64) bool {
-	return size > globalMaxObjectSize
+	return size > globalAPIConfig.getObjectMaxSize()
+}
+
+// maxObjectSizeReader fails with errDataTooLarge once more than the
+// maximum object size has been read, enforcing the limit on uploads
+// whose size is not known upfront.
+type maxObjectSizeReader struct {
+	io.Reader
+	remaining int64
+}
+
+func newMaxObjectSizeReader(r io.Reader) io.Reader {
+	return &maxObjectSizeReader{Reader: r, remaining: globalAPIConfig.getObjectMaxSize()}
+}
+
+func (r *maxObjectSizeReader) Read(p []byte) (n int, err error) {
+	n, err = r.Reader.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n, errDataTooLarge
+	}
+	return n, err
 }
 
 // // Check if part size is more than maximum allowed size.
 func isMaxAllowedPartSize(size int64) bool {
-	return size > globalMaxPartSize
+	return size > globalAPIConfig.getMultipartMaxPartSize()
 }
 
 // Check if part size is more than or equal to minimum allowed size.
 func isMinAllowedPartSize(size int64) bool {
-	return size >= globalMinPartSize
+	return size >= globalAPIConfig.getMultipartMinPartSize()
 }
 
 // isMaxPartNumber - Check if part ID is greater than the maximum allowed ID.
 func isMaxPartID(partID int) bool {
-	return partID > globalMaxPartID
+	return partID > globalAPIConfig.getMultipartMaxParts()
 }
 
 func contains(slice interface{}, elem interface{}) bool {
@@ -1070,9 +1091,14 @@ func newTLSConfig(getCert certs.GetCertificateFunc) *tls.Config {
 		return nil
 	}
 
+	minVersion, err := parseTLSVersion(env.Get(api.EnvAPITLSMinVersion, "1.2"))
+	if err != nil {
+		logger.Fatal(err, fmt.Sprintf("Invalid %s value in environment variable", api.EnvAPITLSMinVersion))
+	}
+
 	tlsConfig := &tls.Config{
 		PreferServerCipherSuites: true,
-		MinVersion:               tls.VersionTLS12,
+		MinVersion:               minVersion,
 		NextProtos:               []string{"http/1.1", "h2"},
 		GetCertificate:           getCert,
 		ClientSessionCache:       tls.NewLRUClientSessionCache(tlsClientSessionCacheSize),
@@ -1083,7 +1109,12 @@ func newTLSConfig(getCert certs.GetCertificateFunc) *tls.Config {
 		tlsConfig.ClientAuth = tls.RequestClientCert
 	}
 
-	if secureCiphers := env.Get(api.EnvAPISecureCiphers, config.EnableOn) == config.EnableOn; secureCiphers {
+	if cipherSuites := env.Get(api.EnvAPITLSCipherSuites, ""); cipherSuites != "" {
+		tlsConfig.CipherSuites, err = parseTLSCipherSuites(cipherSuites)
+		if err != nil {
+			logger.Fatal(err, fmt.Sprintf("Invalid %s value in environment variable", api.EnvAPITLSCipherSuites))
+		}
+	} else if secureCiphers := env.Get(api.EnvAPISecureCiphers, config.EnableOn) == config.EnableOn; secureCiphers {
 		tlsConfig.CipherSuites = fips.TLSCiphers()
 	} else {
 		tlsConfig.CipherSuites = fips.TLSCiphersBackwardCompatible()
@@ -1092,6 +1123,41 @@ func newTLSConfig(getCert certs.GetCertificateFunc) *tls.Config {
 	return tlsConfig
 }
 
+// parseTLSVersion parses a TLS protocol version such as "1.2".
+func parseTLSVersion(s string) (uint16, error) {
+	switch s {
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, fmt.Errorf("unsupported TLS version %q, expected one of 1.0, 1.1, 1.2 or 1.3", s)
+}
+
+// parseTLSCipherSuites parses a comma separated list of TLS cipher
+// suite names, such as TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384. Only
+// cipher suites without known security issues are accepted. Cipher
+// suites of TLS 1.3 are not configurable.
+func parseTLSCipherSuites(s string) ([]uint16, error) {
+	supported := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		supported[suite.Name] = suite.ID
+	}
+	var cipherSuites []uint16
+	for _, name := range strings.Split(s, config.ValueSeparator) {
+		id, ok := supported[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS cipher suite %q", name)
+		}
+		cipherSuites = append(cipherSuites, id)
+	}
+	return cipherSuites, nil
+}
+
 /////////// Types and functions for OpenID IAM testing
 
 // OpenIDClientAppParams - contains openID client application params, used in
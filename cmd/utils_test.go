@@ -19,11 +19,13 @@ package cmd
 
 import (
 	"bytes"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"reflect"
@@ -57,6 +59,35 @@ func TestMaxObjectSize(t *testing.T) {
 	}
 }
 
+// Tests maximum object size configured with object_max_size.
+func TestMaxObjectSizeConfigured(t *testing.T) {
+	defer func(objectMaxSize int64) {
+		globalAPIConfig.mu.Lock()
+		globalAPIConfig.objectMaxSize = objectMaxSize
+		globalAPIConfig.mu.Unlock()
+	}(globalAPIConfig.objectMaxSize)
+
+	globalAPIConfig.mu.Lock()
+	globalAPIConfig.objectMaxSize = 1 << 20
+	globalAPIConfig.mu.Unlock()
+
+	sizes := []struct {
+		isMax bool
+		size  int64
+	}{
+		{false, 1<<20 - 1},
+		{false, 1 << 20},
+		{true, 1<<20 + 1},
+		{true, globalMaxObjectSize},
+	}
+	for i, s := range sizes {
+		isMax := isMaxObjectSize(s.size)
+		if isMax != s.isMax {
+			t.Errorf("Test %d: Expected %t, got %t", i+1, s.isMax, isMax)
+		}
+	}
+}
+
 // Tests minimum allowed part size.
 func TestMinAllowedPartSize(t *testing.T) {
 	sizes := []struct {
@@ -514,3 +545,96 @@ func TestTimedValue(t *testing.T) {
 		t.Fatalf("expected time to be un-equal: %s == %s", t1, t3)
 	}
 }
+
+// Tests parsing of the minimum TLS version.
+func TestParseTLSVersion(t *testing.T) {
+	testCases := []struct {
+		version         string
+		expectedVersion uint16
+		expectedErr     bool
+	}{
+		{"1.0", tls.VersionTLS10, false},
+		{"1.1", tls.VersionTLS11, false},
+		{"1.2", tls.VersionTLS12, false},
+		{"1.3", tls.VersionTLS13, false},
+		{"", 0, true},
+		{"1.4", 0, true},
+		{"TLSv1.2", 0, true},
+	}
+	for i, testCase := range testCases {
+		version, err := parseTLSVersion(testCase.version)
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.expectedErr, err)
+		}
+		if version != testCase.expectedVersion {
+			t.Errorf("Test %d: expected version %x, got %x", i+1, testCase.expectedVersion, version)
+		}
+	}
+}
+
+// Tests parsing of the TLS cipher suite allow-list.
+func TestParseTLSCipherSuites(t *testing.T) {
+	testCases := []struct {
+		cipherSuites         string
+		expectedCipherSuites []uint16
+		expectedErr          bool
+	}{
+		{
+			cipherSuites:         "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+			expectedCipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384},
+		},
+		{
+			cipherSuites: "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384, TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+			expectedCipherSuites: []uint16{
+				tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+				tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			},
+		},
+		// Insecure cipher suites are rejected.
+		{cipherSuites: "TLS_RSA_WITH_RC4_128_SHA", expectedErr: true},
+		{cipherSuites: "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,unknown", expectedErr: true},
+	}
+	for i, testCase := range testCases {
+		cipherSuites, err := parseTLSCipherSuites(testCase.cipherSuites)
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.expectedErr, err)
+		}
+		if !reflect.DeepEqual(cipherSuites, testCase.expectedCipherSuites) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expectedCipherSuites, cipherSuites)
+		}
+	}
+}
+
+// Tests that the server refuses TLS handshakes below the minimum version.
+func TestNewTLSConfigMinVersion(t *testing.T) {
+	getCert := func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		// Fall back to the certificate of the test server.
+		return nil, nil
+	}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.TLS = newTLSConfig(getCert)
+	srv.StartTLS()
+	defer srv.Close()
+
+	testCases := []struct {
+		maxVersion  uint16
+		expectedErr bool
+	}{
+		{tls.VersionTLS10, true},
+		{tls.VersionTLS11, true},
+		{tls.VersionTLS12, false},
+		{tls.VersionTLS13, false},
+	}
+	for i, testCase := range testCases {
+		conn, err := tls.Dial("tcp", srv.Listener.Addr().String(), &tls.Config{
+			InsecureSkipVerify: true,
+			MaxVersion:         testCase.maxVersion,
+		})
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("Test %d: expected handshake error %v, got %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil {
+			conn.Close()
+		}
+	}
+}
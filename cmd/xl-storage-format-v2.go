@@ -1390,6 +1390,11 @@ func (x *xlMetaV2) UpdateObjectVersion(fi FileInfo) error {
 				}
 				for k, v := range fi.Metadata {
 					if len(k) > len(ReservedMetadataPrefixLower) && strings.EqualFold(k[:len(ReservedMetadataPrefixLower)], ReservedMetadataPrefixLower) {
+						if v == "" {
+							// Empty internal values remove the key.
+							delete(ver.ObjectV2.MetaSys, k)
+							continue
+						}
 						ver.ObjectV2.MetaSys[k] = []byte(v)
 					} else {
 						ver.ObjectV2.MetaUser[k] = v
@@ -440,6 +440,28 @@ func (lc Lifecycle) PredictExpiryTime(obj ObjectOpts) (string, time.Time) {
 	return finalExpiryRuleID, finalExpiryDate
 }
 
+// PredictAbortMultipartTime returns the date/time after which an incomplete
+// multipart upload of the given object, initiated at the given time, is
+// aborted along with the ID of the rule which decides it.
+func (lc Lifecycle) PredictAbortMultipartTime(object string, initiated time.Time) (string, time.Time) {
+	var finalAbortDate time.Time
+	var finalAbortRuleID string
+	for _, rule := range lc.Rules {
+		if rule.Status == Disabled || rule.AbortIncompleteMultipartUpload.IsNull() {
+			continue
+		}
+		if !strings.HasPrefix(object, rule.GetPrefix()) {
+			continue
+		}
+		abortDate := ExpectedExpiryTime(initiated, int(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation))
+		if finalAbortDate.IsZero() || finalAbortDate.After(abortDate) {
+			finalAbortRuleID = rule.ID
+			finalAbortDate = abortDate
+		}
+	}
+	return finalAbortRuleID, finalAbortDate
+}
+
 // PredictTransitionTime returns the transition date/time of a given object
 // after evaluating the current lifecycle document.
 func (lc Lifecycle) PredictTransitionTime(obj ObjectOpts) (string, time.Time) {
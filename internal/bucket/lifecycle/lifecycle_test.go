@@ -162,6 +162,11 @@ func TestMarshalLifecycleConfig(t *testing.T) {
 				Expiration:                  Expiration{Date: midnightTS},
 				NoncurrentVersionTransition: NoncurrentVersionTransition{NoncurrentDays: TransitionDays(2), StorageClass: "TEST"},
 			},
+			{
+				Status:                         "Enabled",
+				Filter:                         Filter{Prefix: Prefix{string: "prefix-2", set: true}},
+				AbortIncompleteMultipartUpload: AbortIncompleteMultipartUpload{DaysAfterInitiation: ExpirationDays(7)},
+			},
 		},
 	}
 	b, err := xml.MarshalIndent(&lc, "", "\t")
@@ -614,6 +619,61 @@ func TestSetPredictionHeaders(t *testing.T) {
 	}
 }
 
+func TestPredictAbortMultipartTime(t *testing.T) {
+	lc := Lifecycle{
+		Rules: []Rule{
+			{
+				ID:                             "rule-1",
+				Status:                         "Enabled",
+				Filter:                         Filter{Prefix: Prefix{string: "uploads/", set: true}},
+				AbortIncompleteMultipartUpload: AbortIncompleteMultipartUpload{DaysAfterInitiation: 7, set: true},
+			},
+			{
+				ID:                             "rule-2",
+				Status:                         "Enabled",
+				Filter:                         Filter{Prefix: Prefix{string: "uploads/small/", set: true}},
+				AbortIncompleteMultipartUpload: AbortIncompleteMultipartUpload{DaysAfterInitiation: 1, set: true},
+			},
+			{
+				ID:                             "rule-3",
+				Status:                         "Disabled",
+				Filter:                         Filter{Prefix: Prefix{string: "disabled/", set: true}},
+				AbortIncompleteMultipartUpload: AbortIncompleteMultipartUpload{DaysAfterInitiation: 1, set: true},
+			},
+			{
+				ID:         "rule-4",
+				Status:     "Enabled",
+				Filter:     Filter{Prefix: Prefix{string: "expiring/", set: true}},
+				Expiration: Expiration{Days: 1, set: true},
+			},
+		},
+	}
+
+	initiated := time.Date(2020, time.March, 15, 10, 10, 10, 0, time.UTC)
+	testCases := []struct {
+		object         string
+		expectedRuleID string
+		expectedDate   time.Time
+	}{
+		{"uploads/object", "rule-1", time.Date(2020, time.March, 23, 0, 0, 0, 0, time.UTC)},
+		// The earliest abort date wins among overlapping rules.
+		{"uploads/small/object", "rule-2", time.Date(2020, time.March, 17, 0, 0, 0, 0, time.UTC)},
+		{"disabled/object", "", time.Time{}},
+		{"expiring/object", "", time.Time{}},
+		{"other/object", "", time.Time{}},
+	}
+
+	for i, tc := range testCases {
+		ruleID, date := lc.PredictAbortMultipartTime(tc.object, initiated)
+		if ruleID != tc.expectedRuleID {
+			t.Fatalf("Test %d: Expected rule ID %q but got %q", i+1, tc.expectedRuleID, ruleID)
+		}
+		if !date.Equal(tc.expectedDate) {
+			t.Fatalf("Test %d: Expected abort date %v but got %v", i+1, tc.expectedDate, date)
+		}
+	}
+}
+
 func TestTransitionTier(t *testing.T) {
 	lc := Lifecycle{
 		Rules: []Rule{
@@ -42,9 +42,10 @@ type Rule struct {
 	Prefix     Prefix     `xml:"Prefix,omitempty"`
 	Expiration Expiration `xml:"Expiration,omitempty"`
 	Transition Transition `xml:"Transition,omitempty"`
-	// FIXME: add a type to catch unsupported AbortIncompleteMultipartUpload AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
-	NoncurrentVersionExpiration NoncurrentVersionExpiration `xml:"NoncurrentVersionExpiration,omitempty"`
-	NoncurrentVersionTransition NoncurrentVersionTransition `xml:"NoncurrentVersionTransition,omitempty"`
+	// AbortIncompleteMultipartUpload aborts uploads not completed within the given number of days.
+	AbortIncompleteMultipartUpload AbortIncompleteMultipartUpload `xml:"AbortIncompleteMultipartUpload,omitempty"`
+	NoncurrentVersionExpiration    NoncurrentVersionExpiration    `xml:"NoncurrentVersionExpiration,omitempty"`
+	NoncurrentVersionTransition    NoncurrentVersionTransition    `xml:"NoncurrentVersionTransition,omitempty"`
 }
 
 var (
@@ -102,6 +103,17 @@ func (r Rule) validateNoncurrentExpiration() error {
 	return r.NoncurrentVersionExpiration.Validate()
 }
 
+func (r Rule) validateAbortIncompleteMultipartUpload() error {
+	if err := r.AbortIncompleteMultipartUpload.Validate(); err != nil {
+		return err
+	}
+	// Multipart uploads have no tags to filter on.
+	if r.AbortIncompleteMultipartUpload.set && r.Tags() != "" {
+		return errAbortIncompleteMultipartUploadTags
+	}
+	return nil
+}
+
 func (r Rule) validatePrefixAndFilter() error {
 	if !r.Prefix.set && r.Filter.IsEmpty() || r.Prefix.set && !r.Filter.IsEmpty() {
 		return errXMLNotWellFormed
@@ -179,7 +191,11 @@ func (r Rule) Validate() error {
 	if err := r.validateNoncurrentTransition(); err != nil {
 		return err
 	}
-	if !r.Expiration.set && !r.Transition.set && !r.NoncurrentVersionExpiration.set && !r.NoncurrentVersionTransition.set {
+	if err := r.validateAbortIncompleteMultipartUpload(); err != nil {
+		return err
+	}
+	if !r.Expiration.set && !r.Transition.set && !r.NoncurrentVersionExpiration.set && !r.NoncurrentVersionTransition.set &&
+		!r.AbortIncompleteMultipartUpload.set {
 		return errXMLNotWellFormed
 	}
 	return nil
@@ -61,6 +61,37 @@ func TestInvalidRules(t *testing.T) {
 	                    </Rule>`,
 			expectedErr: errInvalidRuleStatus,
 		},
+		{ // Rule with only AbortIncompleteMultipartUpload
+			inputXML: ` <Rule>
+			                  <ID>rule with abort incomplete multipart upload</ID>
+			                  <Filter><Prefix>uploads/</Prefix></Filter>
+			                  <AbortIncompleteMultipartUpload>
+			                      <DaysAfterInitiation>7</DaysAfterInitiation>
+			                  </AbortIncompleteMultipartUpload>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: nil,
+		},
+		{ // Rule with AbortIncompleteMultipartUpload missing DaysAfterInitiation
+			inputXML: ` <Rule>
+			                  <ID>rule with empty abort incomplete multipart upload</ID>
+			                  <Filter><Prefix>uploads/</Prefix></Filter>
+			                  <AbortIncompleteMultipartUpload></AbortIncompleteMultipartUpload>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: errAbortIncompleteMultipartUploadDays,
+		},
+		{ // Rule with AbortIncompleteMultipartUpload and a tag filter
+			inputXML: ` <Rule>
+			                  <ID>rule with abort incomplete multipart upload and tags</ID>
+			                  <Filter><Tag><Key>key1</Key><Value>val1</Value></Tag></Filter>
+			                  <AbortIncompleteMultipartUpload>
+			                      <DaysAfterInitiation>7</DaysAfterInitiation>
+			                  </AbortIncompleteMultipartUpload>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: errAbortIncompleteMultipartUploadTags,
+		},
 	}
 
 	for i, tc := range invalidTestCases {
@@ -20,11 +20,14 @@ package api
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net"
 	"runtime"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/minio/internal/config"
 	"github.com/minio/pkg/env"
 )
@@ -45,6 +48,27 @@ const (
 	apiDeleteCleanupInterval       = "delete_cleanup_interval"
 	apiDisableODirect              = "disable_odirect"
 	apiGzipObjects                 = "gzip_objects"
+	apiClockSkew                   = "clock_skew"
+	apiClockSkewPresigned          = "clock_skew_presigned"
+	apiRequestsRate                = "requests_rate"
+	apiRequestsRateBurst           = "requests_rate_burst"
+	apiDisableAuthTypes            = "disable_auth_types"
+	apiIPAllow                     = "ip_allow"
+	apiIPDeny                      = "ip_deny"
+	apiTrustedProxies              = "trusted_proxies"
+	apiDisableRegionCheck          = "disable_region_check"
+	apiObjectMaxSize               = "object_max_size"
+	apiMultipartMaxParts           = "multipart_max_parts"
+	apiMultipartMinPartSize        = "multipart_min_part_size"
+	apiMultipartMaxPartSize        = "multipart_max_part_size"
+	apiReadOnlyBuckets             = "read_only_buckets"
+	apiWriteOnlyBuckets            = "write_only_buckets"
+	apiAuditAnonymousSample        = "audit_anonymous_sample"
+	apiMaxBuckets                  = "max_buckets"
+	apiRequireContentSHA256        = "require_content_sha256"
+	apiBasePath                    = "base_path"
+	apiBasePathSigned              = "base_path_signed"
+	apiRejectCaseCollisions        = "reject_case_collisions"
 
 	EnvAPIRequestsMax              = "MINIO_API_REQUESTS_MAX"
 	EnvAPIRequestsDeadline         = "MINIO_API_REQUESTS_DEADLINE"
@@ -52,7 +76,10 @@ const (
 	EnvAPICorsAllowOrigin          = "MINIO_API_CORS_ALLOW_ORIGIN"
 	EnvAPIRemoteTransportDeadline  = "MINIO_API_REMOTE_TRANSPORT_DEADLINE"
 	EnvAPIListQuorum               = "MINIO_API_LIST_QUORUM"
-	EnvAPISecureCiphers            = "MINIO_API_SECURE_CIPHERS" // default "on"
+	EnvAPISecureCiphers            = "MINIO_API_SECURE_CIPHERS"  // default "on"
+	EnvAPITLSMinVersion            = "MINIO_API_TLS_MIN_VERSION" // default "1.2"
+	EnvAPITLSCipherSuites          = "MINIO_API_TLS_CIPHER_SUITES"
+	EnvAPIHSTS                     = "MINIO_API_HSTS" // default "on"
 	EnvAPIReplicationWorkers       = "MINIO_API_REPLICATION_WORKERS"
 	EnvAPIReplicationFailedWorkers = "MINIO_API_REPLICATION_FAILED_WORKERS"
 	EnvAPITransitionWorkers        = "MINIO_API_TRANSITION_WORKERS"
@@ -63,6 +90,27 @@ const (
 	EnvDeleteCleanupInterval          = "MINIO_DELETE_CLEANUP_INTERVAL"
 	EnvAPIDisableODirect              = "MINIO_API_DISABLE_ODIRECT"
 	EnvAPIGzipObjects                 = "MINIO_API_GZIP_OBJECTS"
+	EnvAPIClockSkew                   = "MINIO_API_CLOCK_SKEW"
+	EnvAPIClockSkewPresigned          = "MINIO_API_CLOCK_SKEW_PRESIGNED"
+	EnvAPIRequestsRate                = "MINIO_API_REQUESTS_RATE"
+	EnvAPIRequestsRateBurst           = "MINIO_API_REQUESTS_RATE_BURST"
+	EnvAPIDisableAuthTypes            = "MINIO_API_DISABLE_AUTH_TYPES"
+	EnvAPIIPAllow                     = "MINIO_API_IP_ALLOW"
+	EnvAPIIPDeny                      = "MINIO_API_IP_DENY"
+	EnvAPITrustedProxies              = "MINIO_API_TRUSTED_PROXIES"
+	EnvAPIDisableRegionCheck          = "MINIO_API_DISABLE_REGION_CHECK"
+	EnvAPIObjectMaxSize               = "MINIO_API_OBJECT_MAX_SIZE"
+	EnvAPIMultipartMaxParts           = "MINIO_API_MULTIPART_MAX_PARTS"
+	EnvAPIMultipartMinPartSize        = "MINIO_API_MULTIPART_MIN_PART_SIZE"
+	EnvAPIMultipartMaxPartSize        = "MINIO_API_MULTIPART_MAX_PART_SIZE"
+	EnvAPIReadOnlyBuckets             = "MINIO_API_READ_ONLY_BUCKETS"
+	EnvAPIWriteOnlyBuckets            = "MINIO_API_WRITE_ONLY_BUCKETS"
+	EnvAPIAuditAnonymousSample        = "MINIO_API_AUDIT_ANONYMOUS_SAMPLE"
+	EnvAPIMaxBuckets                  = "MINIO_API_MAX_BUCKETS"
+	EnvAPIRequireContentSHA256        = "MINIO_API_REQUIRE_CONTENT_SHA256"
+	EnvAPIBasePath                    = "MINIO_API_BASE_PATH"
+	EnvAPIBasePathSigned              = "MINIO_API_BASE_PATH_SIGNED"
+	EnvAPIRejectCaseCollisions        = "MINIO_API_REJECT_CASE_COLLISIONS"
 )
 
 // Deprecated key and ENVs
@@ -130,9 +178,105 @@ var (
 			Key:   apiGzipObjects,
 			Value: "off",
 		},
+		config.KV{
+			Key:   apiClockSkew,
+			Value: "15m",
+		},
+		config.KV{
+			Key:   apiClockSkewPresigned,
+			Value: "15m",
+		},
+		config.KV{
+			Key:   apiRequestsRate,
+			Value: "0",
+		},
+		config.KV{
+			Key:   apiRequestsRateBurst,
+			Value: "0",
+		},
+		config.KV{
+			Key:   apiDisableAuthTypes,
+			Value: "",
+		},
+		config.KV{
+			Key:   apiIPAllow,
+			Value: "",
+		},
+		config.KV{
+			Key:   apiIPDeny,
+			Value: "",
+		},
+		config.KV{
+			Key:   apiTrustedProxies,
+			Value: "",
+		},
+		config.KV{
+			Key:   apiDisableRegionCheck,
+			Value: "off",
+		},
+		config.KV{
+			Key:   apiObjectMaxSize,
+			Value: "5TiB",
+		},
+		config.KV{
+			Key:   apiMultipartMaxParts,
+			Value: "10000",
+		},
+		config.KV{
+			Key:   apiMultipartMinPartSize,
+			Value: "5MiB",
+		},
+		config.KV{
+			Key:   apiMultipartMaxPartSize,
+			Value: "5GiB",
+		},
+		config.KV{
+			Key:   apiReadOnlyBuckets,
+			Value: "",
+		},
+		config.KV{
+			Key:   apiWriteOnlyBuckets,
+			Value: "",
+		},
+		config.KV{
+			Key:   apiAuditAnonymousSample,
+			Value: "1",
+		},
+		config.KV{
+			Key:   apiMaxBuckets,
+			Value: "0",
+		},
+		config.KV{
+			Key:   apiRequireContentSHA256,
+			Value: "on",
+		},
+		config.KV{
+			Key:   apiBasePath,
+			Value: "",
+		},
+		config.KV{
+			Key:   apiBasePathSigned,
+			Value: "on",
+		},
+		config.KV{
+			Key:   apiRejectCaseCollisions,
+			Value: "off",
+		},
 	}
 )
 
+// Authentication types which may be disabled with disable_auth_types.
+const (
+	AuthTypeAnonymous       = "anonymous"
+	AuthTypePresigned       = "presigned"
+	AuthTypePresignedV2     = "presigned_v2"
+	AuthTypePostPolicy      = "post_policy"
+	AuthTypeStreamingSigned = "streaming_signed"
+	AuthTypeSigned          = "signed"
+	AuthTypeSignedV2        = "signed_v2"
+	AuthTypeSTS             = "sts"
+)
+
 // Config storage class configuration
 type Config struct {
 	RequestsMax                 int           `json:"requests_max"`
@@ -149,6 +293,27 @@ type Config struct {
 	DeleteCleanupInterval       time.Duration `json:"delete_cleanup_interval"`
 	DisableODirect              bool          `json:"disable_odirect"`
 	GzipObjects                 bool          `json:"gzip_objects"`
+	ClockSkew                   time.Duration `json:"clock_skew"`
+	ClockSkewPresigned          time.Duration `json:"clock_skew_presigned"`
+	RequestsRate                float64       `json:"requests_rate"`
+	RequestsRateBurst           int           `json:"requests_rate_burst"`
+	DisableAuthTypes            []string      `json:"disable_auth_types"`
+	IPAllow                     []*net.IPNet  `json:"ip_allow"`
+	IPDeny                      []*net.IPNet  `json:"ip_deny"`
+	TrustedProxies              []*net.IPNet  `json:"trusted_proxies"`
+	DisableRegionCheck          bool          `json:"disable_region_check"`
+	ObjectMaxSize               int64         `json:"object_max_size"`
+	MultipartMaxParts           int           `json:"multipart_max_parts"`
+	MultipartMinPartSize        int64         `json:"multipart_min_part_size"`
+	MultipartMaxPartSize        int64         `json:"multipart_max_part_size"`
+	ReadOnlyBuckets             []string      `json:"read_only_buckets"`
+	WriteOnlyBuckets            []string      `json:"write_only_buckets"`
+	AuditAnonymousSample        int           `json:"audit_anonymous_sample"`
+	MaxBuckets                  int           `json:"max_buckets"`
+	RequireContentSHA256        bool          `json:"require_content_sha256"`
+	BasePath                    string        `json:"base_path"`
+	BasePathSigned              bool          `json:"base_path_signed"`
+	RejectCaseCollisions        bool          `json:"reject_case_collisions"`
 }
 
 // UnmarshalJSON - Validate SS and RRS parity when unmarshalling JSON.
@@ -162,6 +327,51 @@ func (sCfg *Config) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(data, &aux)
 }
 
+// parseCIDRs parses a comma separated list of CIDRs, single IP
+// addresses are treated as a CIDR matching only that address.
+func parseCIDRs(v string) ([]*net.IPNet, error) {
+	if v == "" {
+		return nil, nil
+	}
+	var cidrs []*net.IPNet
+	for _, s := range strings.Split(v, ",") {
+		s = strings.TrimSpace(s)
+		if !strings.Contains(s, "/") {
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address %q", s)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			cidrs = append(cidrs, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, cidr, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, err
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	return cidrs, nil
+}
+
+// parseBuckets parses a comma separated list of bucket names.
+func parseBuckets(v string) []string {
+	if v == "" {
+		return nil
+	}
+	var buckets []string
+	for _, bucket := range strings.Split(v, ",") {
+		if bucket = strings.TrimSpace(bucket); bucket != "" {
+			buckets = append(buckets, bucket)
+		}
+	}
+	return buckets
+}
+
 // LookupConfig - lookup api config and override with valid environment settings if any.
 func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 	// remove this since we have removed this already.
@@ -186,6 +396,9 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 	if err != nil {
 		return cfg, err
 	}
+	if requestsDeadline < 0 {
+		return cfg, errors.New("invalid API requests deadline value")
+	}
 
 	clusterDeadline, err := time.ParseDuration(env.Get(EnvAPIClusterDeadline, kvs.GetWithDefault(apiClusterDeadline, DefaultKVS)))
 	if err != nil {
@@ -256,6 +469,138 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 
 	gzipObjects := env.Get(EnvAPIGzipObjects, kvs.Get(apiGzipObjects)) == config.EnableOn
 
+	clockSkew, err := time.ParseDuration(env.Get(EnvAPIClockSkew, kvs.GetWithDefault(apiClockSkew, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if clockSkew <= 0 {
+		return cfg, errors.New("invalid API clock skew value")
+	}
+
+	clockSkewPresigned, err := time.ParseDuration(env.Get(EnvAPIClockSkewPresigned, kvs.GetWithDefault(apiClockSkewPresigned, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if clockSkewPresigned <= 0 {
+		return cfg, errors.New("invalid API presigned clock skew value")
+	}
+
+	requestsRate, err := strconv.ParseFloat(env.Get(EnvAPIRequestsRate, kvs.GetWithDefault(apiRequestsRate, DefaultKVS)), 64)
+	if err != nil {
+		return cfg, err
+	}
+	if requestsRate < 0 {
+		return cfg, errors.New("invalid API requests rate value")
+	}
+
+	requestsRateBurst, err := strconv.Atoi(env.Get(EnvAPIRequestsRateBurst, kvs.GetWithDefault(apiRequestsRateBurst, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if requestsRateBurst < 0 {
+		return cfg, errors.New("invalid API requests rate burst value")
+	}
+
+	var disableAuthTypes []string
+	if v := env.Get(EnvAPIDisableAuthTypes, kvs.Get(apiDisableAuthTypes)); v != "" {
+		for _, authType := range strings.Split(v, ",") {
+			authType = strings.TrimSpace(authType)
+			switch authType {
+			case AuthTypeAnonymous, AuthTypePresigned, AuthTypePresignedV2, AuthTypePostPolicy,
+				AuthTypeStreamingSigned, AuthTypeSigned, AuthTypeSignedV2, AuthTypeSTS:
+			default:
+				return cfg, fmt.Errorf("invalid API auth type %q", authType)
+			}
+			disableAuthTypes = append(disableAuthTypes, authType)
+		}
+	}
+
+	ipAllow, err := parseCIDRs(env.Get(EnvAPIIPAllow, kvs.Get(apiIPAllow)))
+	if err != nil {
+		return cfg, err
+	}
+
+	ipDeny, err := parseCIDRs(env.Get(EnvAPIIPDeny, kvs.Get(apiIPDeny)))
+	if err != nil {
+		return cfg, err
+	}
+
+	trustedProxies, err := parseCIDRs(env.Get(EnvAPITrustedProxies, kvs.Get(apiTrustedProxies)))
+	if err != nil {
+		return cfg, err
+	}
+
+	disableRegionCheck := env.Get(EnvAPIDisableRegionCheck, kvs.Get(apiDisableRegionCheck)) == config.EnableOn
+
+	objectMaxSize, err := humanize.ParseBytes(env.Get(EnvAPIObjectMaxSize, kvs.GetWithDefault(apiObjectMaxSize, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if objectMaxSize == 0 || objectMaxSize > 5*humanize.TiByte {
+		return cfg, errors.New("invalid API object max size value, must be between 1B and 5TiB")
+	}
+
+	multipartMaxParts, err := strconv.Atoi(env.Get(EnvAPIMultipartMaxParts, kvs.GetWithDefault(apiMultipartMaxParts, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if multipartMaxParts <= 0 || multipartMaxParts > 10000 {
+		return cfg, errors.New("invalid API multipart max parts value, must be between 1 and 10000")
+	}
+
+	multipartMinPartSize, err := humanize.ParseBytes(env.Get(EnvAPIMultipartMinPartSize, kvs.GetWithDefault(apiMultipartMinPartSize, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+
+	multipartMaxPartSize, err := humanize.ParseBytes(env.Get(EnvAPIMultipartMaxPartSize, kvs.GetWithDefault(apiMultipartMaxPartSize, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if multipartMaxPartSize == 0 || multipartMaxPartSize > 5*humanize.GiByte {
+		return cfg, errors.New("invalid API multipart max part size value, must be between 1B and 5GiB")
+	}
+	if multipartMinPartSize == 0 || multipartMinPartSize > multipartMaxPartSize {
+		return cfg, errors.New("invalid API multipart min part size value, must be between 1B and the multipart max part size")
+	}
+
+	readOnlyBuckets := parseBuckets(env.Get(EnvAPIReadOnlyBuckets, kvs.Get(apiReadOnlyBuckets)))
+	writeOnlyBuckets := parseBuckets(env.Get(EnvAPIWriteOnlyBuckets, kvs.Get(apiWriteOnlyBuckets)))
+	for _, bucket := range writeOnlyBuckets {
+		for _, readOnlyBucket := range readOnlyBuckets {
+			if bucket == readOnlyBucket {
+				return cfg, fmt.Errorf("bucket %q cannot be both read-only and write-only", bucket)
+			}
+		}
+	}
+
+	auditAnonymousSample, err := strconv.Atoi(env.Get(EnvAPIAuditAnonymousSample, kvs.GetWithDefault(apiAuditAnonymousSample, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if auditAnonymousSample < 0 {
+		return cfg, errors.New("invalid API audit anonymous sample value")
+	}
+
+	maxBuckets, err := strconv.Atoi(env.Get(EnvAPIMaxBuckets, kvs.GetWithDefault(apiMaxBuckets, DefaultKVS)))
+	if err != nil {
+		return cfg, err
+	}
+	if maxBuckets < 0 {
+		return cfg, errors.New("invalid API max buckets value")
+	}
+
+	requireContentSHA256 := env.Get(EnvAPIRequireContentSHA256, kvs.GetWithDefault(apiRequireContentSHA256, DefaultKVS)) == config.EnableOn
+
+	basePath := strings.TrimSuffix(env.Get(EnvAPIBasePath, kvs.GetWithDefault(apiBasePath, DefaultKVS)), "/")
+	if basePath != "" && !strings.HasPrefix(basePath, "/") {
+		return cfg, fmt.Errorf("invalid API base path %q, must start with '/'", basePath)
+	}
+
+	basePathSigned := env.Get(EnvAPIBasePathSigned, kvs.GetWithDefault(apiBasePathSigned, DefaultKVS)) == config.EnableOn
+
+	rejectCaseCollisions := env.Get(EnvAPIRejectCaseCollisions, kvs.GetWithDefault(apiRejectCaseCollisions, DefaultKVS)) == config.EnableOn
+
 	return Config{
 		RequestsMax:                 requestsMax,
 		RequestsDeadline:            requestsDeadline,
@@ -271,5 +616,26 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 		DeleteCleanupInterval:       deleteCleanupInterval,
 		DisableODirect:              disableODirect,
 		GzipObjects:                 gzipObjects,
+		ClockSkew:                   clockSkew,
+		ClockSkewPresigned:          clockSkewPresigned,
+		RequestsRate:                requestsRate,
+		RequestsRateBurst:           requestsRateBurst,
+		DisableAuthTypes:            disableAuthTypes,
+		IPAllow:                     ipAllow,
+		IPDeny:                      ipDeny,
+		TrustedProxies:              trustedProxies,
+		DisableRegionCheck:          disableRegionCheck,
+		ObjectMaxSize:               int64(objectMaxSize),
+		MultipartMaxParts:           multipartMaxParts,
+		MultipartMinPartSize:        int64(multipartMinPartSize),
+		MultipartMaxPartSize:        int64(multipartMaxPartSize),
+		ReadOnlyBuckets:             readOnlyBuckets,
+		WriteOnlyBuckets:            writeOnlyBuckets,
+		AuditAnonymousSample:        auditAnonymousSample,
+		MaxBuckets:                  maxBuckets,
+		RequireContentSHA256:        requireContentSHA256,
+		BasePath:                    basePath,
+		BasePathSigned:              basePathSigned,
+		RejectCaseCollisions:        rejectCaseCollisions,
 	}, nil
 }
@@ -34,7 +34,7 @@ var (
 		},
 		config.HelpKV{
 			Key:         apiRequestsDeadline,
-			Description: `set the deadline for API requests waiting to be processed` + defaultHelpPostfix(apiRequestsDeadline),
+			Description: `set the deadline for API requests waiting to be processed, "0s" rejects requests right away once "requests_max" is reached` + defaultHelpPostfix(apiRequestsDeadline),
 			Optional:    true,
 			Type:        "duration",
 		},
@@ -104,5 +104,131 @@ var (
 			Optional:    true,
 			Type:        "boolean",
 		},
+		config.HelpKV{
+			Key:         apiClockSkew,
+			Description: `set the maximum allowed difference between the request date and server time for requests signed in the Authorization header` + defaultHelpPostfix(apiClockSkew),
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         apiClockSkewPresigned,
+			Description: `set how far in the future presigned URLs may be dated, their expiry is always set by X-Amz-Expires` + defaultHelpPostfix(apiClockSkewPresigned),
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         apiRequestsRate,
+			Description: `set the maximum number of requests per second allowed for each source IP, X-Forwarded-For is honored only from trusted proxies, "0" disables rate limiting` + defaultHelpPostfix(apiRequestsRate),
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiRequestsRateBurst,
+			Description: `set the maximum burst of requests allowed above the configured requests rate` + defaultHelpPostfix(apiRequestsRateBurst),
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiDisableAuthTypes,
+			Description: `set comma separated list of authentication types to reject e.g. "anonymous,presigned,presigned_v2,post_policy,streaming_signed,signed,signed_v2,sts", admin API requests are not affected`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         apiIPAllow,
+			Description: `set comma separated list of CIDRs allowed to access the server e.g. "10.0.0.0/8,fd00::/8", all addresses are allowed if empty`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         apiIPDeny,
+			Description: `set comma separated list of CIDRs denied access to the server, takes precedence over allowed CIDRs`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         apiTrustedProxies,
+			Description: `set comma separated list of CIDRs of trusted reverse proxies whose forwarded headers are honored`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         apiDisableRegionCheck,
+			Description: `set to accept signatures for any region instead of only the server region` + defaultHelpPostfix(apiDisableRegionCheck),
+			Optional:    true,
+			Type:        "boolean",
+		},
+		config.HelpKV{
+			Key:         apiObjectMaxSize,
+			Description: `set the maximum size of an object uploaded in a single operation e.g. "100GiB"` + defaultHelpPostfix(apiObjectMaxSize),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiMultipartMaxParts,
+			Description: `set the maximum number of parts of a multipart upload` + defaultHelpPostfix(apiMultipartMaxParts),
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiMultipartMinPartSize,
+			Description: `set the minimum size of every part but the last one of a multipart upload e.g. "5MiB"` + defaultHelpPostfix(apiMultipartMinPartSize),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiMultipartMaxPartSize,
+			Description: `set the maximum size of a part of a multipart upload e.g. "1GiB"` + defaultHelpPostfix(apiMultipartMaxPartSize),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiReadOnlyBuckets,
+			Description: `set comma separated list of buckets rejecting all write requests regardless of credentials` + defaultHelpPostfix(apiReadOnlyBuckets),
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         apiWriteOnlyBuckets,
+			Description: `set comma separated list of buckets rejecting all read requests regardless of credentials` + defaultHelpPostfix(apiWriteOnlyBuckets),
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         apiAuditAnonymousSample,
+			Description: `set to audit log one in N successful anonymous GET and HEAD requests, "0" logs only failed ones` + defaultHelpPostfix(apiAuditAnonymousSample),
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiMaxBuckets,
+			Description: `set the maximum number of buckets, "0" for no limit` + defaultHelpPostfix(apiMaxBuckets),
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiRequireContentSHA256,
+			Description: `set to "off" to accept signature V4 header signed requests without x-amz-content-sha256` + defaultHelpPostfix(apiRequireContentSHA256),
+			Optional:    true,
+			Type:        "boolean",
+		},
+		config.HelpKV{
+			Key:         apiBasePath,
+			Description: `set the path prefix stripped from requests when served behind a reverse proxy e.g. "/storage", a bucket named after its first segment is only reachable through the prefix` + defaultHelpPostfix(apiBasePath),
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiBasePathSigned,
+			Description: `set to "off" if clients sign requests without the base path` + defaultHelpPostfix(apiBasePathSigned),
+			Optional:    true,
+			Type:        "boolean",
+		},
+		config.HelpKV{
+			Key:         apiRejectCaseCollisions,
+			Description: `set to "on" to reject uploads of object names differing only in case from an existing object, for case-insensitive backends, every upload lists the objects of its parent prefix starting with the same three characters in any case` + defaultHelpPostfix(apiRejectCaseCollisions),
+			Optional:    true,
+			Type:        "boolean",
+		},
 	}
 )
@@ -61,10 +61,14 @@ var (
 	// MD5 checksum.
 	ErrMissingCustomerKeyMD5 = Errorf("The SSE-C request is missing the customer key MD5")
 
-	// ErrInvalidCustomerKey indicates that the SSE-C client key is not valid - e.g. not a
-	// base64-encoded string or not 256 bits long.
+	// ErrInvalidCustomerKey indicates that the SSE-C client key is not valid - e.g. it
+	// cannot decrypt the object key.
 	ErrInvalidCustomerKey = Errorf("The SSE-C client key is invalid")
 
+	// ErrMalformedCustomerKey indicates that the SSE-C client key sent by the client is
+	// not a base64-encoded string or not 256 bits long.
+	ErrMalformedCustomerKey = Errorf("The SSE-C client key is not a base64-encoded 256 bit key")
+
 	// ErrSecretKeyMismatch indicates that the provided secret key (SSE-C client key / SSE-S3 KMS key)
 	// does not match the secret key used during encrypting the object.
 	ErrSecretKeyMismatch = Errorf("The secret key does not match the secret key used during upload")
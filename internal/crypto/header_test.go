@@ -289,7 +289,7 @@ var ssecParseTests = []struct {
 			"X-Amz-Server-Side-Encryption-Customer-Key":       []string{"MzJieXRlc2xvbmdzZWNyZXRr.ZXltdXN0cHJvdmlkZWQ="}, // invalid key
 			"X-Amz-Server-Side-Encryption-Customer-Key-Md5":   []string{"7PpPLAK26ONlVUGOWlusfg=="},
 		},
-		ExpectedErr: ErrInvalidCustomerKey, // 3
+		ExpectedErr: ErrMalformedCustomerKey, // 3
 	},
 	{
 		Header: http.Header{
@@ -315,6 +315,14 @@ var ssecParseTests = []struct {
 		},
 		ExpectedErr: ErrCustomerKeyMD5Mismatch, // 6
 	},
+	{
+		Header: http.Header{
+			"X-Amz-Server-Side-Encryption-Customer-Algorithm": []string{"AES256"},
+			"X-Amz-Server-Side-Encryption-Customer-Key":       []string{"MTZieXRlbG9uZ3NlY3JldA=="}, // 128 bit client key
+			"X-Amz-Server-Side-Encryption-Customer-Key-Md5":   []string{"7PpPLAK26ONlVUGOWlusfg=="},
+		},
+		ExpectedErr: ErrMalformedCustomerKey, // 7
+	},
 }
 
 func TestSSECParse(t *testing.T) {
@@ -368,7 +376,7 @@ var ssecCopyParseTests = []struct {
 			"X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key":       []string{"MzJieXRlc2xvbmdzZWNyZXRr.ZXltdXN0cHJvdmlkZWQ="}, // invalid key
 			"X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key-Md5":   []string{"7PpPLAK26ONlVUGOWlusfg=="},
 		},
-		ExpectedErr: ErrInvalidCustomerKey, // 3
+		ExpectedErr: ErrMalformedCustomerKey, // 3
 	},
 	{
 		Header: http.Header{
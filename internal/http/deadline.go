@@ -0,0 +1,84 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package http
+
+import (
+	"errors"
+	"io"
+	"net"
+	"time"
+)
+
+// ErrReadBodyTimeout is returned when the client sends no request
+// body data within the configured read body timeout.
+var ErrReadBodyTimeout = errors.New("timed out reading the request body")
+
+// connContextKey is the request context key holding the client connection.
+type connContextKey struct{}
+
+// deadlineBody sets a read deadline on the client connection for every
+// read of the request body, a client stalling mid-upload fails the read
+// instead of holding on to the connection.
+type deadlineBody struct {
+	io.ReadCloser
+	conn    net.Conn
+	timeout time.Duration
+}
+
+func (b *deadlineBody) Read(p []byte) (n int, err error) {
+	b.conn.SetReadDeadline(time.Now().Add(b.timeout))
+	n, err = b.ReadCloser.Read(p)
+	// Clear the deadline, the connection is read in
+	// the background once the request body is consumed.
+	b.conn.SetReadDeadline(time.Time{})
+
+	var nerr net.Error
+	if errors.As(err, &nerr) && nerr.Timeout() {
+		return n, ErrReadBodyTimeout
+	}
+	return n, err
+}
+
+// deadlineConn sets a write deadline for every write to the client
+// connection, a client which stops reading the response fails the
+// write instead of holding on to the connection.
+type deadlineConn struct {
+	net.Conn
+	timeout time.Duration
+}
+
+func (c *deadlineConn) Write(p []byte) (n int, err error) {
+	c.Conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	n, err = c.Conn.Write(p)
+	c.Conn.SetWriteDeadline(time.Time{})
+	return n, err
+}
+
+// deadlineListener wraps all accepted connections with deadlineConn.
+type deadlineListener struct {
+	net.Listener
+	timeout time.Duration
+}
+
+func (l deadlineListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &deadlineConn{Conn: conn, timeout: l.timeout}, nil
+}
@@ -38,8 +38,10 @@ const (
 	CacheControl       = "Cache-Control"
 	ContentDisposition = "Content-Disposition"
 	Authorization      = "Authorization"
+	WWWAuthenticate    = "WWW-Authenticate"
 	Action             = "Action"
 	Range              = "Range"
+	Allow              = "Allow"
 )
 
 // Non standard S3 HTTP response constants
@@ -48,6 +50,13 @@ const (
 	XCacheLookup = "X-Cache-Lookup"
 )
 
+// Reverse proxy HTTP request constants
+const (
+	XForwardedFor   = "X-Forwarded-For"
+	XForwardedHost  = "X-Forwarded-Host"
+	XForwardedProto = "X-Forwarded-Proto"
+)
+
 // Standard S3 HTTP request constants
 const (
 	IfModifiedSince   = "If-Modified-Since"
@@ -58,6 +67,9 @@ const (
 	// S3 storage class
 	AmzStorageClass = "x-amz-storage-class"
 
+	// S3 retry hint in milliseconds for throttled requests
+	AmzRetryAfterMs = "x-amz-retry-after-ms"
+
 	// S3 object version ID
 	AmzVersionID    = "x-amz-version-id"
 	AmzDeleteMarker = "x-amz-delete-marker"
@@ -100,6 +112,10 @@ const (
 	// Object date/time of expiration
 	AmzExpiration = "x-amz-expiration"
 
+	// Incomplete multipart upload date/time and rule of abortion
+	AmzAbortDate   = "x-amz-abort-date"
+	AmzAbortRuleID = "x-amz-abort-rule-id"
+
 	// Dummy putBucketACL
 	AmzACL = "x-amz-acl"
 
@@ -138,6 +154,9 @@ const (
 	// Response request id.
 	AmzRequestID = "x-amz-request-id"
 
+	// Response host id, identifies the node which served the request.
+	AmzRequestHostID = "x-amz-id-2"
+
 	// Deployment id.
 	MinioDeploymentID = "x-minio-deployment-id"
 
@@ -53,6 +53,14 @@ const (
 	// DefaultReadHeaderTimeout for very slow inactive connections
 	DefaultReadHeaderTimeout = 30 * time.Second
 
+	// DefaultReadBodyTimeout for clients stalling while sending the request body,
+	// disabled by default as it applies to all connections including internode ones.
+	DefaultReadBodyTimeout = 0
+
+	// DefaultWriteResponseTimeout for clients stalling while reading the response,
+	// disabled by default as it applies to all connections including internode ones.
+	DefaultWriteResponseTimeout = 0
+
 	// DefaultMaxHeaderBytes - default maximum HTTP header size in bytes.
 	DefaultMaxHeaderBytes = 1 * humanize.MiByte
 )
@@ -62,10 +70,14 @@ type Server struct {
 	http.Server
 	Addrs           []string      // addresses on which the server listens for new connection.
 	ShutdownTimeout time.Duration // timeout used for graceful server shutdown.
-	listenerMutex   sync.Mutex    // to guard 'listener' field.
-	listener        *httpListener // HTTP listener for all 'Addrs' field.
-	inShutdown      uint32        // indicates whether the server is in shutdown or not
-	requestCount    int32         // counter holds no. of request in progress.
+	// timeout for each read of the request body, 0 disables it.
+	ReadBodyTimeout time.Duration
+	// timeout for each write of the response, 0 disables it.
+	WriteResponseTimeout time.Duration
+	listenerMutex        sync.Mutex    // to guard 'listener' field.
+	listener             *httpListener // HTTP listener for all 'Addrs' field.
+	inShutdown           uint32        // indicates whether the server is in shutdown or not
+	requestCount         int32         // counter holds no. of request in progress.
 }
 
 // GetRequestCount - returns number of request in progress.
@@ -81,6 +93,8 @@ func (srv *Server) Start(ctx context.Context) (err error) {
 		tlsConfig = srv.TLSConfig.Clone()
 	}
 	handler := srv.Handler // if srv.Handler holds non-synced state -> possible data race
+	readBodyTimeout := srv.ReadBodyTimeout
+	writeResponseTimeout := srv.WriteResponseTimeout
 
 	// Create new HTTP listener.
 	var listener *httpListener
@@ -94,6 +108,7 @@ func (srv *Server) Start(ctx context.Context) (err error) {
 
 	// Wrap given handler to do additional
 	// * return 503 (service unavailable) if the server in shutdown.
+	// * time out reads of the request body from stalled clients.
 	wrappedHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// If server is in shutdown.
 		if atomic.LoadUint32(&srv.inShutdown) != 0 {
@@ -107,6 +122,14 @@ func (srv *Server) Start(ctx context.Context) (err error) {
 		atomic.AddInt32(&srv.requestCount, 1)
 		defer atomic.AddInt32(&srv.requestCount, -1)
 
+		// HTTP/2 streams share the connection, deadlines
+		// can only be set on HTTP/1 connections.
+		if readBodyTimeout > 0 && r.ProtoMajor == 1 && r.Body != nil && r.Body != http.NoBody {
+			if conn, ok := r.Context().Value(connContextKey{}).(net.Conn); ok {
+				r.Body = &deadlineBody{ReadCloser: r.Body, conn: conn, timeout: readBodyTimeout}
+			}
+		}
+
 		// Handle request using passed handler.
 		handler.ServeHTTP(w, r)
 	})
@@ -116,11 +139,22 @@ func (srv *Server) Start(ctx context.Context) (err error) {
 	srv.listener = listener
 	srv.listenerMutex.Unlock()
 
+	if readBodyTimeout > 0 {
+		srv.ConnContext = func(ctx context.Context, c net.Conn) context.Context {
+			return context.WithValue(ctx, connContextKey{}, c)
+		}
+	}
+
+	var l net.Listener = listener
+	if writeResponseTimeout > 0 {
+		l = deadlineListener{Listener: listener, timeout: writeResponseTimeout}
+	}
+
 	// Start servicing with listener.
 	if tlsConfig != nil {
-		return srv.Server.Serve(tls.NewListener(listener, tlsConfig))
+		return srv.Server.Serve(tls.NewListener(l, tlsConfig))
 	}
-	return srv.Server.Serve(listener)
+	return srv.Server.Serve(l)
 }
 
 // Shutdown - shuts down HTTP server.
@@ -187,6 +221,18 @@ func (srv *Server) UseReadHeaderTimeout(d time.Duration) *Server {
 	return srv
 }
 
+// UseReadBodyTimeout configure the timeout for each read of the request body
+func (srv *Server) UseReadBodyTimeout(d time.Duration) *Server {
+	srv.ReadBodyTimeout = d
+	return srv
+}
+
+// UseWriteResponseTimeout configure the timeout for each write of the response
+func (srv *Server) UseWriteResponseTimeout(d time.Duration) *Server {
+	srv.WriteResponseTimeout = d
+	return srv
+}
+
 // UseHandler configure final handler for this HTTP *Server
 func (srv *Server) UseHandler(h http.Handler) *Server {
 	srv.Handler = h
@@ -220,6 +266,8 @@ func NewServer(addrs []string) *Server {
 	}
 	// This is not configurable for now.
 	httpServer.MaxHeaderBytes = DefaultMaxHeaderBytes
+	// Never wait indefinitely for clients trickling the request headers.
+	httpServer.ReadHeaderTimeout = DefaultReadHeaderTimeout
 	return httpServer
 }
 
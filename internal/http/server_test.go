@@ -18,11 +18,17 @@
 package http
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/minio/pkg/certs"
 )
@@ -88,3 +94,181 @@ func TestNewServer(t *testing.T) {
 		}
 	}
 }
+
+func TestServerShutdownDrainsRequests(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		fmt.Fprintf(w, "Hello, world")
+	})
+
+	addr := "127.0.0.1:" + getNextPort()
+	server := NewServer([]string{addr}).
+		UseHandler(handler).
+		UseShutdownTimeout(DefaultShutdownTimeout)
+	go server.Start(context.Background())
+
+	respCh := make(chan *http.Response, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		var err error
+		// Retry until the listener is ready.
+		for i := 0; i < 50; i++ {
+			var resp *http.Response
+			if resp, err = http.Get("http://" + addr); err == nil {
+				respCh <- resp
+				return
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		errCh <- err
+	}()
+
+	select {
+	case <-started:
+	case err := <-errCh:
+		t.Fatalf("unable to send request: %v", err)
+	}
+
+	shutdownCh := make(chan error, 1)
+	go func() {
+		shutdownCh <- server.Shutdown()
+	}()
+
+	// Shutdown must wait for the in-flight request.
+	select {
+	case err := <-shutdownCh:
+		t.Fatalf("server shutdown before in-flight request completed: %v", err)
+	case <-time.After(time.Second):
+	}
+
+	close(release)
+
+	if err := <-shutdownCh; err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+	select {
+	case resp := <-respCh:
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected in-flight request to succeed, got %d", resp.StatusCode)
+		}
+	case err := <-errCh:
+		t.Fatalf("in-flight request failed: %v", err)
+	}
+}
+
+func TestServerReadBodyTimeout(t *testing.T) {
+	errCh := make(chan error, 1)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := ioutil.ReadAll(r.Body)
+		errCh <- err
+	})
+
+	addr := "127.0.0.1:" + getNextPort()
+	server := NewServer([]string{addr}).
+		UseHandler(handler).
+		UseShutdownTimeout(DefaultShutdownTimeout).
+		UseReadBodyTimeout(500 * time.Millisecond)
+	go server.Start(context.Background())
+	defer server.Shutdown()
+
+	var conn net.Conn
+	var err error
+	// Retry until the listener is ready.
+	for i := 0; i < 50; i++ {
+		if conn, err = net.Dial("tcp", addr); err == nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("unable to connect: %v", err)
+	}
+	defer conn.Close()
+
+	// Send only part of the announced body and stall.
+	if _, err = io.WriteString(conn, "PUT /bucket/object HTTP/1.1\r\nHost: "+addr+"\r\nContent-Length: 1024\r\n\r\nstalled"); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err = <-errCh:
+		if err != ErrReadBodyTimeout {
+			t.Fatalf("expected %v, got %v", ErrReadBodyTimeout, err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("request body read did not time out")
+	}
+}
+
+func TestServerReadHeaderTimeout(t *testing.T) {
+	called := make(chan struct{}, 1)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called <- struct{}{}
+	})
+
+	addr := "127.0.0.1:" + getNextPort()
+	server := NewServer([]string{addr}).
+		UseHandler(handler).
+		UseShutdownTimeout(DefaultShutdownTimeout).
+		UseReadHeaderTimeout(500 * time.Millisecond)
+	go server.Start(context.Background())
+	defer server.Shutdown()
+
+	var conn net.Conn
+	var err error
+	// Retry until the listener is ready.
+	for i := 0; i < 50; i++ {
+		if conn, err = net.Dial("tcp", addr); err == nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("unable to connect: %v", err)
+	}
+	defer conn.Close()
+
+	// Trickle the request headers one byte at a time, well
+	// within any per read timeout but past the header deadline.
+	closed := make(chan []byte, 1)
+	go func() {
+		// Any error response is followed by closing the connection.
+		resp, _ := ioutil.ReadAll(conn)
+		closed <- resp
+	}()
+	header := "GET /bucket/object HTTP/1.1\r\nHost: " + addr + "\r\nX-Slow: " + strings.Repeat("a", 100) + "\r\n\r\n"
+	start := time.Now()
+	for i := 0; i < len(header); i++ {
+		select {
+		case resp := <-closed:
+			if strings.HasPrefix(string(resp), "HTTP/1.1 200") {
+				t.Fatalf("expected the connection to be closed, got %q", resp)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Fatalf("connection closed after %v", elapsed)
+			}
+			select {
+			case <-called:
+				t.Fatal("handler called for a request with incomplete headers")
+			default:
+			}
+			return
+		case <-time.After(50 * time.Millisecond):
+		}
+		if _, err = conn.Write([]byte{header[i]}); err != nil {
+			// Server closed the connection.
+			return
+		}
+	}
+	t.Fatal("request headers were read past the deadline")
+}
+
+func TestNewServerReadHeaderTimeout(t *testing.T) {
+	if server := NewServer([]string{":9000"}); server.ReadHeaderTimeout != DefaultReadHeaderTimeout {
+		t.Fatalf("expected default read header timeout %v, got %v", DefaultReadHeaderTimeout, server.ReadHeaderTimeout)
+	}
+}
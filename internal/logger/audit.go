@@ -24,6 +24,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/klauspost/compress/gzhttp"
@@ -155,6 +156,48 @@ func GetAuditEntry(ctx context.Context) *audit.Entry {
 	return nil
 }
 
+// Successful anonymous GET and HEAD requests are audit logged once in
+// every anonymousAuditSample requests, zero logs none of them.
+// Authenticated and failed requests are always logged.
+var (
+	anonymousAuditSample  uint64 = 1
+	anonymousAuditCounter uint64
+)
+
+// SetAnonymousAuditSample sets the rate at which successful anonymous
+// read requests are audit logged, once in every n requests.
+func SetAnonymousAuditSample(n int) {
+	atomic.StoreUint64(&anonymousAuditSample, uint64(n))
+}
+
+// isAnonymousRequest returns true if the request carries no credentials.
+func isAnonymousRequest(r *http.Request) bool {
+	if r.Header.Get(xhttp.Authorization) != "" {
+		return false
+	}
+	query := r.URL.Query()
+	return query.Get(xhttp.AmzCredential) == "" && query.Get(xhttp.AmzAccessKeyID) == ""
+}
+
+// skipAnonymousAudit returns true if the audit log entry of a
+// successful anonymous read request is dropped by sampling.
+func skipAnonymousAudit(r *http.Request, statusCode int) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if statusCode < http.StatusOK || statusCode >= http.StatusBadRequest {
+		return false
+	}
+	if !isAnonymousRequest(r) {
+		return false
+	}
+	sample := atomic.LoadUint64(&anonymousAuditSample)
+	if sample == 0 {
+		return true
+	}
+	return (atomic.AddUint64(&anonymousAuditCounter, 1)-1)%sample != 0
+}
+
 // AuditLog - logs audit logs to all audit targets.
 func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, reqClaims map[string]interface{}, filterKeys ...string) {
 	auditTgts := AuditTargets()
@@ -205,7 +248,11 @@ func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, reqCl
 			timeToFirstByte = st.TimeToFirstByte
 			outputBytes = int64(st.Size())
 		}
+		if skipAnonymousAudit(r, statusCode) {
+			return
+		}
 
+		entry.AccessKey = reqInfo.AccessKey
 		entry.API.Name = reqInfo.API
 		entry.API.Bucket = reqInfo.BucketName
 		entry.API.Object = reqInfo.ObjectName
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logger
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSkipAnonymousAudit(t *testing.T) {
+	defer SetAnonymousAuditSample(1)
+
+	newRequest := func(method, target string, header http.Header) *http.Request {
+		r, err := http.NewRequest(method, target, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range header {
+			r.Header[k] = v
+		}
+		return r
+	}
+
+	anonGet := newRequest(http.MethodGet, "http://localhost/bucket/object", nil)
+	anonHead := newRequest(http.MethodHead, "http://localhost/bucket/object", nil)
+	anonPut := newRequest(http.MethodPut, "http://localhost/bucket/object", nil)
+	signed := newRequest(http.MethodGet, "http://localhost/bucket/object", http.Header{
+		"Authorization": []string{"AWS4-HMAC-SHA256 Credential=minio/20221015/us-east-1/s3/aws4_request"},
+	})
+	presigned := newRequest(http.MethodGet, "http://localhost/bucket/object?X-Amz-Credential=minio", nil)
+	presignedV2 := newRequest(http.MethodGet, "http://localhost/bucket/object?AWSAccessKeyId=minio", nil)
+
+	logged := func(r *http.Request, statusCode, requests int) (n int) {
+		for i := 0; i < requests; i++ {
+			if !skipAnonymousAudit(r, statusCode) {
+				n++
+			}
+		}
+		return n
+	}
+
+	testCases := []struct {
+		sample     int
+		r          *http.Request
+		statusCode int
+		logged     int
+	}{
+		{1, anonGet, http.StatusOK, 6},
+		{3, anonGet, http.StatusOK, 2},
+		{3, anonHead, http.StatusPartialContent, 2},
+		{3, anonGet, http.StatusNotModified, 2},
+		{0, anonGet, http.StatusOK, 0},
+		{0, anonGet, http.StatusForbidden, 6},
+		{0, anonGet, http.StatusNotFound, 6},
+		{0, anonGet, http.StatusInternalServerError, 6},
+		{0, anonGet, 0, 6},
+		{0, anonPut, http.StatusOK, 6},
+		{0, signed, http.StatusOK, 6},
+		{0, presigned, http.StatusOK, 6},
+		{0, presignedV2, http.StatusOK, 6},
+	}
+
+	for i, testCase := range testCases {
+		SetAnonymousAuditSample(testCase.sample)
+		if n := logged(testCase.r, testCase.statusCode, 6); n != testCase.logged {
+			t.Errorf("Test %d: expected %d of 6 requests to be logged, got %d", i+1, testCase.logged, n)
+		}
+	}
+}
@@ -56,6 +56,7 @@ type Entry struct {
 	RemoteHost string                 `json:"remotehost,omitempty"`
 	RequestID  string                 `json:"requestID,omitempty"`
 	UserAgent  string                 `json:"userAgent,omitempty"`
+	AccessKey  string                 `json:"accessKey,omitempty"`
 	ReqClaims  map[string]interface{} `json:"requestClaims,omitempty"`
 	ReqQuery   map[string]string      `json:"requestQuery,omitempty"`
 	ReqHeader  map[string]string      `json:"requestHeader,omitempty"`